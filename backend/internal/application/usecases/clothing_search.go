@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// クローゼット検索の1ページの件数
+const (
+	DefaultClothingPageSize = 50
+	MaxClothingPageSize     = 200
+)
+
+// クローゼット検索のリクエスト
+// 空の条件は絞り込みを行わず、複数の値を指定した条件はいずれかに一致するアイテムを対象とする
+type ClothingSearchRequest struct {
+	Categories []string
+
+	// Colors 色名（"紺"・"navy" などの自由入力、標準パレットの色に変換して照合する）
+	Colors []string
+
+	Brands []string
+
+	// MinWarmth, MaxWarmth 保温レベルの範囲（0 の場合は制限なし）
+	MinWarmth int
+	MaxWarmth int
+
+	Season         entities.Season
+	Availabilities []entities.Availability
+
+	// Query 名前・ブランドの部分一致
+	Query string
+
+	// Sort 並び順（省略時は登録順）
+	Sort repositories.ClothingSort
+
+	// Order asc / desc（省略時は昇順）
+	Order string
+
+	// Limit 1ページの件数
+	// Limit・Cursor をどちらも省略した場合はページ分割せず全件を返す（カーソルのみ指定した場合は DefaultClothingPageSize）
+	Limit int
+
+	// Cursor 前のページの結果で返された NextCursor（省略時は先頭から）
+	Cursor string
+}
+
+// クローゼット検索の結果
+type ClothingSearchResult struct {
+	Items []*entities.ClothingItem
+
+	// NextCursor 次のページを取得するためのカーソル（最後のページの場合は空）
+	NextCursor string
+}
+
+// ユーザーのクローゼットを検索条件で絞り込み、並び替えて返す（Limit を指定した場合は1ページ分）
+// 条件が不正な場合は ErrInvalidClothingQuery をラップしたエラーを返す
+func (uc *ClothingUseCase) SearchClothing(userID string, req ClothingSearchRequest) (*ClothingSearchResult, error) {
+	query := repositories.ClothingQuery{
+		UserID:         userID,
+		Categories:     req.Categories,
+		Brands:         req.Brands,
+		MinWarmth:      req.MinWarmth,
+		MaxWarmth:      req.MaxWarmth,
+		Season:         req.Season,
+		Availabilities: req.Availabilities,
+		Text:           req.Query,
+		Sort:           req.Sort,
+		Limit:          req.Limit,
+	}
+
+	for _, name := range req.Colors {
+		color, ok := entities.ParseColor(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown color: %s", ErrInvalidClothingQuery, name)
+		}
+		query.Colors = append(query.Colors, color.Name)
+	}
+	if query.MinWarmth < 0 || query.MaxWarmth < 0 || (query.MaxWarmth > 0 && query.MinWarmth > query.MaxWarmth) {
+		return nil, fmt.Errorf("%w: invalid warmth range", ErrInvalidClothingQuery)
+	}
+	if query.Season != "" && !query.Season.IsValid() {
+		return nil, fmt.Errorf("%w: invalid season: %s", ErrInvalidClothingQuery, query.Season)
+	}
+	for _, availability := range query.Availabilities {
+		if !availability.IsValid() {
+			return nil, fmt.Errorf("%w: invalid availability: %s", ErrInvalidClothingQuery, availability)
+		}
+	}
+
+	if query.Sort == "" {
+		query.Sort = repositories.ClothingSortCreated
+	}
+	if !query.Sort.IsValid() {
+		return nil, fmt.Errorf("%w: invalid sort: %s", ErrInvalidClothingQuery, query.Sort)
+	}
+	switch req.Order {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, fmt.Errorf("%w: order must be asc or desc", ErrInvalidClothingQuery)
+	}
+
+	if query.Limit == 0 && req.Cursor != "" {
+		query.Limit = DefaultClothingPageSize
+	}
+	if query.Limit < 0 || query.Limit > MaxClothingPageSize {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidClothingQuery, MaxClothingPageSize)
+	}
+
+	if req.Cursor != "" {
+		after, err := decodeClothingCursor(req.Cursor, query.Sort, query.Descending)
+		if err != nil {
+			return nil, err
+		}
+		query.After = after
+	}
+
+	now := time.Now()
+	if uc.laundryCycle > 0 {
+		query.LaundryReturnCutoff = now.Add(-uc.laundryCycle)
+	}
+
+	page, err := uc.clothingRepo.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	returnFromLaundry(page.Items, now, uc.laundryCycle)
+
+	result := &ClothingSearchResult{Items: page.Items}
+	if page.Next != nil {
+		result.NextCursor = encodeClothingCursor(query.Sort, query.Descending, *page.Next)
+	}
+	return result, nil
+}
+
+// ページ分割のカーソルの内容（並び順と、前のページの最後のアイテムの並び替えキー）
+type clothingCursorToken struct {
+	Sort        repositories.ClothingSort `json:"s"`
+	Descending  bool                      `json:"d,omitempty"`
+	ID          string                    `json:"i"`
+	Name        string                    `json:"n,omitempty"`
+	WarmthLevel int                       `json:"w,omitempty"`
+	LastWornOn  string                    `json:"l,omitempty"`
+}
+
+// カーソルを URL に含められる文字列に変換
+func encodeClothingCursor(sort repositories.ClothingSort, descending bool, cursor repositories.ClothingCursor) string {
+	token := clothingCursorToken{Sort: sort, Descending: descending, ID: cursor.ID}
+	switch sort {
+	case repositories.ClothingSortName:
+		token.Name = cursor.Name
+	case repositories.ClothingSortWarmth:
+		token.WarmthLevel = cursor.WarmthLevel
+	case repositories.ClothingSortLastWorn:
+		if !cursor.LastWornOn.IsZero() {
+			token.LastWornOn = cursor.LastWornOn.UTC().Format(wearDateLayout)
+		}
+	}
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// カーソルを復元（並び順が異なるリクエストのカーソルは受け付けない）
+func decodeClothingCursor(value string, sort repositories.ClothingSort, descending bool) (*repositories.ClothingCursor, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", ErrInvalidClothingQuery)
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalid
+	}
+	var token clothingCursorToken
+	if err := json.Unmarshal(data, &token); err != nil || token.ID == "" {
+		return nil, invalid
+	}
+	if token.Sort != sort || token.Descending != descending {
+		return nil, fmt.Errorf("%w: cursor does not match sort order", ErrInvalidClothingQuery)
+	}
+
+	cursor := &repositories.ClothingCursor{ID: token.ID, Name: token.Name, WarmthLevel: token.WarmthLevel}
+	if token.LastWornOn != "" {
+		if cursor.LastWornOn, err = time.Parse(wearDateLayout, token.LastWornOn); err != nil {
+			return nil, invalid
+		}
+	}
+	return cursor, nil
+}
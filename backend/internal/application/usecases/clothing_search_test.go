@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func createTestCloset(t *testing.T, s *testStore, userID string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		item, err := entities.NewClothingItem(userID, "shirt", "シャツ", "navy", string(entities.CategoryTops))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.clothing.Create(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSearchClothingWithoutPagingReturnsWholeCloset(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	createTestCloset(t, s, "user-1", DefaultClothingPageSize+10)
+
+	result, err := uc.SearchClothing("user-1", ClothingSearchRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != DefaultClothingPageSize+10 || result.NextCursor != "" {
+		t.Errorf("items = %d cursor = %q, want the whole closet (%d) without a cursor", len(result.Items), result.NextCursor, DefaultClothingPageSize+10)
+	}
+}
+
+func TestSearchClothingPaging(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	createTestCloset(t, s, "user-1", DefaultClothingPageSize+10)
+
+	first, err := uc.SearchClothing("user-1", ClothingSearchRequest{Limit: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Items) != 40 || first.NextCursor == "" {
+		t.Fatalf("first page = %d items cursor %q, want 40 items and a cursor", len(first.Items), first.NextCursor)
+	}
+
+	// カーソルのみ指定した場合は DefaultClothingPageSize 件ずつ返す
+	second, err := uc.SearchClothing("user-1", ClothingSearchRequest{Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Items) != 20 || second.NextCursor != "" {
+		t.Errorf("second page = %d items cursor %q, want the remaining 20 items", len(second.Items), second.NextCursor)
+	}
+	seen := make(map[string]bool)
+	for _, item := range append(first.Items, second.Items...) {
+		if seen[item.ID] {
+			t.Errorf("item %s returned twice", item.ID)
+		}
+		seen[item.ID] = true
+	}
+
+	if _, err := uc.SearchClothing("user-1", ClothingSearchRequest{Limit: MaxClothingPageSize + 1}); !errors.Is(err, ErrInvalidClothingQuery) {
+		t.Errorf("limit above maximum error = %v, want ErrInvalidClothingQuery", err)
+	}
+	if _, err := uc.SearchClothing("user-1", ClothingSearchRequest{Cursor: first.NextCursor, Order: "desc"}); !errors.Is(err, ErrInvalidClothingQuery) {
+		t.Errorf("cursor with a different order error = %v, want ErrInvalidClothingQuery", err)
+	}
+}
+
+func TestSearchClothingColors(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	for _, color := range []string{"紺", "#1F2A4D", "Tan", "titanium"} {
+		item, _ := entities.NewClothingItem("user-1", "item", "シャツ", color, string(entities.CategoryTops))
+		s.clothing.Create(item)
+	}
+
+	result, err := uc.SearchClothing("user-1", ClothingSearchRequest{Colors: []string{"ネイビー"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != 2 {
+		t.Errorf("navy items = %d, want 2", len(result.Items))
+	}
+	if _, err := uc.SearchClothing("user-1", ClothingSearchRequest{Colors: []string{"titanium"}}); !errors.Is(err, ErrInvalidClothingQuery) {
+		t.Errorf("unknown colour error = %v, want ErrInvalidClothingQuery", err)
+	}
+}
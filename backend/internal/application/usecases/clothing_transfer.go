@@ -0,0 +1,573 @@
+package usecases
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// 衣服アイテムのインポート・エクスポートのファイル形式
+type ClothingFileFormat string
+
+const (
+	// ClothingFormatJSON 衣服アイテムのオブジェクトの配列（POST /api/clothing と同じフィールド名）
+	ClothingFormatJSON ClothingFileFormat = "json"
+
+	// ClothingFormatCSV 1行目をヘッダーとし、列名は JSON のフィールド名と同じ（seasons はカンマ区切り）
+	ClothingFormatCSV ClothingFileFormat = "csv"
+)
+
+// 一度にインポートできる行数の上限
+const MaxClothingImportRows = 1000
+
+// エクスポート時に1回のクエリで取得する件数
+const clothingExportPageSize = MaxClothingPageSize
+
+// CSV の列（エクスポート時の列順）
+var clothingCSVColumns = []string{
+	"id", "name", "type", "category", "color", "brand", "warmth_level", "seasons",
+	"material", "waterproof", "windproof", "breathability", "formality", "size", "notes",
+	"availability", "image_url", "thumbnail_url", "created_at",
+}
+
+// Excel で UTF-8 の CSV として開けるよう先頭に付けるバイトオーダーマーク
+const utf8BOM = "\ufeff"
+
+// 表計算ソフトが数式の開始とみなす先頭の文字
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// インポート・エクスポートする衣服アイテムの1件
+type ClothingRecord struct {
+	// ID エクスポート時のみ出力（インポート時は無視して新しいIDを割り当てる）
+	ID string `json:"id,omitempty"`
+
+	Name          string                `json:"name"`
+	Type          string                `json:"type"`
+	Category      string                `json:"category"`
+	Color         string                `json:"color"`
+	Brand         string                `json:"brand"`
+	WarmthLevel   int                   `json:"warmth_level"`
+	Seasons       []entities.Season     `json:"seasons"`
+	Material      string                `json:"material"`
+	Waterproof    bool                  `json:"waterproof"`
+	Windproof     bool                  `json:"windproof"`
+	Breathability int                   `json:"breathability"`
+	Formality     int                   `json:"formality"`
+	Size          string                `json:"size"`
+	Notes         string                `json:"notes"`
+	Availability  entities.Availability `json:"availability"`
+	ImageURL      string                `json:"image_url"`
+	ThumbnailURL  string                `json:"thumbnail_url"`
+
+	// CreatedAt エクスポート時のみ出力（インポート時は無視してインポートした日時とする）
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// インポートの結果
+// いずれかの行にエラーがある場合は1件も登録しない
+type ClothingImportResult struct {
+	DryRun bool `json:"dry_run"`
+
+	// Total ファイルに含まれていた行数（CSV のヘッダー行を除く）
+	Total int `json:"total"`
+
+	// Imported 登録した件数（ドライランの場合とエラーがある場合は0）
+	Imported int `json:"imported"`
+
+	Errors []ClothingImportRowError `json:"errors"`
+
+	// Items 登録したアイテム（ドライランの場合は登録される内容で、ID は未割り当て。エラーがある場合は空）
+	Items []*entities.ClothingItem `json:"items"`
+}
+
+// インポートの行ごとのエラー
+type ClothingImportRowError struct {
+	// Row データの行番号（1始まり、CSV のヘッダー行は含まない）
+	Row int `json:"row"`
+
+	// Line CSV ファイル上の行番号（JSON の場合は0）
+	Line int `json:"line,omitempty"`
+
+	Error string `json:"error"`
+}
+
+// 読み込んだ1行（値の形式が不正な場合は err を設定する）
+type clothingImportRow struct {
+	line   int
+	record ClothingRecord
+	err    error
+}
+
+// 定義済みのファイル形式かを確認
+func (f ClothingFileFormat) IsValid() bool {
+	return f == ClothingFormatJSON || f == ClothingFormatCSV
+}
+
+// CSV・JSON のファイルから衣服アイテムをまとめて登録
+// 各行を ClothingItem.Validate で検証し、いずれかの行にエラーがある場合は1件も登録せずに行ごとのエラーを返す
+// dryRun が true の場合は検証のみ行い、登録はしない
+// ファイル自体が読み込めない場合は ErrInvalidClothingImport をラップしたエラーを返す
+func (uc *ClothingUseCase) ImportClothing(userID string, format ClothingFileFormat, body io.Reader, dryRun bool) (*ClothingImportResult, error) {
+	var rows []clothingImportRow
+	var err error
+	switch format {
+	case ClothingFormatJSON:
+		rows, err = readClothingJSON(body)
+	case ClothingFormatCSV:
+		rows, err = readClothingCSV(body)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedClothingFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows to import", ErrInvalidClothingImport)
+	}
+
+	result := &ClothingImportResult{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Errors: []ClothingImportRowError{},
+		Items:  []*entities.ClothingItem{},
+	}
+	now := time.Now()
+	items := make([]*entities.ClothingItem, 0, len(rows))
+	for i, row := range rows {
+		err := row.err
+		if err == nil {
+			var item *entities.ClothingItem
+			if item, err = newClothingItem(row.record.createRequest(userID), now); err == nil {
+				items = append(items, item)
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, ClothingImportRowError{Row: i + 1, Line: row.line, Error: err.Error()})
+		}
+	}
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+	if dryRun {
+		result.Items = items
+		return result, nil
+	}
+
+	// インメモリの UnitOfWork は途中で失敗してもロールバックできないため、
+	// 登録前に全アイテムの ID を割り当てて既存のアイテムと重複しないことを確かめ、Create が失敗する条件を先に取り除く
+	for _, item := range items {
+		item.ID = entities.NewID()
+	}
+	err = uc.uow.Do(func(tx repositories.Transaction) error {
+		for i, item := range items {
+			if _, err := tx.Clothing().GetByID(item.ID); err == nil {
+				return fmt.Errorf("failed to create clothing item (row %d): %w", i+1, repositories.ErrDuplicateKey)
+			}
+		}
+		for i, item := range items {
+			if err := tx.Clothing().Create(item); err != nil {
+				return fmt.Errorf("failed to create clothing item (row %d): %w", i+1, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = len(items)
+	result.Items = items
+	return result, nil
+}
+
+// ユーザーのクローゼットを登録順に書き出す
+// 全件をメモリに読み込まないよう、ページ単位で取得して書き出す
+func (uc *ClothingUseCase) ExportClothing(userID string, format ClothingFileFormat, w io.Writer) error {
+	var writer clothingRecordWriter
+	switch format {
+	case ClothingFormatJSON:
+		writer = &clothingJSONWriter{w: w}
+	case ClothingFormatCSV:
+		writer = &clothingCSVWriter{w: w, csv: csv.NewWriter(w)}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedClothingFormat, format)
+	}
+
+	query := repositories.ClothingQuery{
+		UserID: userID,
+		Sort:   repositories.ClothingSortCreated,
+		Limit:  clothingExportPageSize,
+	}
+	now := time.Now()
+	for {
+		page, err := uc.clothingRepo.Query(query)
+		if err != nil {
+			return err
+		}
+		returnFromLaundry(page.Items, now, uc.laundryCycle)
+
+		for _, item := range page.Items {
+			if err := writer.Write(clothingRecordFrom(item)); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if page.Next == nil {
+			break
+		}
+		query.After = page.Next
+	}
+	return writer.Close()
+}
+
+// 登録リクエストに変換
+func (rec *ClothingRecord) createRequest(userID string) CreateClothingRequest {
+	return CreateClothingRequest{
+		UserID:        userID,
+		Name:          rec.Name,
+		Type:          rec.Type,
+		Category:      rec.Category,
+		Color:         rec.Color,
+		Brand:         rec.Brand,
+		ImageURL:      rec.ImageURL,
+		ThumbnailURL:  rec.ThumbnailURL,
+		WarmthLevel:   rec.WarmthLevel,
+		Seasons:       rec.Seasons,
+		Material:      rec.Material,
+		Waterproof:    rec.Waterproof,
+		Windproof:     rec.Windproof,
+		Breathability: rec.Breathability,
+		Formality:     rec.Formality,
+		Size:          rec.Size,
+		Notes:         rec.Notes,
+		Availability:  rec.Availability,
+	}
+}
+
+// 衣服アイテムをエクスポートする形式に変換
+func clothingRecordFrom(item *entities.ClothingItem) ClothingRecord {
+	createdAt := item.CreatedAt
+	return ClothingRecord{
+		ID:            item.ID,
+		Name:          item.Name,
+		Type:          item.Type,
+		Category:      item.Category,
+		Color:         item.Color,
+		Brand:         item.Brand,
+		WarmthLevel:   item.WarmthLevel,
+		Seasons:       item.Seasons,
+		Material:      item.Material,
+		Waterproof:    item.Waterproof,
+		Windproof:     item.Windproof,
+		Breathability: item.Breathability,
+		Formality:     item.Formality,
+		Size:          item.Size,
+		Notes:         item.Notes,
+		Availability:  item.CurrentAvailability(),
+		ImageURL:      item.ImageURL,
+		ThumbnailURL:  item.ThumbnailURL,
+		CreatedAt:     &createdAt,
+	}
+}
+
+// JSON の配列を1件ずつ読み込む（値の型が合わない行は行ごとのエラーとする）
+func readClothingJSON(body io.Reader) ([]clothingImportRow, error) {
+	decoder := json.NewDecoder(body)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, fmt.Errorf("%w: JSON body must be an array of clothing items", ErrInvalidClothingImport)
+	}
+
+	var rows []clothingImportRow
+	for decoder.More() {
+		if len(rows) >= MaxClothingImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows can be imported at once", ErrInvalidClothingImport, MaxClothingImportRows)
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidClothingImport, err)
+		}
+
+		var row clothingImportRow
+		if err := json.Unmarshal(raw, &row.record); err != nil {
+			row.err = fmt.Errorf("invalid row: %w", err)
+		}
+		rows = append(rows, row)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidClothingImport, err)
+	}
+	return rows, nil
+}
+
+// ヘッダー付きの CSV を読み込む（列の順序は任意、id・created_at 列は無視する）
+func readClothingCSV(body io.Reader) ([]clothingImportRow, error) {
+	buffered := bufio.NewReader(body)
+	if bom, err := buffered.Peek(len(utf8BOM)); err == nil && string(bom) == utf8BOM {
+		buffered.Discard(len(utf8BOM))
+	}
+	reader := csv.NewReader(buffered)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: CSV header row is required", ErrInvalidClothingImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidClothingImport, err)
+	}
+	columns := make([]string, len(header))
+	seen := make(map[string]bool)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !containsColumn(name) {
+			return nil, fmt.Errorf("%w: unknown CSV column: %s", ErrInvalidClothingImport, header[i])
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate CSV column: %s", ErrInvalidClothingImport, header[i])
+		}
+		seen[name] = true
+		columns[i] = name
+	}
+
+	var rows []clothingImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(rows) >= MaxClothingImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows can be imported at once", ErrInvalidClothingImport, MaxClothingImportRows)
+		}
+
+		// 列数が合わない行は行ごとのエラーとし、それ以外の構文エラーは以降の行を正しく読めないためファイル全体のエラーとする
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidClothingImport, err)
+		}
+		if isBlankCSVRecord(record) {
+			continue // 表計算ソフトが出力する空行
+		}
+		line, _ := reader.FieldPos(0)
+		row := clothingImportRow{line: line}
+		if err != nil {
+			row.err = fmt.Errorf("expected %d columns but got %d", len(columns), len(record))
+		} else {
+			row.err = parseClothingCSVRecord(columns, record, &row.record)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// CSV の1行を列名に従って変換（数値・真偽値の空欄は未設定として扱う）
+func parseClothingCSVRecord(columns []string, values []string, rec *ClothingRecord) error {
+	for i, column := range columns {
+		value := unescapeCSVFormula(strings.TrimSpace(values[i]))
+		var err error
+		switch column {
+		case "name":
+			rec.Name = value
+		case "type":
+			rec.Type = value
+		case "category":
+			rec.Category = value
+		case "color":
+			rec.Color = value
+		case "brand":
+			rec.Brand = value
+		case "warmth_level":
+			rec.WarmthLevel, err = parseCSVInt(value)
+		case "seasons":
+			rec.Seasons = parseCSVSeasons(value)
+		case "material":
+			rec.Material = value
+		case "waterproof":
+			rec.Waterproof, err = parseCSVBool(value)
+		case "windproof":
+			rec.Windproof, err = parseCSVBool(value)
+		case "breathability":
+			rec.Breathability, err = parseCSVInt(value)
+		case "formality":
+			rec.Formality, err = parseCSVInt(value)
+		case "size":
+			rec.Size = value
+		case "notes":
+			rec.Notes = value
+		case "availability":
+			rec.Availability = entities.Availability(value)
+		case "image_url":
+			rec.ImageURL = value
+		case "thumbnail_url":
+			rec.ThumbnailURL = value
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s: %q", column, value)
+		}
+	}
+	return nil
+}
+
+func parseCSVInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func parseCSVBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// 季節の一覧（"春,秋" や "春、秋" のように区切る）
+func parseCSVSeasons(value string) []entities.Season {
+	var seasons []entities.Season
+	for _, s := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '、' }) {
+		if s = strings.TrimSpace(s); s != "" {
+			seasons = append(seasons, entities.Season(s))
+		}
+	}
+	return seasons
+}
+
+func isBlankCSVRecord(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func containsColumn(name string) bool {
+	for _, column := range clothingCSVColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+// エクスポートの書き出し先（Flush はページごと、Close は最後に1回呼び出す）
+type clothingRecordWriter interface {
+	Write(rec ClothingRecord) error
+	Flush() error
+	Close() error
+}
+
+// JSON の配列として1件ずつ書き出す
+type clothingJSONWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (jw *clothingJSONWriter) Write(rec ClothingRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if jw.count == 0 {
+		separator = "[\n"
+	}
+	jw.count++
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *clothingJSONWriter) Flush() error {
+	return nil
+}
+
+func (jw *clothingJSONWriter) Close() error {
+	end := "\n]\n"
+	if jw.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	return err
+}
+
+// ヘッダー付きの CSV として1行ずつ書き出す
+type clothingCSVWriter struct {
+	w             io.Writer
+	csv           *csv.Writer
+	headerWritten bool
+}
+
+func (cw *clothingCSVWriter) Write(rec ClothingRecord) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	seasons := make([]string, len(rec.Seasons))
+	for i, season := range rec.Seasons {
+		seasons[i] = string(season)
+	}
+	createdAt := ""
+	if rec.CreatedAt != nil {
+		createdAt = rec.CreatedAt.Format(time.RFC3339)
+	}
+	record := []string{
+		rec.ID, rec.Name, rec.Type, rec.Category, rec.Color, rec.Brand, strconv.Itoa(rec.WarmthLevel), strings.Join(seasons, ","),
+		rec.Material, strconv.FormatBool(rec.Waterproof), strconv.FormatBool(rec.Windproof), strconv.Itoa(rec.Breathability), strconv.Itoa(rec.Formality), rec.Size, rec.Notes,
+		string(rec.Availability), rec.ImageURL, rec.ThumbnailURL, createdAt,
+	}
+	for i, value := range record {
+		record[i] = escapeCSVFormula(value)
+	}
+	return cw.csv.Write(record)
+}
+
+// 表計算ソフトが数式として解釈する文字で始まる値の先頭に ' を付ける（CSV インジェクション対策）
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// escapeCSVFormula で付けた ' を取り除く（エクスポートしたファイルをそのままインポートできるようにする）
+func unescapeCSVFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func (cw *clothingCSVWriter) Flush() error {
+	cw.csv.Flush()
+	return cw.csv.Error()
+}
+
+// クローゼットが空の場合もヘッダー行は出力する
+func (cw *clothingCSVWriter) Close() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	return cw.Flush()
+}
+
+func (cw *clothingCSVWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+	if _, err := io.WriteString(cw.w, utf8BOM); err != nil {
+		return err
+	}
+	return cw.csv.Write(clothingCSVColumns)
+}
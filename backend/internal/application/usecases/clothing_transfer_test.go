@@ -0,0 +1,224 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// Query の呼び出しを記録する衣服リポジトリ（エクスポートがページ単位で取得することの確認用）
+type pagingClothingRepository struct {
+	repositories.ClothingRepository
+	limits []int
+}
+
+func (r *pagingClothingRepository) Query(query repositories.ClothingQuery) (*repositories.ClothingPage, error) {
+	r.limits = append(r.limits, query.Limit)
+	return r.ClothingRepository.Query(query)
+}
+
+func TestImportClothingReportsRowErrors(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+
+	body := "name,type,category,color,warmth_level\n" +
+		"シャツ,shirt,tops,navy,3\n" +
+		",shirt,tops,navy,3\n" +
+		"パンツ,pants,bottoms,black,abc\n"
+	result, err := uc.ImportClothing("user-1", ClothingFormatCSV, strings.NewReader(body), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 3 || result.Imported != 0 || len(result.Errors) != 2 {
+		t.Fatalf("result = total %d imported %d errors %+v, want 3 rows with 2 errors and nothing imported", result.Total, result.Imported, result.Errors)
+	}
+	if result.Errors[0].Row != 2 || result.Errors[0].Line != 3 || result.Errors[1].Row != 3 || result.Errors[1].Line != 4 {
+		t.Errorf("errors = %+v, want rows 2 and 3 on lines 3 and 4", result.Errors)
+	}
+
+	// エラーのある行が1つでもあれば正しい行も登録しない
+	if items, _ := s.clothing.GetByUserID("user-1"); len(items) != 0 {
+		t.Errorf("stored items = %d, want 0", len(items))
+	}
+}
+
+func TestImportClothingDryRun(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+
+	body := `[{"name":"シャツ","type":"shirt","category":"tops","color":"navy"},{"name":"コート","type":"coat","category":"outerwear","color":"black","warmth_level":7}]`
+	result, err := uc.ImportClothing("user-1", ClothingFormatJSON, strings.NewReader(body), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.DryRun || result.Imported != 0 || len(result.Items) != 2 || len(result.Errors) != 0 {
+		t.Fatalf("result = %+v, want a dry run previewing 2 items", result)
+	}
+	for _, item := range result.Items {
+		if item.ID != "" {
+			t.Errorf("dry-run item %q has ID %q, want none", item.Name, item.ID)
+		}
+	}
+	if items, _ := s.clothing.GetByUserID("user-1"); len(items) != 0 {
+		t.Errorf("stored items after dry run = %d, want 0", len(items))
+	}
+
+	result, err = uc.ImportClothing("user-1", ClothingFormatJSON, strings.NewReader(body), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 2 {
+		t.Errorf("imported = %d, want 2", result.Imported)
+	}
+	if items, _ := s.clothing.GetByUserID("user-1"); len(items) != 2 {
+		t.Errorf("stored items = %d, want 2", len(items))
+	}
+}
+
+func TestImportClothingRejectsForeignImages(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+
+	body := `[{"name":"シャツ","type":"shirt","category":"tops","color":"navy","image_url":"/api/images/user-2/clothing/a.jpg"},` +
+		`{"name":"パンツ","type":"pants","category":"bottoms","color":"black","thumbnail_url":"https://example.com/api/images/user-2/clothing/b_thumb.jpg"}]`
+	result, err := uc.ImportClothing("user-1", ClothingFormatJSON, strings.NewReader(body), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) != 2 || result.Imported != 0 {
+		t.Fatalf("result = imported %d errors %+v, want both rows rejected", result.Imported, result.Errors)
+	}
+	for _, rowErr := range result.Errors {
+		if !strings.Contains(rowErr.Error, ErrForeignImage.Error()) {
+			t.Errorf("row %d error = %q, want a foreign image error", rowErr.Row, rowErr.Error)
+		}
+	}
+}
+
+func TestImportClothingRejectsUnreadableFiles(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+
+	for name, test := range map[string]struct {
+		format ClothingFileFormat
+		body   string
+	}{
+		"json object":    {ClothingFormatJSON, `{"name":"シャツ"}`},
+		"empty csv":      {ClothingFormatCSV, ""},
+		"unknown column": {ClothingFormatCSV, "name,price\nシャツ,1000\n"},
+		"header only":    {ClothingFormatCSV, "name,type,category,color\n"},
+	} {
+		if _, err := uc.ImportClothing("user-1", test.format, strings.NewReader(test.body), false); !errors.Is(err, ErrInvalidClothingImport) {
+			t.Errorf("%s: error = %v, want ErrInvalidClothingImport", name, err)
+		}
+	}
+}
+
+func TestExportClothingCSV(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	item, err := entities.NewClothingItem("user-1", "shirt", "シャツ", "navy", string(entities.CategoryTops))
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.Notes = "=HYPERLINK(\"https://example.com\")"
+	item.Brand = "@brand"
+	if err := s.clothing.Create(item); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := uc.ExportClothing("user-1", ClothingFormatCSV, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), utf8BOM) {
+		t.Fatalf("export does not start with a UTF-8 BOM: %q", out.String())
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(out.String(), utf8BOM))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || strings.Join(records[0], ",") != strings.Join(clothingCSVColumns, ",") {
+		t.Fatalf("records = %q, want the header and one row", records)
+	}
+
+	// 数式として解釈される値は ' を付けて書き出す
+	row := make(map[string]string)
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if row["notes"] != "'"+item.Notes || row["brand"] != "'@brand" || row["name"] != item.Name {
+		t.Errorf("row = notes %q brand %q name %q, want formulas prefixed with '", row["notes"], row["brand"], row["name"])
+	}
+
+	// 書き出したファイルは元の値のままインポートできる
+	result, err := uc.ImportClothing("user-2", ClothingFormatCSV, bytes.NewReader(out.Bytes()), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) != 0 || len(result.Items) != 1 {
+		t.Fatalf("re-import = %+v, want 1 item", result)
+	}
+	if got := result.Items[0]; got.Notes != item.Notes || got.Brand != "@brand" {
+		t.Errorf("re-imported notes %q brand %q, want %q and %q", got.Notes, got.Brand, item.Notes, "@brand")
+	}
+}
+
+func TestExportClothingEmptyCloset(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+
+	var csvOut, jsonOut bytes.Buffer
+	if err := uc.ExportClothing("user-1", ClothingFormatCSV, &csvOut); err != nil {
+		t.Fatal(err)
+	}
+	if want := utf8BOM + strings.Join(clothingCSVColumns, ",") + "\n"; csvOut.String() != want {
+		t.Errorf("empty CSV export = %q, want only the header %q", csvOut.String(), want)
+	}
+	if err := uc.ExportClothing("user-1", ClothingFormatJSON, &jsonOut); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(jsonOut.String()) != "[]" {
+		t.Errorf("empty JSON export = %q, want []", jsonOut.String())
+	}
+}
+
+func TestExportClothingStreamsPages(t *testing.T) {
+	s := newTestStore()
+	repo := &pagingClothingRepository{ClothingRepository: s.clothing}
+	uc := NewClothingUseCase(repo, s.wearLogs, s.uow, 0)
+	createTestCloset(t, s, "user-1", clothingExportPageSize+5)
+	createTestCloset(t, s, "user-2", 3)
+
+	var out bytes.Buffer
+	if err := uc.ExportClothing("user-1", ClothingFormatCSV, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.limits) != 2 {
+		t.Fatalf("queries = %d, want 2 pages", len(repo.limits))
+	}
+	for _, limit := range repo.limits {
+		if limit != clothingExportPageSize {
+			t.Errorf("query limit = %d, want %d", limit, clothingExportPageSize)
+		}
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(out.String(), utf8BOM))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != clothingExportPageSize+6 {
+		t.Errorf("records = %d, want the header and %d rows", len(records), clothingExportPageSize+5)
+	}
+	seen := make(map[string]bool)
+	for _, record := range records[1:] {
+		if seen[record[0]] {
+			t.Errorf("item %s exported twice", record[0])
+		}
+		seen[record[0]] = true
+	}
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"fmt"
+	"time"
 
 	"forecast-app/internal/domain/entities"
 	"forecast-app/internal/domain/repositories"
@@ -9,55 +10,118 @@ import (
 
 type ClothingUseCase struct {
 	clothingRepo repositories.ClothingRepository
+	wearLogRepo  repositories.WearLogRepository
+
+	// uow 利用状況の一括変更をアトミックに実行するための UnitOfWork
+	uow repositories.UnitOfWork
+
+	// laundryCycle 洗濯中のアイテムを着用可能に戻すまでの期間（0の場合は自動で戻さない）
+	laundryCycle time.Duration
 }
 
-func NewClothingUseCase(clothingRepo repositories.ClothingRepository) *ClothingUseCase {
+func NewClothingUseCase(clothingRepo repositories.ClothingRepository, wearLogRepo repositories.WearLogRepository, uow repositories.UnitOfWork, laundryCycle time.Duration) *ClothingUseCase {
 	return &ClothingUseCase{
 		clothingRepo: clothingRepo,
+		wearLogRepo:  wearLogRepo,
+		uow:          uow,
+		laundryCycle: laundryCycle,
 	}
 }
 
 type CreateClothingRequest struct {
-	UserID      string `json:"user_id"`
-	Name        string `json:"name"`
-	Category    string `json:"category"`
-	Color       string `json:"color"`
-	Brand       string `json:"brand"`
-	ImageURL    string `json:"image_url"`
-	WarmthLevel int    `json:"warmth_level"`
+	UserID        string            `json:"user_id"`
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	Category      string            `json:"category"`
+	Color         string            `json:"color"`
+	Brand         string            `json:"brand"`
+	ImageURL      string            `json:"image_url"`
+	ThumbnailURL  string            `json:"thumbnail_url"`
+	WarmthLevel   int               `json:"warmth_level"`
+	Seasons       []entities.Season `json:"seasons"`
+	Material      string            `json:"material"`
+	Waterproof    bool              `json:"waterproof"`
+	Windproof     bool              `json:"windproof"`
+	Breathability int               `json:"breathability"`
+	Formality     int               `json:"formality"`
+	Size          string            `json:"size"`
+	Notes         string            `json:"notes"`
+
+	// Availability 利用状況（登録時の省略は available、更新時の省略は変更なし）
+	Availability entities.Availability `json:"availability"`
 }
 
 func (uc *ClothingUseCase) CreateClothingItem(req CreateClothingRequest) (*entities.ClothingItem, error) {
+	clothing, err := newClothingItem(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.clothingRepo.Create(clothing); err != nil {
+		return nil, fmt.Errorf("failed to create clothing item: %w", err)
+	}
+
+	return clothing, nil
+}
+
+// リクエストから登録する衣服アイテムを作成して検証（保存はしない）
+func newClothingItem(req CreateClothingRequest, now time.Time) (*entities.ClothingItem, error) {
 	clothing := &entities.ClothingItem{
-		UserID:      req.UserID,
-		Name:        req.Name,
-		Category:    req.Category,
-		Color:       req.Color,
-		Brand:       req.Brand,
-		ImageURL:    req.ImageURL,
-		WarmthLevel: req.WarmthLevel,
+		UserID:        req.UserID,
+		Name:          req.Name,
+		Type:          req.Type,
+		Category:      req.Category,
+		Color:         req.Color,
+		Brand:         req.Brand,
+		ImageURL:      req.ImageURL,
+		ThumbnailURL:  req.ThumbnailURL,
+		WarmthLevel:   req.WarmthLevel,
+		Seasons:       req.Seasons,
+		Material:      req.Material,
+		Waterproof:    req.Waterproof,
+		Windproof:     req.Windproof,
+		Breathability: req.Breathability,
+		Formality:     req.Formality,
+		Size:          req.Size,
+		Notes:         req.Notes,
+		Availability:  entities.AvailabilityAvailable,
+		CreatedAt:     now,
+	}
+	if req.Availability != "" {
+		if err := clothing.SetAvailability(req.Availability, now); err != nil {
+			return nil, fmt.Errorf("invalid clothing item data: %w", err)
+		}
 	}
 
 	if err := clothing.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid clothing item data: %w", err)
 	}
-
-	if err := uc.clothingRepo.Create(clothing); err != nil {
-		return nil, fmt.Errorf("failed to create clothing item: %w", err)
+	if err := checkImageOwner(clothing.UserID, clothing.ImageURL, clothing.ThumbnailURL); err != nil {
+		return nil, fmt.Errorf("invalid clothing item data: %w", err)
 	}
-
 	return clothing, nil
 }
 
 func (uc *ClothingUseCase) GetUserClothing(userID string) ([]*entities.ClothingItem, error) {
-	return uc.clothingRepo.GetByUserID(userID)
+	clothing, err := uc.clothingRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	returnFromLaundry(clothing, time.Now(), uc.laundryCycle)
+	return clothing, nil
 }
 
 func (uc *ClothingUseCase) GetClothingByID(id string) (*entities.ClothingItem, error) {
-	return uc.clothingRepo.GetByID(id)
+	clothing, err := uc.clothingRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	clothing.ReturnFromLaundry(time.Now(), uc.laundryCycle)
+	return clothing, nil
 }
 
-func (uc *ClothingUseCase) UpdateClothingItem(id string, userID string, req CreateClothingRequest) (*entities.ClothingItem, error) {
+// expectedVersion が 0 より大きい場合、保存済みのバージョンと一致しなければ ErrPreconditionFailed を返す
+func (uc *ClothingUseCase) UpdateClothingItem(id string, userID string, req CreateClothingRequest, expectedVersion int) (*entities.ClothingItem, error) {
 	clothing, err := uc.clothingRepo.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("clothing item not found: %w", err)
@@ -67,16 +131,41 @@ func (uc *ClothingUseCase) UpdateClothingItem(id string, userID string, req Crea
 		return nil, fmt.Errorf("unauthorized: user does not own this clothing item")
 	}
 
+	if expectedVersion > 0 && clothing.Version != expectedVersion {
+		return nil, ErrPreconditionFailed
+	}
+
 	clothing.Name = req.Name
+	clothing.Type = req.Type
 	clothing.Category = req.Category
 	clothing.Color = req.Color
 	clothing.Brand = req.Brand
 	clothing.ImageURL = req.ImageURL
+	clothing.ThumbnailURL = req.ThumbnailURL
 	clothing.WarmthLevel = req.WarmthLevel
+	clothing.Seasons = req.Seasons
+	clothing.Material = req.Material
+	clothing.Waterproof = req.Waterproof
+	clothing.Windproof = req.Windproof
+	clothing.Breathability = req.Breathability
+	clothing.Formality = req.Formality
+	clothing.Size = req.Size
+	clothing.Notes = req.Notes
+
+	now := time.Now()
+	clothing.ReturnFromLaundry(now, uc.laundryCycle)
+	if req.Availability != "" {
+		if err := clothing.SetAvailability(req.Availability, now); err != nil {
+			return nil, fmt.Errorf("invalid clothing item data: %w", err)
+		}
+	}
 
 	if err := clothing.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid clothing item data: %w", err)
 	}
+	if err := checkImageOwner(userID, clothing.ImageURL, clothing.ThumbnailURL); err != nil {
+		return nil, fmt.Errorf("invalid clothing item data: %w", err)
+	}
 
 	if err := uc.clothingRepo.Update(clothing); err != nil {
 		return nil, fmt.Errorf("failed to update clothing item: %w", err)
@@ -97,3 +186,151 @@ func (uc *ClothingUseCase) DeleteClothingItem(id string, userID string) error {
 
 	return uc.clothingRepo.Delete(id)
 }
+
+// 一度に利用状況を変更できるアイテム数の上限
+const MaxBulkAvailabilityItems = 200
+
+// 利用状況の一括変更リクエストの構造体
+// 対象は IDs（アイテムIDの指定）または From（現在の利用状況が一致する全アイテム）のいずれか一方で指定する
+type BulkAvailabilityRequest struct {
+	IDs          []string              `json:"ids"`
+	From         entities.Availability `json:"from"`
+	Availability entities.Availability `json:"availability"`
+}
+
+// 複数の衣服アイテムの利用状況をまとめて変更（洗濯に出す・洗濯から戻すなど）
+// いずれかのアイテムが存在しない・他のユーザーのものである場合は全ての変更を取り消す
+func (uc *ClothingUseCase) BulkUpdateAvailability(userID string, req BulkAvailabilityRequest) ([]*entities.ClothingItem, error) {
+	if !req.Availability.IsValid() {
+		return nil, fmt.Errorf("invalid availability: %s", req.Availability)
+	}
+	if (len(req.IDs) == 0) == (req.From == "") {
+		return nil, fmt.Errorf("specify either ids or from")
+	}
+	if req.From != "" && !req.From.IsValid() {
+		return nil, fmt.Errorf("invalid availability: %s", req.From)
+	}
+	if len(req.IDs) > MaxBulkAvailabilityItems {
+		return nil, fmt.Errorf("at most %d items can be updated at once", MaxBulkAvailabilityItems)
+	}
+
+	now := time.Now()
+	updated := []*entities.ClothingItem{}
+	err := uc.uow.Do(func(tx repositories.Transaction) error {
+		targets, err := uc.availabilityTargets(tx, userID, req, now)
+		if err != nil {
+			return err
+		}
+
+		for _, clothing := range targets {
+			if err := clothing.SetAvailability(req.Availability, now); err != nil {
+				return err
+			}
+			if err := tx.Clothing().Update(clothing); err != nil {
+				return fmt.Errorf("failed to update clothing item %s: %w", clothing.ID, err)
+			}
+			updated = append(updated, clothing)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// 一括変更の対象アイテムを取得（洗濯の期間が経過したアイテムは着用可能に戻した状態で扱う）
+func (uc *ClothingUseCase) availabilityTargets(tx repositories.Transaction, userID string, req BulkAvailabilityRequest, now time.Time) ([]*entities.ClothingItem, error) {
+	if req.From != "" {
+		clothing, err := tx.Clothing().GetByUserID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clothing items: %w", err)
+		}
+		returnFromLaundry(clothing, now, uc.laundryCycle)
+
+		var targets []*entities.ClothingItem
+		for _, item := range clothing {
+			if item.CurrentAvailability() == req.From {
+				targets = append(targets, item)
+			}
+		}
+		return targets, nil
+	}
+
+	var targets []*entities.ClothingItem
+	seen := make(map[string]bool)
+	for _, id := range req.IDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		clothing, err := tx.Clothing().GetByID(id)
+		if err != nil || clothing.UserID != userID {
+			return nil, fmt.Errorf("clothing item not found: %s", id)
+		}
+		clothing.ReturnFromLaundry(now, uc.laundryCycle)
+		targets = append(targets, clothing)
+	}
+	return targets, nil
+}
+
+// 洗濯の期間が経過したアイテムを着用可能に戻す（保存はせず、次回の更新時に反映される）
+func returnFromLaundry(clothing []*entities.ClothingItem, now time.Time, laundryCycle time.Duration) {
+	for _, item := range clothing {
+		item.ReturnFromLaundry(now, laundryCycle)
+	}
+}
+
+// 着用可能なアイテムのみを返す（洗濯の期間が経過したアイテムは着用可能とみなす）
+func availableItems(clothing []*entities.ClothingItem, now time.Time, laundryCycle time.Duration) []*entities.ClothingItem {
+	var available []*entities.ClothingItem
+	for _, item := range clothing {
+		item.ReturnFromLaundry(now, laundryCycle)
+		if item.IsAvailable() {
+			available = append(available, item)
+		}
+	}
+	return available
+}
+
+// 衣服アイテムの着用履歴
+type WearHistory struct {
+	ClothingID string
+
+	// WearCount 着用した日数
+	WearCount int
+
+	// LastWornOn 最後に着用した日（着用記録がない場合は nil）
+	LastWornOn *time.Time
+
+	// Entries 着用記録（着用日の新しい順）
+	Entries []*entities.WearLogEntry
+}
+
+// 衣服アイテムの着用履歴を取得
+func (uc *ClothingUseCase) GetWearHistory(id string, userID string) (*WearHistory, error) {
+	clothing, err := uc.clothingRepo.GetByID(id)
+	if err != nil || clothing.UserID != userID {
+		return nil, fmt.Errorf("clothing item not found")
+	}
+
+	entries, err := uc.wearLogRepo.GetByClothingID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wear history: %w", err)
+	}
+
+	history := &WearHistory{
+		ClothingID: id,
+		WearCount:  len(entries),
+		Entries:    entries,
+	}
+	if history.Entries == nil {
+		history.Entries = []*entities.WearLogEntry{}
+	}
+	if len(entries) > 0 {
+		lastWornOn := entries[0].WornOn
+		history.LastWornOn = &lastWornOn
+	}
+	return history, nil
+}
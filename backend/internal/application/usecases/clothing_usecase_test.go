@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func TestBulkUpdateAvailability(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	own := createOutfitCloset(t, s, "user-1")
+	others := createOutfitCloset(t, s, "user-2")
+
+	updated, err := uc.BulkUpdateAvailability("user-1", BulkAvailabilityRequest{IDs: []string{own[0].ID, own[1].ID, own[0].ID}, Availability: entities.AvailabilityLaundry})
+	if err != nil {
+		t.Fatalf("BulkUpdateAvailability: %v", err)
+	}
+	if len(updated) != 2 {
+		t.Fatalf("updated = %d items, want 2", len(updated))
+	}
+	for _, item := range updated {
+		if stored, _ := s.clothing.GetByID(item.ID); stored.Availability != entities.AvailabilityLaundry || stored.AvailabilityChangedAt.IsZero() {
+			t.Errorf("stored item %s = %q since %v, want laundry", item.ID, stored.Availability, stored.AvailabilityChangedAt)
+		}
+	}
+
+	// 他のユーザーのアイテムを含む場合はどのアイテムも変更しない
+	if _, err := uc.BulkUpdateAvailability("user-1", BulkAvailabilityRequest{IDs: []string{own[2].ID, others[0].ID}, Availability: entities.AvailabilityStorage}); err == nil {
+		t.Error("updating another user's item succeeded, want an error")
+	}
+	if stored, _ := s.clothing.GetByID(own[2].ID); !stored.IsAvailable() {
+		t.Errorf("item %s = %q, want it left available", own[2].ID, stored.Availability)
+	}
+
+	// From を指定すると現在の利用状況が一致するアイテムを全て変更する
+	returned, err := uc.BulkUpdateAvailability("user-1", BulkAvailabilityRequest{From: entities.AvailabilityLaundry, Availability: entities.AvailabilityAvailable})
+	if err != nil || len(returned) != 2 {
+		t.Fatalf("BulkUpdateAvailability(from laundry) = %d items, %v, want 2", len(returned), err)
+	}
+	if stored, _ := s.clothing.GetByID(others[0].ID); !stored.IsAvailable() {
+		t.Errorf("other user's item = %q, want it untouched", stored.Availability)
+	}
+
+	invalid := map[string]BulkAvailabilityRequest{
+		"unknown availability": {IDs: []string{own[0].ID}, Availability: "lost"},
+		"ids and from":         {IDs: []string{own[0].ID}, From: entities.AvailabilityLaundry, Availability: entities.AvailabilityAvailable},
+		"no target":            {Availability: entities.AvailabilityAvailable},
+		"unknown from":         {From: "lost", Availability: entities.AvailabilityAvailable},
+		"too many items":       {IDs: make([]string, MaxBulkAvailabilityItems+1), Availability: entities.AvailabilityLaundry},
+	}
+	for name, req := range invalid {
+		if _, err := uc.BulkUpdateAvailability("user-1", req); err == nil {
+			t.Errorf("%s: succeeded, want an error", name)
+		}
+	}
+}
+
+func TestLaundryReturnsAfterCycle(t *testing.T) {
+	s := newTestStore()
+	cycle := 48 * time.Hour
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, cycle)
+	closet := createOutfitCloset(t, s, "user-1")
+
+	sentAt := time.Now().Add(-3 * cycle)
+	closet[0].SetAvailability(entities.AvailabilityLaundry, sentAt)
+	closet[1].SetAvailability(entities.AvailabilityLaundry, time.Now())
+	for _, item := range closet[:2] {
+		if err := s.clothing.Update(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := uc.GetUserClothing("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	availability := make(map[string]entities.Availability)
+	for _, item := range items {
+		availability[item.ID] = item.CurrentAvailability()
+	}
+	if availability[closet[0].ID] != entities.AvailabilityAvailable || availability[closet[1].ID] != entities.AvailabilityLaundry {
+		t.Errorf("availability = %v, want only the item washed %v ago returned", availability, 3*cycle)
+	}
+}
+
+func TestGetRecommendationsSkipsUnavailableItems(t *testing.T) {
+	s := newTestStore()
+	uc := newTestFashionUseCase(s, &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 18, FeelsLike: 18}})
+	closet := createOutfitCloset(t, s, "user-1")
+	req := RecommendationRequest{UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917}
+
+	clothingUC := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	if _, err := clothingUC.BulkUpdateAvailability("user-1", BulkAvailabilityRequest{IDs: []string{closet[1].ID}, Availability: entities.AvailabilityCleaners}); err != nil {
+		t.Fatal(err)
+	}
+
+	// クリーニング中のボトムスしかないためコーディネートを組めない
+	recommendation, err := uc.GetRecommendations(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recommendation.Outfits) != 0 {
+		t.Errorf("outfits = %+v, want none while the only bottoms are at the cleaners", recommendation.Outfits)
+	}
+
+	if _, err := clothingUC.BulkUpdateAvailability("user-1", BulkAvailabilityRequest{From: entities.AvailabilityCleaners, Availability: entities.AvailabilityAvailable}); err != nil {
+		t.Fatal(err)
+	}
+	recommendation, err = uc.GetRecommendations(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recommendation.Outfits) == 0 {
+		t.Errorf("no outfits after the bottoms came back: %s", recommendation.Reason)
+	}
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"errors"
+)
+
+// ErrPreconditionFailed クライアントが指定したバージョン（If-Match）が最新のバージョンと一致しない場合のエラー
+var ErrPreconditionFailed = errors.New("precondition failed: resource has been modified")
+
+// ErrInvalidCoordinates 緯度経度が有効な範囲外の場合のエラー
+var ErrInvalidCoordinates = errors.New("invalid coordinates")
+
+// ErrRecommendationNotFound 推奨結果が存在しない、または他のユーザーの推奨結果である場合のエラー
+var ErrRecommendationNotFound = errors.New("recommendation not found")
+
+// ErrInvalidTimeWindow 外出時間帯の指定が不正、または予報の範囲外の場合のエラー
+var ErrInvalidTimeWindow = errors.New("invalid time window")
+
+// ErrInvalidImageSignature 画像URLの署名が不正な場合のエラー
+var ErrInvalidImageSignature = errors.New("invalid image signature")
+
+// ErrImageNotFound 画像が存在しない場合のエラー
+var ErrImageNotFound = errors.New("image not found")
+
+// ErrInvalidClothingQuery クローゼットの検索条件（並び順・カーソルなど）が不正な場合のエラー
+var ErrInvalidClothingQuery = errors.New("invalid clothing query")
+
+// ErrInvalidClothingImport インポートするファイルが読み込めない場合（形式の誤り・行数の超過など）のエラー
+var ErrInvalidClothingImport = errors.New("invalid clothing import")
+
+// ErrUnsupportedClothingFormat インポート・エクスポートのファイル形式が CSV・JSON 以外の場合のエラー
+var ErrUnsupportedClothingFormat = errors.New("unsupported clothing file format")
+
+// ErrImageStorage 画像の保存先（BlobStore）への書き込みに失敗した場合のエラー
+var ErrImageStorage = errors.New("image storage failed")
+
+// ErrForeignImage 画像のURLが他のユーザーのアップロードした画像を指している場合のエラー
+var ErrForeignImage = errors.New("image belongs to another user")
+
+// ErrUnknownRecommendationStrategy ユーザー設定で指定された推奨戦略名が存在しない場合のエラー
+var ErrUnknownRecommendationStrategy = errors.New("unknown recommendation strategy")
@@ -1,7 +1,10 @@
 package usecases
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"forecast-app/internal/domain/entities"
@@ -18,6 +21,20 @@ type FashionUseCase struct {
 	clothingRepo     repositories.ClothingRepository
 	
 	recommendationRepo repositories.FashionRecommendationRepository
+	
+	userRepo         repositories.UserRepository
+	
+	// wearLogRepo 最近着たアイテムの判定に使用する着用記録
+	wearLogRepo      repositories.WearLogRepository
+	
+	// uow フィードバックと体感補正の更新、推奨の採用による着用記録をアトミックに実行するための UnitOfWork
+	uow              repositories.UnitOfWork
+	
+	// defaultStrategy ユーザーが戦略を指定していない場合に使用する推奨戦略
+	defaultStrategy  services.RecommendationStrategy
+	
+	// laundryCycle 洗濯中のアイテムを着用可能とみなすまでの期間（0の場合は自動で戻さない）
+	laundryCycle     time.Duration
 }
 
 // ファッションユースケースの新しいインスタンスを作成
@@ -26,12 +43,22 @@ func NewFashionUseCase(
 	weatherRepo repositories.WeatherRepository,
 	clothingRepo repositories.ClothingRepository,
 	recommendationRepo repositories.FashionRecommendationRepository,
+	userRepo repositories.UserRepository,
+	wearLogRepo repositories.WearLogRepository,
+	uow repositories.UnitOfWork,
+	defaultStrategy services.RecommendationStrategy,
+	laundryCycle time.Duration,
 ) *FashionUseCase {
 	return &FashionUseCase{
 		fashionService:     fashionService,
 		weatherRepo:        weatherRepo,
 		clothingRepo:       clothingRepo,
 		recommendationRepo: recommendationRepo,
+		userRepo:           userRepo,
+		wearLogRepo:        wearLogRepo,
+		uow:                uow,
+		defaultStrategy:    defaultStrategy,
+		laundryCycle:       laundryCycle,
 	}
 }
 
@@ -41,23 +68,93 @@ type RecommendationRequest struct {
 	Latitude  float64 `json:"latitude"`   // 現在地の緯度（天気取得用）
 	Longitude float64 `json:"longitude"`  // 現在地の経度（天気取得用）
 	Location  string  `json:"location"`   // 地域名（表示用）
+
+	// DepartureAt・ReturnAt 外出する時間帯（指定した場合は時間ごとの予報から時間帯全体をカバーする服装を推奨）
+	DepartureAt *time.Time `json:"departure_at,omitempty"`
+	ReturnAt    *time.Time `json:"return_at,omitempty"`
+
+	// Waypoints 立ち寄る地点（自宅・職場など）を滞在順に指定（指定した場合は Latitude・Longitude と外出時間帯より優先）
+	Waypoints []WaypointRequest `json:"waypoints,omitempty"`
 }
 
+// 立ち寄る地点と滞在時間帯
+type WaypointRequest struct {
+	Name      string     `json:"name"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	ArriveAt  time.Time  `json:"arrive_at"`          // 到着（自宅の場合は出発）時刻
+	LeaveAt   *time.Time `json:"leave_at,omitempty"` // 出発時刻（省略時は次の地点の到着時刻、最後の地点は到着の1時間後）
+}
+
+// 指定できる立ち寄り地点の最大数
+const MaxWaypoints = 5
+
+// 最後の地点の出発時刻を省略した場合の滞在時間
+const defaultWaypointStay = time.Hour
+
+// 外出時間帯として指定できる最大の長さ
+const MaxDayPlanDuration = 24 * time.Hour
+
 //  指定された位置情報と天気条件に基づいてファッション推奨
-func (uc *FashionUseCase) GetRecommendations(req RecommendationRequest) (*entities.FashionRecommendation, error) {
-	weatherCondition, err := uc.weatherRepo.GetByLocation(req.Latitude, req.Longitude)
-	if err != nil {
-		return nil, fmt.Errorf("天気データの取得に失敗しました: %w", err)
+func (uc *FashionUseCase) GetRecommendations(ctx context.Context, req RecommendationRequest) (*entities.FashionRecommendation, error) {
+	// 外出時間帯の指定があれば時間ごとの予報、なければ現在の天気を使用
+	var weatherCondition *entities.WeatherCondition
+	var dayPlan *entities.DayPlan
+	var err error
+	// 立ち寄り地点を指定した場合は地点ごとに検証し、それ以外はリクエストの地点を検証する
+	if len(req.Waypoints) == 0 {
+		if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.Waypoints) > 0 {
+		dayPlan, err = uc.waypointDayPlan(ctx, req.Waypoints)
+		if err != nil {
+			return nil, err
+		}
+	} else if req.DepartureAt != nil || req.ReturnAt != nil {
+		dayPlan, err = uc.dayPlan(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		weatherCondition, err = uc.weatherRepo.GetByLocation(ctx, req.Latitude, req.Longitude)
+		if err != nil {
+			return nil, fmt.Errorf("天気データの取得に失敗しました: %w", err)
+		}
 	}
 
-	// ユーザーの衣服アイテムを取得
+	// ユーザーの衣服アイテムを取得（洗濯中・貸し出し中などのアイテムは推奨の対象外）
 	clothingItems, err := uc.clothingRepo.GetByUserID(req.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("ユーザーの衣服データの取得に失敗しました: %w", err)
 	}
+	clothingItems = availableItems(clothingItems, time.Now(), uc.laundryCycle)
+
+	// 最近着たアイテムを減点するための最終着用日
+	lastWorn, err := uc.wearLogRepo.GetLastWornByUserID(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("着用記録の取得に失敗しました: %w", err)
+	}
+
+	// ユーザー設定と体感補正（未登録ユーザーや匿名の場合は設定なしとして扱う）
+	var preferences *entities.UserPreferences
+	var comfortOffset float64
+	if user, err := uc.userRepo.GetByID(req.UserID); err == nil {
+		preferences = user.Preferences
+		comfortOffset = user.ComfortOffset
+	}
 
 	// ドメインサービスを使用してファッション推奨を生成
-	recommendation := uc.fashionService.GenerateRecommendation(weatherCondition, clothingItems)
+	recommendation := uc.fashionService.GenerateRecommendation(services.RecommendationInput{
+		Weather:     weatherCondition,
+		Clothing:    clothingItems,
+		Preferences: preferences,
+		ComfortOffset: comfortOffset,
+		DayPlan:     dayPlan,
+		LastWorn:    lastWorn,
+		Now:         time.Now(),
+	}, uc.strategyFor(preferences))
 	
 	// 推奨結果に追加情報を設定
 	recommendation.UserID = req.UserID
@@ -81,3 +178,237 @@ func (uc *FashionUseCase) GetUserRecommendations(userID string) ([]*entities.Fas
 func (uc *FashionUseCase) GetRecommendationByID(id string) (*entities.FashionRecommendation, error) {
 	return uc.recommendationRepo.GetByID(id)
 }
+
+// 推奨結果へのフィードバックリクエストの構造体
+type FeedbackRequest struct {
+	Comfort     entities.ThermalComfort `json:"comfort"`      // too_cold / just_right / too_hot
+	ItemRatings []ItemRatingRequest     `json:"item_ratings"` // アイテムごとの評価（任意）
+	Comment     string                  `json:"comment"`
+}
+
+// アイテムごとの評価リクエスト
+type ItemRatingRequest struct {
+	ClothingID string              `json:"clothing_id"`
+	Rating     entities.ItemRating `json:"rating"` // up / down
+}
+
+// 推奨結果にフィードバックを記録し、体感の申告からユーザーの体感補正を学習
+// 回答済みの推奨への再送信は前回の回答を置き換え、体感補正も前回分を取り消してから反映する
+// 推奨が存在しない・他のユーザーの推奨である場合は ErrRecommendationNotFound を返す
+func (uc *FashionUseCase) SubmitFeedback(userID, recommendationID string, req FeedbackRequest) (*entities.FashionRecommendation, error) {
+	feedback := &entities.RecommendationFeedback{
+		Comfort:     req.Comfort,
+		Comment:     req.Comment,
+		SubmittedAt: time.Now(),
+	}
+	for _, rating := range req.ItemRatings {
+		feedback.ItemRatings = append(feedback.ItemRatings, entities.ItemFeedback{
+			ClothingID: rating.ClothingID,
+			Rating:     rating.Rating,
+		})
+	}
+
+	var recommendation *entities.FashionRecommendation
+	err := uc.uow.Do(func(tx repositories.Transaction) error {
+		var err error
+		recommendation, err = tx.FashionRecommendations().GetByID(recommendationID)
+		if err != nil || recommendation.UserID != userID {
+			return ErrRecommendationNotFound
+		}
+		if err := feedback.Validate(recommendation); err != nil {
+			return fmt.Errorf("無効なフィードバックです: %w", err)
+		}
+
+		delta := feedback.OffsetDelta()
+		if recommendation.Feedback != nil {
+			delta -= recommendation.Feedback.OffsetDelta()
+		}
+
+		recommendation.Feedback = feedback
+		if err := tx.FashionRecommendations().Update(recommendation); err != nil {
+			return fmt.Errorf("フィードバックの保存に失敗しました: %w", err)
+		}
+
+		if delta == 0 {
+			return nil
+		}
+		user, err := tx.Users().GetByID(userID)
+		if err != nil {
+			return fmt.Errorf("ユーザーが見つかりません: %w", err)
+		}
+		user.AdjustComfortOffset(delta)
+		user.UpdatedAt = time.Now()
+		if err := tx.Users().Update(user); err != nil {
+			return fmt.Errorf("体感補正の更新に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recommendation, nil
+}
+
+// 推奨の採用リクエストの構造体
+type AcceptRequest struct {
+	OutfitRank int    `json:"outfit_rank"` // 採用するコーディネートの順位（省略時は最上位）
+	WornOn     string `json:"worn_on"`     // 着用日（YYYY-MM-DD、省略時は当日）
+}
+
+// 推奨されたコーディネートを採用し、含まれるアイテムの着用を記録
+// 同じ日に記録済みのアイテムは重複して記録せず、新たに記録した着用記録を返す
+// 推奨が存在しない・他のユーザーの推奨である場合は ErrRecommendationNotFound を返す
+func (uc *FashionUseCase) AcceptRecommendation(userID, recommendationID string, req AcceptRequest) ([]*entities.WearLogEntry, error) {
+	wornOn, err := parseWearDate(req.WornOn, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded []*entities.WearLogEntry
+	err = uc.uow.Do(func(tx repositories.Transaction) error {
+		recommendation, err := tx.FashionRecommendations().GetByID(recommendationID)
+		if err != nil || recommendation.UserID != userID {
+			return ErrRecommendationNotFound
+		}
+
+		items := recommendation.Items
+		if req.OutfitRank > 0 {
+			items = nil
+			for _, outfit := range recommendation.Outfits {
+				if outfit.Rank == req.OutfitRank {
+					items = outfit.Items
+					break
+				}
+			}
+			if items == nil {
+				return fmt.Errorf("順位 %d のコーディネートはこの推奨に含まれていません", req.OutfitRank)
+			}
+		}
+
+		clothingIDs := make([]string, 0, len(items))
+		for _, item := range items {
+			clothingIDs = append(clothingIDs, item.ClothingID)
+		}
+		recorded, err = recordWear(tx, userID, clothingIDs, wornOn, entities.WearSourceRecommendation, recommendation.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// ユーザー設定で指定された推奨戦略を返す（未指定・未知の戦略名の場合は既定の戦略）
+func (uc *FashionUseCase) strategyFor(preferences *entities.UserPreferences) services.RecommendationStrategy {
+	if preferences != nil && preferences.RecommendationStrategy != "" {
+		if strategy := services.NewRecommendationStrategy(preferences.RecommendationStrategy); strategy != nil {
+			return strategy
+		}
+	}
+	return uc.defaultStrategy
+}
+
+// 外出時間帯の時間ごとの予報を取得して集計
+func (uc *FashionUseCase) dayPlan(ctx context.Context, req RecommendationRequest) (*entities.DayPlan, error) {
+	if req.DepartureAt == nil || req.ReturnAt == nil {
+		return nil, fmt.Errorf("%w: 出発時刻と帰宅時刻の両方を指定してください", ErrInvalidTimeWindow)
+	}
+	if !req.ReturnAt.After(*req.DepartureAt) {
+		return nil, fmt.Errorf("%w: 帰宅時刻は出発時刻より後にしてください", ErrInvalidTimeWindow)
+	}
+	if req.ReturnAt.Sub(*req.DepartureAt) > MaxDayPlanDuration {
+		return nil, fmt.Errorf("%w: 外出時間は%.0f時間以内で指定してください", ErrInvalidTimeWindow, MaxDayPlanDuration.Hours())
+	}
+
+	forecast, err := uc.weatherRepo.GetForecast(ctx, req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, fmt.Errorf("天気予報の取得に失敗しました: %w", err)
+	}
+
+	plan, err := services.NewDayPlan(forecast.Hourly, *req.DepartureAt, *req.ReturnAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTimeWindow, err)
+	}
+	return plan, nil
+}
+
+// 立ち寄る地点ごとの予報を並行して取得し、滞在時間帯をつなげて集計
+func (uc *FashionUseCase) waypointDayPlan(ctx context.Context, requests []WaypointRequest) (*entities.DayPlan, error) {
+	waypoints, err := resolveWaypoints(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(waypoints))
+	for i := range waypoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			forecast, err := uc.weatherRepo.GetForecast(ctx, waypoints[i].Latitude, waypoints[i].Longitude)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s の天気予報の取得に失敗しました: %w", waypoints[i].Name, err)
+				return
+			}
+			waypoints[i].Hourly = forecast.Hourly
+		}(i)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	plan, err := services.NewWaypointDayPlan(waypoints)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTimeWindow, err)
+	}
+	return plan, nil
+}
+
+// 立ち寄り地点の指定を検証し、各地点の滞在時間帯を確定
+func resolveWaypoints(requests []WaypointRequest) ([]services.WaypointForecast, error) {
+	if len(requests) > MaxWaypoints {
+		return nil, fmt.Errorf("%w: 立ち寄り地点は%d件以内で指定してください", ErrInvalidTimeWindow, MaxWaypoints)
+	}
+
+	waypoints := make([]services.WaypointForecast, len(requests))
+	for i, req := range requests {
+		name := req.Name
+		if name == "" {
+			name = fmt.Sprintf("地点%d", i+1)
+		}
+		if err := validateCoordinates(req.Latitude, req.Longitude); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if req.ArriveAt.IsZero() {
+			return nil, fmt.Errorf("%w: %s の到着時刻を指定してください", ErrInvalidTimeWindow, name)
+		}
+
+		leaveAt := req.ArriveAt.Add(defaultWaypointStay)
+		switch {
+		case req.LeaveAt != nil:
+			leaveAt = *req.LeaveAt
+		case i+1 < len(requests):
+			leaveAt = requests[i+1].ArriveAt
+		}
+		if !leaveAt.After(req.ArriveAt) {
+			return nil, fmt.Errorf("%w: %s の出発時刻は到着時刻より後にしてください", ErrInvalidTimeWindow, name)
+		}
+		if i > 0 && req.ArriveAt.Before(waypoints[i-1].LeaveAt) {
+			return nil, fmt.Errorf("%w: 地点は滞在順に指定してください（%s）", ErrInvalidTimeWindow, name)
+		}
+
+		waypoints[i] = services.WaypointForecast{
+			Name:      name,
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			ArriveAt:  req.ArriveAt,
+			LeaveAt:   leaveAt,
+		}
+	}
+
+	if waypoints[len(waypoints)-1].LeaveAt.Sub(waypoints[0].ArriveAt) > MaxDayPlanDuration {
+		return nil, fmt.Errorf("%w: 外出時間は%.0f時間以内で指定してください", ErrInvalidTimeWindow, MaxDayPlanDuration.Hours())
+	}
+	return waypoints, nil
+}
@@ -0,0 +1,299 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/domain/services"
+	infrarepo "forecast-app/internal/infrastructure/repositories"
+)
+
+func TestGetRecommendationsWeatherUnavailable(t *testing.T) {
+	weather := &fakeWeatherRepository{err: fmt.Errorf("%w: timeout", repositories.ErrWeatherUnavailable)}
+	uc := newTestFashionUseCase(newTestStore(), weather)
+
+	_, err := uc.GetRecommendations(context.Background(), RecommendationRequest{UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917})
+	if !errors.Is(err, repositories.ErrWeatherUnavailable) {
+		t.Errorf("GetRecommendations error = %v, want ErrWeatherUnavailable", err)
+	}
+}
+
+func TestGetRecommendationsUsesStaleCachedWeather(t *testing.T) {
+	weather := &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 18, FeelsLike: 18, Condition: entities.ConditionClear}}
+	cached := infrarepo.NewCachedWeatherRepository(weather, infrarepo.WeatherCacheOptions{TTL: time.Nanosecond, StaleTTL: time.Hour})
+	uc := newTestFashionUseCase(newTestStore(), cached)
+	req := RecommendationRequest{UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917}
+
+	if _, err := uc.GetRecommendations(context.Background(), req); err != nil {
+		t.Fatalf("GetRecommendations: %v", err)
+	}
+
+	// 天気サービスの障害中はキャッシュに残っていた天気を Stale を付けて使用する
+	weather.setErr(fmt.Errorf("%w: circuit open", repositories.ErrWeatherUnavailable))
+	var recommendation *entities.FashionRecommendation
+	deadline := time.Now().Add(time.Second)
+	for {
+		var err error
+		recommendation, err = uc.GetRecommendations(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GetRecommendations during outage: %v", err)
+		}
+		// 最初の呼び出しは古いデータを返しつつバックグラウンドで更新し、更新の失敗後に Stale が付く
+		if recommendation.Weather.Stale || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !recommendation.Weather.Stale || recommendation.Weather.Temperature != 18 {
+		t.Errorf("weather = stale %v temperature %v, want stale 18", recommendation.Weather.Stale, recommendation.Weather.Temperature)
+	}
+}
+
+func TestGetRecommendationsValidatesCoordinates(t *testing.T) {
+	weather := &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 18, FeelsLike: 18}}
+	uc := newTestFashionUseCase(newTestStore(), weather)
+	departure := time.Now().Add(time.Hour)
+	returnAt := departure.Add(3 * time.Hour)
+
+	tests := map[string]RecommendationRequest{
+		"current weather": {UserID: "user-1", Latitude: 91, Longitude: 139.6917},
+		"day plan":        {UserID: "user-1", Latitude: 35.6895, Longitude: 181, DepartureAt: &departure, ReturnAt: &returnAt},
+		"waypoint": {UserID: "user-1", Waypoints: []WaypointRequest{
+			{Name: "自宅", Latitude: 35.6895, Longitude: 139.6917, ArriveAt: departure},
+			{Name: "会社", Latitude: -91, Longitude: 139.7, ArriveAt: returnAt},
+		}},
+	}
+	for name, req := range tests {
+		if _, err := uc.GetRecommendations(context.Background(), req); !errors.Is(err, ErrInvalidCoordinates) {
+			t.Errorf("%s: error = %v, want ErrInvalidCoordinates", name, err)
+		}
+	}
+}
+
+// コーディネートを1通り組めるクローゼットを登録し、登録したアイテムを返す
+func createOutfitCloset(t *testing.T, s *testStore, userID string) []*entities.ClothingItem {
+	t.Helper()
+	var items []*entities.ClothingItem
+	for _, spec := range []struct{ name, category string }{
+		{"Tシャツ", string(entities.CategoryTops)},
+		{"デニム", string(entities.CategoryBottoms)},
+		{"スニーカー", string(entities.CategoryShoes)},
+	} {
+		item, err := entities.NewClothingItem(userID, spec.name, spec.name, "navy", spec.category)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.clothing.Create(item); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func createTestUser(t *testing.T, s *testStore) *entities.User {
+	t.Helper()
+	user, err := entities.NewUser("山田", entities.NewID()+"@example.com", "hashed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func TestSubmitFeedbackAdjustsComfortOffset(t *testing.T) {
+	s := newTestStore()
+	uc := newTestFashionUseCase(s, &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 18, FeelsLike: 18}})
+	user := createTestUser(t, s)
+	createOutfitCloset(t, s, user.ID)
+	req := RecommendationRequest{UserID: user.ID, Latitude: 35.6895, Longitude: 139.6917}
+
+	first, err := uc.GetRecommendations(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.SubmitFeedback(user.ID, first.ID, FeedbackRequest{Comfort: entities.ComfortTooCold}); err != nil {
+		t.Fatalf("SubmitFeedback: %v", err)
+	}
+	if got, _ := s.users.GetByID(user.ID); got.ComfortOffset != entities.ComfortOffsetStep {
+		t.Errorf("comfort offset = %v, want %v", got.ComfortOffset, entities.ComfortOffsetStep)
+	}
+
+	// 再送信は前回の回答を置き換える
+	updated, err := uc.SubmitFeedback(user.ID, first.ID, FeedbackRequest{Comfort: entities.ComfortTooHot})
+	if err != nil {
+		t.Fatalf("SubmitFeedback again: %v", err)
+	}
+	if updated.Feedback == nil || updated.Feedback.Comfort != entities.ComfortTooHot {
+		t.Errorf("feedback = %+v, want too_hot", updated.Feedback)
+	}
+	if got, _ := s.users.GetByID(user.ID); got.ComfortOffset != -entities.ComfortOffsetStep {
+		t.Errorf("comfort offset after resubmission = %v, want %v", got.ComfortOffset, -entities.ComfortOffsetStep)
+	}
+
+	// 学習した体感補正は次回の推奨に反映される
+	next, err := uc.GetRecommendations(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.Outfits) == 0 {
+		t.Fatalf("no outfits: %s", next.Reason)
+	}
+	if want := services.TargetWarmth(18 + entities.ComfortOffsetStep); next.Outfits[0].TargetWarmth != want {
+		t.Errorf("target warmth = %d, want %d for a user who felt too hot", next.Outfits[0].TargetWarmth, want)
+	}
+
+	// 体感補正は上限で止まる
+	for i := 0; i < 10; i++ {
+		recommendation, err := uc.GetRecommendations(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := uc.SubmitFeedback(user.ID, recommendation.ID, FeedbackRequest{Comfort: entities.ComfortTooCold}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := s.users.GetByID(user.ID); got.ComfortOffset != entities.MaxComfortOffset {
+		t.Errorf("comfort offset = %v, want the cap %v", got.ComfortOffset, entities.MaxComfortOffset)
+	}
+}
+
+func TestSubmitFeedbackRejectsInvalidRequests(t *testing.T) {
+	s := newTestStore()
+	uc := newTestFashionUseCase(s, &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 18, FeelsLike: 18}})
+	owner := createTestUser(t, s)
+	other := createTestUser(t, s)
+	createOutfitCloset(t, s, owner.ID)
+	recommendation, err := uc.GetRecommendations(context.Background(), RecommendationRequest{UserID: owner.ID, Latitude: 35.6895, Longitude: 139.6917})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := uc.SubmitFeedback(other.ID, recommendation.ID, FeedbackRequest{Comfort: entities.ComfortTooCold}); !errors.Is(err, ErrRecommendationNotFound) {
+		t.Errorf("other user's feedback error = %v, want ErrRecommendationNotFound", err)
+	}
+	if _, err := uc.SubmitFeedback(owner.ID, "missing", FeedbackRequest{Comfort: entities.ComfortTooCold}); !errors.Is(err, ErrRecommendationNotFound) {
+		t.Errorf("unknown recommendation error = %v, want ErrRecommendationNotFound", err)
+	}
+	invalid := []FeedbackRequest{
+		{Comfort: "freezing"},
+		{Comfort: entities.ComfortJustRight, ItemRatings: []ItemRatingRequest{{ClothingID: "not-recommended", Rating: entities.RatingUp}}},
+	}
+	for _, req := range invalid {
+		if _, err := uc.SubmitFeedback(owner.ID, recommendation.ID, req); err == nil {
+			t.Errorf("SubmitFeedback(%+v) succeeded, want an error", req)
+		}
+	}
+
+	// 拒否したフィードバックは記録されず、体感補正も変わらない
+	if got, _ := s.recommendations.GetByID(recommendation.ID); got.Feedback != nil {
+		t.Errorf("feedback = %+v, want none", got.Feedback)
+	}
+	if got, _ := s.users.GetByID(owner.ID); got.ComfortOffset != 0 {
+		t.Errorf("comfort offset = %v, want 0", got.ComfortOffset)
+	}
+}
+
+func TestGetRecommendationsDayPlan(t *testing.T) {
+	departure := time.Now().Truncate(time.Hour).Add(time.Hour)
+	weather := &fakeWeatherRepository{forecast: entities.WeatherForecast{}}
+	for i, feelsLike := range []float64{6, 10, 15, 18} {
+		weather.forecast.Hourly = append(weather.forecast.Hourly, entities.WeatherCondition{
+			DateTime:  departure.Add(time.Duration(i) * time.Hour),
+			FeelsLike: feelsLike,
+			Condition: entities.ConditionClouds,
+		})
+	}
+	s := newTestStore()
+	uc := newTestFashionUseCase(s, weather)
+	createOutfitCloset(t, s, "user-1")
+	at := func(hours int) *time.Time {
+		v := departure.Add(time.Duration(hours) * time.Hour)
+		return &v
+	}
+
+	recommendation, err := uc.GetRecommendations(context.Background(), RecommendationRequest{
+		UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917, DepartureAt: at(0), ReturnAt: at(3),
+	})
+	if err != nil {
+		t.Fatalf("GetRecommendations: %v", err)
+	}
+	if recommendation.DayPlan == nil || recommendation.DayPlan.MinFeelsLike != 6 || recommendation.DayPlan.MaxFeelsLike != 18 {
+		t.Errorf("day plan = %+v, want feels like 6〜18", recommendation.DayPlan)
+	}
+
+	tests := map[string]RecommendationRequest{
+		"departure only":          {DepartureAt: at(0)},
+		"return before departure": {DepartureAt: at(2), ReturnAt: at(1)},
+		"longer than a day":       {DepartureAt: at(0), ReturnAt: at(25)},
+		"outside the forecast":    {DepartureAt: at(10), ReturnAt: at(12)},
+	}
+	for name, req := range tests {
+		req.UserID, req.Latitude, req.Longitude = "user-1", 35.6895, 139.6917
+		if _, err := uc.GetRecommendations(context.Background(), req); !errors.Is(err, ErrInvalidTimeWindow) {
+			t.Errorf("%s: error = %v, want ErrInvalidTimeWindow", name, err)
+		}
+	}
+}
+
+func TestResolveWaypoints(t *testing.T) {
+	start := time.Date(2024, 4, 10, 7, 0, 0, 0, time.UTC)
+	lunch := start.Add(5 * time.Hour)
+	waypoints, err := resolveWaypoints([]WaypointRequest{
+		{Name: "自宅", Latitude: 35.6895, Longitude: 139.6917, ArriveAt: start},
+		{Latitude: 35.68, Longitude: 139.76, ArriveAt: start.Add(time.Hour), LeaveAt: &lunch},
+		{Name: "ジム", Latitude: 35.66, Longitude: 139.7, ArriveAt: start.Add(6 * time.Hour)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 出発時刻の省略時は次の地点の到着時刻、最後の地点は到着の1時間後
+	want := []struct {
+		name            string
+		arrive, leaveAt time.Time
+	}{
+		{"自宅", start, start.Add(time.Hour)},
+		{"地点2", start.Add(time.Hour), lunch},
+		{"ジム", start.Add(6 * time.Hour), start.Add(6*time.Hour + defaultWaypointStay)},
+	}
+	for i, w := range want {
+		got := waypoints[i]
+		if got.Name != w.name || !got.ArriveAt.Equal(w.arrive) || !got.LeaveAt.Equal(w.leaveAt) {
+			t.Errorf("waypoint %d = %s %v〜%v, want %s %v〜%v", i, got.Name, got.ArriveAt, got.LeaveAt, w.name, w.arrive, w.leaveAt)
+		}
+	}
+
+	early := start.Add(30 * time.Minute)
+	tooMany := make([]WaypointRequest, MaxWaypoints+1)
+	for i := range tooMany {
+		tooMany[i] = WaypointRequest{Latitude: 35, Longitude: 139, ArriveAt: start.Add(time.Duration(i) * time.Hour)}
+	}
+	invalid := map[string][]WaypointRequest{
+		"too many waypoints": tooMany,
+		"missing arrival":    {{Name: "自宅", Latitude: 35, Longitude: 139}},
+		"leave before arrival": {
+			{Name: "自宅", Latitude: 35, Longitude: 139, ArriveAt: start, LeaveAt: &start},
+		},
+		"out of order": {
+			{Name: "自宅", Latitude: 35, Longitude: 139, ArriveAt: start, LeaveAt: &lunch},
+			{Name: "会社", Latitude: 35, Longitude: 139, ArriveAt: early},
+		},
+		"longer than a day": {
+			{Name: "自宅", Latitude: 35, Longitude: 139, ArriveAt: start},
+			{Name: "旅行先", Latitude: 35, Longitude: 139, ArriveAt: start.Add(MaxDayPlanDuration)},
+		},
+	}
+	for name, requests := range invalid {
+		if _, err := resolveWaypoints(requests); !errors.Is(err, ErrInvalidTimeWindow) {
+			t.Errorf("%s: error = %v, want ErrInvalidTimeWindow", name, err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/domain/services"
+)
+
+// ImageRoutePrefix 画像を配信するルートのパス（この後ろに画像のパスが続く）
+const ImageRoutePrefix = "/api/images/"
+
+// 画像を保存するキーの接頭辞（BlobStore を他のデータと共有しても衝突しないようにする）
+const imageKeyPrefix = "images/"
+
+// ImageUseCase 画像のアップロードと署名付きURLでの配信を扱うユースケース
+// 画像のURLは保存先のキーに HMAC-SHA256 の署名を付けたもので、署名を知らない第三者はキーを推測しても画像を取得できない
+// 署名付きURLは衣服アイテムや投稿にそのまま保存されるため有効期限は設けていない。
+// 画像を差し替えると以前の画像は削除されるため、URLを知っている第三者からのアクセスは差し替えによって取り消せる
+type ImageUseCase struct {
+	imageService *services.ImageService
+	blobStore    repositories.BlobStore
+	clothingRepo repositories.ClothingRepository
+
+	// signingKey 画像URLの署名に使用する秘密鍵
+	signingKey []byte
+}
+
+func NewImageUseCase(imageService *services.ImageService, blobStore repositories.BlobStore, clothingRepo repositories.ClothingRepository, signingKey []byte) *ImageUseCase {
+	return &ImageUseCase{
+		imageService: imageService,
+		blobStore:    blobStore,
+		clothingRepo: clothingRepo,
+		signingKey:   signingKey,
+	}
+}
+
+// アップロードした画像の署名付きURLと抽出した色
+type UploadedImage struct {
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+
+	// Colors 画像の主要な色（割合の大きい順）
+	Colors []ColorSuggestion `json:"colors"`
+
+	// SuggestedColor 衣服アイテムの色として提案する色名（最も割合の大きい色、抽出できなかった場合は空）
+	SuggestedColor string `json:"suggested_color"`
+}
+
+// 画像から抽出した色の候補
+type ColorSuggestion struct {
+	Name        string  `json:"name"`         // 標準パレットの識別名（"navy" など）
+	DisplayName string  `json:"display_name"` // 表示名（衣服アイテムの色に設定する値）
+	Hex         string  `json:"hex"`          // 抽出した色の平均値
+	Share       float64 `json:"share"`        // 背景を除いた画素に占める割合
+}
+
+// 衣服アイテムの画像を差し替えた結果
+type ClothingImageResult struct {
+	Clothing *entities.ClothingItem `json:"clothing"`
+
+	Colors         []ColorSuggestion `json:"colors"`
+	SuggestedColor string            `json:"suggested_color"`
+
+	// ColorApplied 提案した色をアイテムの色に設定したか
+	ColorApplied bool `json:"color_applied"`
+}
+
+// アップロードできるファイルサイズの上限（バイト）
+func (uc *ImageUseCase) MaxBytes() int64 {
+	return uc.imageService.MaxBytes()
+}
+
+// 画像を検証・変換して保存し、署名付きURLを返す（outfit投稿の画像など）
+func (uc *ImageUseCase) UploadImage(ctx context.Context, userID string, data []byte, contentType string) (*UploadedImage, error) {
+	uploaded, _, err := uc.store(ctx, userID, data, contentType)
+	return uploaded, err
+}
+
+// 衣服アイテムの画像をアップロードして差し替え、画像から抽出した色を提案する
+// 登録済みの色が標準パレットの色として解釈できない場合、または applyColor が true の場合は提案した色で上書きする
+// expectedVersion が 0 より大きい場合、保存済みのバージョンと一致しなければ ErrPreconditionFailed を返す
+func (uc *ImageUseCase) AttachClothingImage(ctx context.Context, id string, userID string, data []byte, contentType string, expectedVersion int, applyColor bool) (*ClothingImageResult, error) {
+	clothing, err := uc.clothingRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("clothing item not found: %w", err)
+	}
+	if clothing.UserID != userID {
+		return nil, fmt.Errorf("unauthorized: user does not own this clothing item")
+	}
+	if expectedVersion > 0 && clothing.Version != expectedVersion {
+		return nil, ErrPreconditionFailed
+	}
+
+	uploaded, keys, err := uc.store(ctx, userID, data, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ClothingImageResult{
+		Clothing:       clothing,
+		Colors:         uploaded.Colors,
+		SuggestedColor: uploaded.SuggestedColor,
+	}
+	if _, recognized := entities.ParseColor(clothing.Color); uploaded.SuggestedColor != "" && (applyColor || !recognized) {
+		clothing.Color = uploaded.SuggestedColor
+		result.ColorApplied = true
+	}
+
+	previous := []string{clothing.ImageURL, clothing.ThumbnailURL}
+	clothing.ImageURL = uploaded.ImageURL
+	clothing.ThumbnailURL = uploaded.ThumbnailURL
+	if err := uc.clothingRepo.Update(clothing); err != nil {
+		uc.deleteBlobs(ctx, keys...)
+		return nil, fmt.Errorf("failed to update clothing item: %w", err)
+	}
+
+	// 差し替え前の画像がこのユーザーのアップロードしたものであれば削除する
+	for _, imageURL := range previous {
+		if key, ok := uc.keyFromURL(imageURL, userID); ok {
+			uc.deleteBlobs(ctx, key)
+		}
+	}
+	return result, nil
+}
+
+// 署名を検証して画像を開く（呼び出し側で Body を閉じる）
+func (uc *ImageUseCase) OpenImage(ctx context.Context, path string, signature string) (*repositories.Blob, error) {
+	if !hmac.Equal([]byte(signature), []byte(uc.sign(path))) {
+		return nil, ErrInvalidImageSignature
+	}
+	blob, err := uc.blobStore.Get(ctx, imageKeyPrefix+path)
+	if errors.Is(err, repositories.ErrBlobNotFound) {
+		return nil, ErrImageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// 画像とサムネイルを保存し、署名付きURLと保存したキーを返す
+func (uc *ImageUseCase) store(ctx context.Context, userID string, data []byte, contentType string) (*UploadedImage, []string, error) {
+	if userID == "" {
+		return nil, nil, errors.New("user ID is required")
+	}
+
+	processed, err := uc.imageService.Process(data, contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := userID + "/" + entities.NewID()
+	imagePath := name + processed.Extension
+	thumbnailPath := name + "_thumb" + processed.Extension
+	imageKey, thumbnailKey := imageKeyPrefix+imagePath, imageKeyPrefix+thumbnailPath
+
+	if err := uc.blobStore.Put(ctx, imageKey, processed.Data, processed.ContentType); err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to store image: %w", ErrImageStorage, err)
+	}
+	if err := uc.blobStore.Put(ctx, thumbnailKey, processed.Thumbnail, processed.ContentType); err != nil {
+		uc.deleteBlobs(ctx, imageKey)
+		return nil, nil, fmt.Errorf("%w: failed to store thumbnail: %w", ErrImageStorage, err)
+	}
+
+	uploaded := &UploadedImage{
+		ImageURL:     uc.signedURL(imagePath),
+		ThumbnailURL: uc.signedURL(thumbnailPath),
+		ContentType:  processed.ContentType,
+		Width:        processed.Width,
+		Height:       processed.Height,
+		Colors:       make([]ColorSuggestion, 0, len(processed.Colors)),
+	}
+	for _, color := range processed.Colors {
+		uploaded.Colors = append(uploaded.Colors, ColorSuggestion{
+			Name:        color.Color.Name,
+			DisplayName: color.Color.DisplayName,
+			Hex:         color.Hex,
+			Share:       color.Share,
+		})
+	}
+	if len(uploaded.Colors) > 0 {
+		uploaded.SuggestedColor = uploaded.Colors[0].DisplayName
+	}
+	return uploaded, []string{imageKey, thumbnailKey}, nil
+}
+
+// 画像の署名付きURL（/api/images/{path}?sig={署名}）
+func (uc *ImageUseCase) signedURL(path string) string {
+	return ImageRoutePrefix + path + "?sig=" + uc.sign(path)
+}
+
+// 画像のパスに対する HMAC-SHA256 署名（URL セーフな Base64）
+func (uc *ImageUseCase) sign(path string) string {
+	mac := hmac.New(sha256.New, uc.signingKey)
+	mac.Write([]byte(path))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// userID のユーザーがアップロードした画像の署名付きURLから保存先のキーを取り出す（それ以外のURLは false）
+func (uc *ImageUseCase) keyFromURL(imageURL string, userID string) (string, bool) {
+	u, err := url.Parse(imageURL)
+	if err != nil || u.IsAbs() || !strings.HasPrefix(u.Path, ImageRoutePrefix) {
+		return "", false
+	}
+	path := strings.TrimPrefix(u.Path, ImageRoutePrefix)
+	if !hmac.Equal([]byte(u.Query().Get("sig")), []byte(uc.sign(path))) {
+		return "", false
+	}
+	if userID == "" || !strings.HasPrefix(path, userID+"/") {
+		return "", false
+	}
+	return imageKeyPrefix + path, true
+}
+
+// 画像のURLが他のユーザーのアップロードした画像を指していないかを確認
+// 画像の配信ルート以外のURL（外部の画像など）と空のURLは対象外とする
+func checkImageOwner(userID string, imageURLs ...string) error {
+	for _, imageURL := range imageURLs {
+		if imageURL == "" {
+			continue
+		}
+		u, err := url.Parse(imageURL)
+		if err != nil {
+			continue
+		}
+		p := path.Clean("/" + u.Path)
+		if !strings.HasPrefix(p+"/", ImageRoutePrefix) {
+			continue
+		}
+		if userID == "" || !strings.HasPrefix(p, ImageRoutePrefix+userID+"/") {
+			return fmt.Errorf("%w: %s", ErrForeignImage, imageURL)
+		}
+	}
+	return nil
+}
+
+// 不要になった画像を削除（失敗しても処理は続行する）
+func (uc *ImageUseCase) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := uc.blobStore.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete image %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/services"
+)
+
+func newTestImageUseCase(store *testStore) (*ImageUseCase, *memoryBlobStore) {
+	blobs := newMemoryBlobStore()
+	uc := NewImageUseCase(services.NewImageService(services.ImageOptions{}), blobs, store.clothing, []byte("test-signing-key"))
+	return uc, blobs
+}
+
+func testPNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// 署名付きURLから画像のパスと署名を取り出す
+func splitImageURL(t *testing.T, imageURL string) (string, string) {
+	t.Helper()
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		t.Fatalf("parse %q: %v", imageURL, err)
+	}
+	return strings.TrimPrefix(u.Path, ImageRoutePrefix), u.Query().Get("sig")
+}
+
+func createTestClothing(t *testing.T, store *testStore, userID string, mutate func(*entities.ClothingItem)) *entities.ClothingItem {
+	t.Helper()
+	item := &entities.ClothingItem{
+		UserID:       userID,
+		Name:         "シャツ",
+		Type:         "シャツ",
+		Category:     string(entities.CategoryTops),
+		Color:        "白",
+		Availability: entities.AvailabilityAvailable,
+		CreatedAt:    time.Now(),
+	}
+	if mutate != nil {
+		mutate(item)
+	}
+	if err := store.clothing.Create(item); err != nil {
+		t.Fatalf("create clothing: %v", err)
+	}
+	return item
+}
+
+func TestOpenImageRejectsInvalidSignatures(t *testing.T) {
+	ctx := context.Background()
+	uc, _ := newTestImageUseCase(newTestStore())
+	uploaded, err := uc.UploadImage(ctx, "user-a", testPNG(t, color.White), "image/png")
+	if err != nil {
+		t.Fatalf("UploadImage: %v", err)
+	}
+	imagePath, sig := splitImageURL(t, uploaded.ImageURL)
+	thumbPath, thumbSig := splitImageURL(t, uploaded.ThumbnailURL)
+
+	blob, err := uc.OpenImage(ctx, imagePath, sig)
+	if err != nil {
+		t.Fatalf("OpenImage with a valid signature: %v", err)
+	}
+	blob.Body.Close()
+
+	other := NewImageUseCase(services.NewImageService(services.ImageOptions{}), uc.blobStore, nil, []byte("another-key"))
+	_, otherSig := splitImageURL(t, other.signedURL(imagePath))
+
+	tests := []struct {
+		name string
+		path string
+		sig  string
+	}{
+		{"missing signature", imagePath, ""},
+		{"garbage signature", imagePath, "not-a-signature"},
+		{"signature of another image", imagePath, thumbSig},
+		{"path of another image", thumbPath, sig},
+		{"signature made with another key", imagePath, otherSig},
+		{"truncated signature", imagePath, sig[:len(sig)-1]},
+		{"tampered path", "user-b/" + strings.TrimPrefix(imagePath, "user-a/"), sig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.OpenImage(ctx, tt.path, tt.sig); !errors.Is(err, ErrInvalidImageSignature) {
+				t.Errorf("OpenImage error = %v, want ErrInvalidImageSignature", err)
+			}
+		})
+	}
+
+	missing := "user-a/missing.png"
+	if _, err := uc.OpenImage(ctx, missing, uc.sign(missing)); !errors.Is(err, ErrImageNotFound) {
+		t.Errorf("OpenImage of a missing image error = %v, want ErrImageNotFound", err)
+	}
+}
+
+func TestUploadImageStorageFailure(t *testing.T) {
+	uc, blobs := newTestImageUseCase(newTestStore())
+	blobs.failPut = true
+
+	_, err := uc.UploadImage(context.Background(), "user-a", testPNG(t, color.White), "image/png")
+	if !errors.Is(err, ErrImageStorage) {
+		t.Errorf("UploadImage error = %v, want ErrImageStorage", err)
+	}
+}
+
+func TestAttachClothingImageReplacesOwnImage(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore()
+	uc, blobs := newTestImageUseCase(store)
+	item := createTestClothing(t, store, "user-a", func(c *entities.ClothingItem) { c.Color = "独自の色" })
+
+	first, err := uc.AttachClothingImage(ctx, item.ID, "user-a", testPNG(t, color.RGBA{R: 20, G: 30, B: 110, A: 255}), "image/png", 0, false)
+	if err != nil {
+		t.Fatalf("AttachClothingImage: %v", err)
+	}
+	if !first.ColorApplied || first.Clothing.Color != "ネイビー" {
+		t.Errorf("color = %q (applied %v), want ネイビー applied", first.Clothing.Color, first.ColorApplied)
+	}
+	firstPath, _ := splitImageURL(t, first.Clothing.ImageURL)
+
+	second, err := uc.AttachClothingImage(ctx, item.ID, "user-a", testPNG(t, color.White), "image/png", 0, false)
+	if err != nil {
+		t.Fatalf("AttachClothingImage (replace): %v", err)
+	}
+	if second.ColorApplied {
+		t.Error("recognised color was overwritten without apply_color")
+	}
+	if blobs.has(imageKeyPrefix + firstPath) {
+		t.Error("previous image was not deleted")
+	}
+	secondPath, _ := splitImageURL(t, second.Clothing.ImageURL)
+	if !blobs.has(imageKeyPrefix + secondPath) {
+		t.Error("new image is missing")
+	}
+}
+
+func TestAttachClothingImageKeepsOtherUsersImage(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore()
+	uc, blobs := newTestImageUseCase(store)
+
+	victim, err := uc.UploadImage(ctx, "user-b", testPNG(t, color.White), "image/png")
+	if err != nil {
+		t.Fatalf("UploadImage: %v", err)
+	}
+
+	// 検証を経ずに保存された他のユーザーの署名付きURL（検証の導入前に保存されたデータなど）
+	item := createTestClothing(t, store, "user-a", func(c *entities.ClothingItem) {
+		c.ImageURL = victim.ImageURL
+		c.ThumbnailURL = victim.ThumbnailURL
+	})
+	if _, err := uc.AttachClothingImage(ctx, item.ID, "user-a", testPNG(t, color.Black), "image/png", 0, false); err != nil {
+		t.Fatalf("AttachClothingImage: %v", err)
+	}
+
+	for _, imageURL := range []string{victim.ImageURL, victim.ThumbnailURL} {
+		victimPath, _ := splitImageURL(t, imageURL)
+		if !blobs.has(imageKeyPrefix + victimPath) {
+			t.Errorf("another user's image %s was deleted", victimPath)
+		}
+	}
+}
+
+func TestAttachClothingImageRejectsOtherUsersItem(t *testing.T) {
+	store := newTestStore()
+	uc, _ := newTestImageUseCase(store)
+	item := createTestClothing(t, store, "user-b", nil)
+
+	if _, err := uc.AttachClothingImage(context.Background(), item.ID, "user-a", testPNG(t, color.White), "image/png", 0, false); err == nil {
+		t.Error("AttachClothingImage on another user's item succeeded")
+	}
+}
+
+func TestCheckImageOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		imageURL string
+		wantErr  bool
+	}{
+		{"empty", "", false},
+		{"own image", "/api/images/user-a/1.png?sig=x", false},
+		{"external image", "https://example.com/photo.jpg", false},
+		{"other user's image", "/api/images/user-b/1.png?sig=x", true},
+		{"other user's image with host", "https://tenkiru.example/api/images/user-b/1.png?sig=x", true},
+		{"traversal to other user", "/api/images/user-a/../user-b/1.png?sig=x", true},
+		{"user ID prefix", "/api/images/user-ab/1.png?sig=x", true},
+		{"image route root", "/api/images/1.png?sig=x", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkImageOwner("user-a", tt.imageURL)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkImageOwner(%q) error = %v, want error %v", tt.imageURL, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrForeignImage) {
+				t.Errorf("checkImageOwner(%q) error = %v, want ErrForeignImage", tt.imageURL, err)
+			}
+		})
+	}
+}
+
+func TestClothingAndOutfitRejectForeignImageURLs(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore()
+	images, _ := newTestImageUseCase(store)
+	clothing := NewClothingUseCase(store.clothing, store.wearLogs, store.uow, 0)
+	outfits := NewOutfitUseCase(store.outfitPosts, store.uow)
+
+	victim, err := images.UploadImage(ctx, "user-b", testPNG(t, color.White), "image/png")
+	if err != nil {
+		t.Fatalf("UploadImage: %v", err)
+	}
+	req := CreateClothingRequest{UserID: "user-a", Name: "シャツ", Type: "シャツ", Category: "トップス", Color: "白", ImageURL: victim.ImageURL}
+
+	if _, err := clothing.CreateClothingItem(req); !errors.Is(err, ErrForeignImage) {
+		t.Errorf("CreateClothingItem error = %v, want ErrForeignImage", err)
+	}
+
+	req.ImageURL = ""
+	item, err := clothing.CreateClothingItem(req)
+	if err != nil {
+		t.Fatalf("CreateClothingItem: %v", err)
+	}
+	req.ThumbnailURL = victim.ThumbnailURL
+	if _, err := clothing.UpdateClothingItem(item.ID, "user-a", req, 0); !errors.Is(err, ErrForeignImage) {
+		t.Errorf("UpdateClothingItem error = %v, want ErrForeignImage", err)
+	}
+
+	_, err = outfits.CreateOutfitPost(CreateOutfitPostRequest{UserID: "user-a", UserName: "A", Items: []string{item.ID}, ImageURL: victim.ImageURL, Description: "今日のコーデ"})
+	if !errors.Is(err, ErrForeignImage) {
+		t.Errorf("CreateOutfitPost error = %v, want ErrForeignImage", err)
+	}
+
+	own, err := images.UploadImage(ctx, "user-a", testPNG(t, color.White), "image/png")
+	if err != nil {
+		t.Fatalf("UploadImage: %v", err)
+	}
+	req.ImageURL, req.ThumbnailURL = own.ImageURL, own.ThumbnailURL
+	if _, err := clothing.UpdateClothingItem(item.ID, "user-a", req, 0); err != nil {
+		t.Errorf("UpdateClothingItem with own image: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,45 +11,63 @@ import (
 
 type OutfitUseCase struct {
 	outfitRepo repositories.OutfitPostRepository
+
+	// uow 投稿の保存と着用記録をアトミックに実行するための UnitOfWork
+	uow repositories.UnitOfWork
 }
 
-func NewOutfitUseCase(outfitRepo repositories.OutfitPostRepository) *OutfitUseCase {
+func NewOutfitUseCase(outfitRepo repositories.OutfitPostRepository, uow repositories.UnitOfWork) *OutfitUseCase {
 	return &OutfitUseCase{
 		outfitRepo: outfitRepo,
+		uow:        uow,
 	}
 }
 
 type CreateOutfitPostRequest struct {
-	UserID      string   `json:"user_id"`
-	UserName    string   `json:"user_name"`
-	ImageURL    string   `json:"image_url"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
-	Location    string   `json:"location"`
-	Temperature float64  `json:"temperature"`
+	UserID       string   `json:"user_id"`
+	UserName     string   `json:"user_name"`
+	Items        []string `json:"items"` // 着用した衣服アイテムのID（クローゼットのアイテムは着用記録にも追加）
+	ImageURL     string   `json:"image_url"`
+	ThumbnailURL string   `json:"thumbnail_url"` // POST /api/images で生成したサムネイルのURL
+	Description  string   `json:"description"`
+	Tags         []string `json:"tags"`
+	Location     string   `json:"location"`
+	Temperature  float64  `json:"temperature"`
 }
 
 func (uc *OutfitUseCase) CreateOutfitPost(req CreateOutfitPostRequest) (*entities.OutfitPost, error) {
 	outfitPost := &entities.OutfitPost{
-		UserID:      req.UserID,
-		UserName:    req.UserName,
-		ImageURL:    req.ImageURL,
-		Description: req.Description,
-		Tags:        req.Tags,
-		Location:    req.Location,
-		Temperature: req.Temperature,
-		Likes:       0,
-		CreatedAt:   time.Now(),
+		UserID:       req.UserID,
+		UserName:     req.UserName,
+		Items:        req.Items,
+		ImageURL:     req.ImageURL,
+		ThumbnailURL: req.ThumbnailURL,
+		Description:  req.Description,
+		Tags:         req.Tags,
+		Location:     req.Location,
+		Temperature:  req.Temperature,
+		Likes:        0,
+		CreatedAt:    time.Now(),
 	}
 
 	// Validate outfit post
 	if err := outfitPost.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid outfit post data: %w", err)
 	}
+	if err := checkImageOwner(outfitPost.UserID, outfitPost.ImageURL, outfitPost.ThumbnailURL); err != nil {
+		return nil, fmt.Errorf("invalid outfit post data: %w", err)
+	}
 
-	// Save outfit post
-	if err := uc.outfitRepo.Create(outfitPost); err != nil {
-		return nil, fmt.Errorf("failed to create outfit post: %w", err)
+	// Save outfit post and log the clothing items worn in it
+	err := uc.uow.Do(func(tx repositories.Transaction) error {
+		if err := tx.OutfitPosts().Create(outfitPost); err != nil {
+			return fmt.Errorf("failed to create outfit post: %w", err)
+		}
+		_, err := recordWear(tx, outfitPost.UserID, outfitPost.Items, entities.WearDate(outfitPost.CreatedAt), entities.WearSourceOutfitPost, outfitPost.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return outfitPost, nil
@@ -66,17 +85,29 @@ func (uc *OutfitUseCase) GetOutfitPostByID(id string) (*entities.OutfitPost, err
 	return uc.outfitRepo.GetByID(id)
 }
 
+// Maximum number of attempts when a like collides with a concurrent update
+const likeMaxAttempts = 5
+
 func (uc *OutfitUseCase) LikeOutfitPost(id string, userID string) error {
-	outfitPost, err := uc.outfitRepo.GetByID(id)
-	if err != nil {
-		return fmt.Errorf("outfit post not found: %w", err)
+	var err error
+	for attempt := 0; attempt < likeMaxAttempts; attempt++ {
+		var outfitPost *entities.OutfitPost
+		outfitPost, err = uc.outfitRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("outfit post not found: %w", err)
+		}
+
+		// Increment likes
+		outfitPost.Likes++
+
+		// Save updated outfit post; retry with a fresh copy if another like won the race
+		err = uc.outfitRepo.Update(outfitPost)
+		if !errors.Is(err, repositories.ErrConflict) {
+			break
+		}
 	}
 
-	// Increment likes
-	outfitPost.Likes++
-
-	// Save updated outfit post
-	if err := uc.outfitRepo.Update(outfitPost); err != nil {
+	if err != nil {
 		return fmt.Errorf("failed to update outfit post: %w", err)
 	}
 
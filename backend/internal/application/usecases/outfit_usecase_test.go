@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// 最初の conflicts 回の Update で他の更新が先に入ったことにする outfit 投稿リポジトリ
+type racingOutfitPostRepository struct {
+	repositories.OutfitPostRepository
+	conflicts int
+}
+
+func (r *racingOutfitPostRepository) Update(post *entities.OutfitPost) error {
+	if r.conflicts > 0 {
+		r.conflicts--
+		// 他のリクエストのいいねを保存してから競合を返す
+		current, err := r.OutfitPostRepository.GetByID(post.ID)
+		if err != nil {
+			return err
+		}
+		current.Likes++
+		if err := r.OutfitPostRepository.Update(current); err != nil {
+			return err
+		}
+		return &repositories.ConflictError{Entity: "outfit post", ID: post.ID, ExpectedVersion: post.Version, CurrentVersion: current.Version}
+	}
+	return r.OutfitPostRepository.Update(post)
+}
+
+func TestLikeOutfitPostRetriesOnConflict(t *testing.T) {
+	s := newTestStore()
+	post := &entities.OutfitPost{UserID: "user-2", Items: []string{"シャツ"}}
+	if err := s.outfitPosts.Create(post); err != nil {
+		t.Fatal(err)
+	}
+	repo := &racingOutfitPostRepository{OutfitPostRepository: s.outfitPosts, conflicts: 2}
+	uc := NewOutfitUseCase(repo, s.uow)
+
+	if err := uc.LikeOutfitPost(post.ID, "user-1"); err != nil {
+		t.Fatalf("LikeOutfitPost: %v", err)
+	}
+	stored, _ := s.outfitPosts.GetByID(post.ID)
+	if stored.Likes != 3 {
+		t.Errorf("likes = %d, want 3 (2 concurrent likes and ours)", stored.Likes)
+	}
+
+	// 競合が続く場合は諦めて競合エラーを返す
+	repo.conflicts = likeMaxAttempts
+	if err := uc.LikeOutfitPost(post.ID, "user-1"); !errors.Is(err, repositories.ErrConflict) {
+		t.Errorf("LikeOutfitPost under constant contention error = %v, want ErrConflict", err)
+	}
+}
+
+func TestUpdateClothingItemChecksExpectedVersion(t *testing.T) {
+	s := newTestStore()
+	uc := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0)
+	item, err := uc.CreateClothingItem(CreateClothingRequest{UserID: "user-1", Name: "シャツ", Type: "shirt", Category: "tops", Color: "navy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := CreateClothingRequest{Name: "白シャツ", Type: "shirt", Category: "tops", Color: "white"}
+
+	updated, err := uc.UpdateClothingItem(item.ID, "user-1", req, item.Version)
+	if err != nil {
+		t.Fatalf("UpdateClothingItem: %v", err)
+	}
+	if updated.Version != item.Version+1 {
+		t.Errorf("version = %d, want %d", updated.Version, item.Version+1)
+	}
+
+	// 古いバージョンを指定した更新は拒否する
+	if _, err := uc.UpdateClothingItem(item.ID, "user-1", req, item.Version); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("UpdateClothingItem with a stale version error = %v, want ErrPreconditionFailed", err)
+	}
+	// バージョンを指定しない場合は確認しない
+	if _, err := uc.UpdateClothingItem(item.ID, "user-1", req, 0); err != nil {
+		t.Errorf("UpdateClothingItem without a version: %v", err)
+	}
+}
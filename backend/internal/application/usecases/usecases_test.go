@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/domain/services"
+	infrarepo "forecast-app/internal/infrastructure/repositories"
+)
+
+// ユースケースのテストで使用するインメモリのリポジトリ一式
+type testStore struct {
+	users           *infrarepo.InMemoryUserRepository
+	clothing        *infrarepo.InMemoryClothingRepository
+	recommendations *infrarepo.InMemoryFashionRecommendationRepository
+	outfitPosts     *infrarepo.InMemoryOutfitPostRepository
+	wearLogs        *infrarepo.InMemoryWearLogRepository
+	uow             *infrarepo.InMemoryUnitOfWork
+}
+
+func newTestStore() *testStore {
+	s := &testStore{
+		users:           infrarepo.NewInMemoryUserRepository(),
+		wearLogs:        infrarepo.NewInMemoryWearLogRepository(),
+		recommendations: infrarepo.NewInMemoryFashionRecommendationRepository(),
+		outfitPosts:     infrarepo.NewInMemoryOutfitPostRepository(),
+	}
+	s.clothing = infrarepo.NewInMemoryClothingRepository(s.wearLogs)
+	s.uow = infrarepo.NewInMemoryUnitOfWork(s.users, s.clothing, s.recommendations, s.outfitPosts, s.wearLogs)
+	return s
+}
+
+// テスト用のインメモリ BlobStore（failPut を設定すると Put が失敗する）
+type memoryBlobStore struct {
+	mutex   sync.Mutex
+	blobs   map[string][]byte
+	failPut bool
+}
+
+func newMemoryBlobStore() *memoryBlobStore {
+	return &memoryBlobStore{blobs: make(map[string][]byte)}
+}
+
+func (s *memoryBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.failPut {
+		return fmt.Errorf("disk full")
+	}
+	s.blobs[key] = append([]byte{}, data...)
+	return nil
+}
+
+func (s *memoryBlobStore) Get(ctx context.Context, key string) (*repositories.Blob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", key, repositories.ErrBlobNotFound)
+	}
+	return &repositories.Blob{Body: io.NopCloser(bytes.NewReader(data)), ContentType: "application/octet-stream", Size: int64(len(data))}, nil
+}
+
+func (s *memoryBlobStore) Delete(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+func (s *memoryBlobStore) has(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.blobs[key]
+	return ok
+}
+
+// テスト用の天気リポジトリ（setErr で設定したエラーを返す）
+type fakeWeatherRepository struct {
+	mutex     sync.Mutex
+	condition entities.WeatherCondition
+	forecast  entities.WeatherForecast
+	err       error
+}
+
+func (r *fakeWeatherRepository) setErr(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.err = err
+}
+
+func (r *fakeWeatherRepository) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	condition := r.condition
+	return &condition, nil
+}
+
+func (r *fakeWeatherRepository) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+	forecast := r.forecast
+	return &forecast, nil
+}
+
+func newTestFashionUseCase(s *testStore, weather repositories.WeatherRepository) *FashionUseCase {
+	return NewFashionUseCase(services.NewFashionRecommendationService(), weather, s.clothing, s.recommendations, s.users, s.wearLogs, s.uow, services.NewRecommendationStrategy(services.StrategyRules), 0)
+}
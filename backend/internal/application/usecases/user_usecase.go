@@ -10,6 +10,7 @@ import (
 
 	"forecast-app/internal/domain/entities"
 	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/domain/services"
 )
 
 // ユーザー関連のビジネスロジックを実装するユースケース
@@ -17,14 +18,18 @@ type UserUseCase struct {
 	// userRepo ユーザーデータの永続化を担当するリポジトリ
 	userRepo repositories.UserRepository
 	
+	// uow 複数ステップの処理をアトミックに実行するための UnitOfWork
+	uow repositories.UnitOfWork
+	
 	// jwtSecret JWT Token生成・検証用の秘密鍵
 	jwtSecret []byte
 }
 
 // ユーザーユースケースの新しいインスタンスを作成
-func NewUserUseCase(userRepo repositories.UserRepository, jwtSecret string) *UserUseCase {
+func NewUserUseCase(userRepo repositories.UserRepository, uow repositories.UnitOfWork, jwtSecret string) *UserUseCase {
 	return &UserUseCase{
 		userRepo:  userRepo,
+		uow:       uow,
 		jwtSecret: []byte(jwtSecret),
 	}
 }
@@ -50,12 +55,6 @@ type AuthResponse struct {
 
 // 新しいユーザーを登録し、認証トークンを発行
 func (uc *UserUseCase) Register(req RegisterRequest) (*AuthResponse, error) {
-	// メールアドレスの重複チェック
-	existingUser, _ := uc.userRepo.GetByEmail(req.Email)
-	if existingUser != nil {
-		return nil, errors.New("このメールアドレスは既に使用されています")
-	}
-
 	// パスワードのハッシュ化
 	// bcrypt アルゴリズムを使用してセキュアに暗号化
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -77,9 +76,22 @@ func (uc *UserUseCase) Register(req RegisterRequest) (*AuthResponse, error) {
 		return nil, fmt.Errorf("無効なユーザーデータです: %w", err)
 	}
 
-	// ユーザーの永続化
-	if err := uc.userRepo.Create(user); err != nil {
-		return nil, fmt.Errorf("ユーザーの作成に失敗しました: %w", err)
+	// 重複チェックと永続化を同一トランザクションで実行
+	err = uc.uow.Do(func(tx repositories.Transaction) error {
+		// メールアドレスの重複チェック
+		existingUser, _ := tx.Users().GetByEmail(req.Email)
+		if existingUser != nil {
+			return errors.New("このメールアドレスは既に使用されています")
+		}
+
+		// ユーザーの永続化
+		if err := tx.Users().Create(user); err != nil {
+			return fmt.Errorf("ユーザーの作成に失敗しました: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// JWT トークンの生成
@@ -139,13 +151,24 @@ func (uc *UserUseCase) GetProfile(userID string) (*entities.User, error) {
 }
 
 // ユーザープロフィール情報を更新
-func (uc *UserUseCase) UpdateProfile(userID string, name string, preferences *entities.UserPreferences) (*entities.User, error) {
+// expectedVersion が 0 より大きい場合、保存済みのバージョンと一致しなければ ErrPreconditionFailed を返す
+// 存在しない推奨戦略名を指定した場合は ErrUnknownRecommendationStrategy を返す（空の場合はサーバーの既定値を使用）
+func (uc *UserUseCase) UpdateProfile(userID string, name string, preferences *entities.UserPreferences, expectedVersion int) (*entities.User, error) {
+	if preferences != nil && preferences.RecommendationStrategy != "" && services.NewRecommendationStrategy(preferences.RecommendationStrategy) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRecommendationStrategy, preferences.RecommendationStrategy)
+	}
+
 	// 既存ユーザーの取得
 	user, err := uc.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, fmt.Errorf("ユーザーが見つかりません: %w", err)
 	}
 
+	// クライアントが参照したバージョンから変更されていないかを確認
+	if expectedVersion > 0 && user.Version != expectedVersion {
+		return nil, ErrPreconditionFailed
+	}
+
 	// ユーザーデータの更新
 	user.Name = name
 	if preferences != nil {
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/services"
+)
+
+func TestUpdateProfileValidatesRecommendationStrategy(t *testing.T) {
+	s := newTestStore()
+	uc := NewUserUseCase(s.users, s.uow, "secret")
+	user := createTestUser(t, s)
+
+	// 存在しない戦略名は保存せずに拒否する
+	_, err := uc.UpdateProfile(user.ID, user.Name, &entities.UserPreferences{RecommendationStrategy: "random"}, 0)
+	if !errors.Is(err, ErrUnknownRecommendationStrategy) {
+		t.Fatalf("UpdateProfile error = %v, want ErrUnknownRecommendationStrategy", err)
+	}
+	if got, _ := s.users.GetByID(user.ID); got.Preferences != nil && got.Preferences.RecommendationStrategy != "" {
+		t.Errorf("stored strategy = %q, want unchanged", got.Preferences.RecommendationStrategy)
+	}
+
+	// 定義済みの戦略名と空（サーバーの既定値）は受け付ける
+	for _, name := range []string{services.StrategyWeighted, services.StrategyRules, ""} {
+		updated, err := uc.UpdateProfile(user.ID, user.Name, &entities.UserPreferences{RecommendationStrategy: name}, 0)
+		if err != nil {
+			t.Fatalf("UpdateProfile(%q): %v", name, err)
+		}
+		if updated.Preferences.RecommendationStrategy != name {
+			t.Errorf("strategy = %q, want %q", updated.Preferences.RecommendationStrategy, name)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// 着用日の指定形式
+const wearDateLayout = "2006-01-02"
+
+// 着用日の指定を解釈（未指定の場合は now の日付）
+// 未来の日付は記録できないが、タイムゾーンの差を考慮して翌日までは受け付ける
+func parseWearDate(value string, now time.Time) (time.Time, error) {
+	today := entities.WearDate(now)
+	if value == "" {
+		return today, nil
+	}
+
+	wornOn, err := time.Parse(wearDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("着用日は YYYY-MM-DD 形式で指定してください: %s", value)
+	}
+	if wornOn.After(today.AddDate(0, 0, 1)) {
+		return time.Time{}, fmt.Errorf("未来の日付は着用日に指定できません: %s", value)
+	}
+	return wornOn, nil
+}
+
+// ユーザーが所有する衣服アイテムの着用を記録し、新たに記録したものを返す
+// 所有していない・削除済みのアイテムと、同じ日に記録済みのアイテムは読み飛ばす
+func recordWear(tx repositories.Transaction, userID string, clothingIDs []string, wornOn time.Time, source entities.WearSource, sourceID string) ([]*entities.WearLogEntry, error) {
+	recorded := []*entities.WearLogEntry{}
+	seen := make(map[string]bool)
+	for _, clothingID := range clothingIDs {
+		if clothingID == "" || seen[clothingID] {
+			continue
+		}
+		seen[clothingID] = true
+
+		item, err := tx.Clothing().GetByID(clothingID)
+		if err != nil || item.UserID != userID {
+			continue
+		}
+
+		entry := &entities.WearLogEntry{
+			UserID:     userID,
+			ClothingID: clothingID,
+			WornOn:     wornOn,
+			Source:     source,
+			SourceID:   sourceID,
+			CreatedAt:  time.Now(),
+		}
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("無効な着用記録です: %w", err)
+		}
+		if err := tx.WearLogs().Create(entry); err != nil {
+			if errors.Is(err, repositories.ErrDuplicateKey) {
+				continue
+			}
+			return nil, fmt.Errorf("着用記録の保存に失敗しました: %w", err)
+		}
+		recorded = append(recorded, entry)
+	}
+	return recorded, nil
+}
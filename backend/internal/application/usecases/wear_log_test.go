@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func TestParseWearDate(t *testing.T) {
+	now := time.Date(2024, 5, 10, 23, 0, 0, 0, time.UTC)
+	if got, err := parseWearDate("", now); err != nil || !got.Equal(time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseWearDate(\"\") = %v, %v, want today", got, err)
+	}
+	// タイムゾーンの差を考慮して翌日までは受け付ける
+	if _, err := parseWearDate("2024-05-11", now); err != nil {
+		t.Errorf("parseWearDate(tomorrow) error = %v", err)
+	}
+	for _, value := range []string{"2024-05-12", "2024/05/01", "yesterday"} {
+		if _, err := parseWearDate(value, now); err == nil {
+			t.Errorf("parseWearDate(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestAcceptRecommendationRecordsWear(t *testing.T) {
+	s := newTestStore()
+	uc := newTestFashionUseCase(s, &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 18, FeelsLike: 18}})
+	closet := createOutfitCloset(t, s, "user-1")
+	recommendation, err := uc.GetRecommendations(context.Background(), RecommendationRequest{UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := uc.AcceptRecommendation("user-1", recommendation.ID, AcceptRequest{WornOn: "2024-05-01"})
+	if err != nil {
+		t.Fatalf("AcceptRecommendation: %v", err)
+	}
+	if len(recorded) != len(closet) {
+		t.Fatalf("recorded = %d entries, want %d", len(recorded), len(closet))
+	}
+	for _, entry := range recorded {
+		if entry.Source != entities.WearSourceRecommendation || entry.SourceID != recommendation.ID || entry.WornOn.Format(wearDateLayout) != "2024-05-01" {
+			t.Errorf("entry = %+v, want a recommendation entry on 2024-05-01", entry)
+		}
+	}
+
+	// 同じ日に採用し直しても重複して記録しない
+	again, err := uc.AcceptRecommendation("user-1", recommendation.ID, AcceptRequest{WornOn: "2024-05-01"})
+	if err != nil || len(again) != 0 {
+		t.Errorf("second AcceptRecommendation = %d entries, %v, want none", len(again), err)
+	}
+
+	history, err := NewClothingUseCase(s.clothing, s.wearLogs, s.uow, 0).GetWearHistory(closet[0].ID, "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.WearCount != 1 || history.LastWornOn == nil || history.LastWornOn.Format(wearDateLayout) != "2024-05-01" {
+		t.Errorf("history = %+v, want one wear on 2024-05-01", history)
+	}
+
+	if _, err := uc.AcceptRecommendation("user-2", recommendation.ID, AcceptRequest{}); !errors.Is(err, ErrRecommendationNotFound) {
+		t.Errorf("other user's accept error = %v, want ErrRecommendationNotFound", err)
+	}
+	if _, err := uc.AcceptRecommendation("user-1", recommendation.ID, AcceptRequest{OutfitRank: 9}); err == nil {
+		t.Error("accepting an unknown outfit rank succeeded, want an error")
+	}
+}
+
+func TestCreateOutfitPostRecordsOwnItems(t *testing.T) {
+	s := newTestStore()
+	uc := NewOutfitUseCase(s.outfitPosts, s.uow)
+	own := createOutfitCloset(t, s, "user-1")
+	others := createOutfitCloset(t, s, "user-2")
+
+	post, err := uc.CreateOutfitPost(CreateOutfitPostRequest{UserID: "user-1", UserName: "山田", Items: []string{own[0].ID, own[0].ID, others[0].ID, "フリーテキスト"}})
+	if err != nil {
+		t.Fatalf("CreateOutfitPost: %v", err)
+	}
+
+	// 他のユーザーのアイテムとクローゼットにないアイテムは記録しない
+	lastWorn, _ := s.wearLogs.GetLastWornByUserID("user-1")
+	if len(lastWorn) != 1 || lastWorn[own[0].ID].IsZero() {
+		t.Errorf("last worn = %v, want only %s", lastWorn, own[0].ID)
+	}
+	entries, _ := s.wearLogs.GetByClothingID(own[0].ID)
+	if len(entries) != 1 || entries[0].Source != entities.WearSourceOutfitPost || entries[0].SourceID != post.ID {
+		t.Errorf("entries = %+v, want one outfit post entry", entries)
+	}
+	if entries, _ := s.wearLogs.GetByClothingID(others[0].ID); len(entries) != 0 {
+		t.Errorf("other user's item has %d entries, want 0", len(entries))
+	}
+}
+
+func TestGetRecommendationsRotatesRecentlyWornItems(t *testing.T) {
+	s := newTestStore()
+	uc := newTestFashionUseCase(s, &fakeWeatherRepository{condition: entities.WeatherCondition{Temperature: 27, FeelsLike: 27}})
+	closet := createOutfitCloset(t, s, "user-1")
+	spare, err := entities.NewClothingItem("user-1", "Tシャツ", "Tシャツ", "navy", string(entities.CategoryTops))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.clothing.Create(spare); err != nil {
+		t.Fatal(err)
+	}
+	req := RecommendationRequest{UserID: "user-1", Latitude: 35.6895, Longitude: 139.6917}
+
+	// 重ね着しない暖かさで、昨日着たトップスより、同じ条件の着ていないトップスを優先する
+	worn := &entities.WearLogEntry{UserID: "user-1", ClothingID: closet[0].ID, WornOn: entities.WearDate(time.Now().AddDate(0, 0, -1)), Source: entities.WearSourceRecommendation, CreatedAt: time.Now()}
+	if err := s.wearLogs.Create(worn); err != nil {
+		t.Fatal(err)
+	}
+	recommendation, err := uc.GetRecommendations(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tops []string
+	for _, item := range recommendation.Items {
+		if item.Category == string(entities.CategoryTops) {
+			tops = append(tops, item.ClothingID)
+		}
+	}
+	if len(tops) != 1 || tops[0] != spare.ID {
+		t.Errorf("tops = %v, want only the unworn top %s", tops, spare.ID)
+	}
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// WeatherUseCase 天気情報の取得に関するユースケース
+type WeatherUseCase struct {
+	weatherRepo repositories.WeatherRepository
+}
+
+// 天気ユースケースの新しいインスタンスを作成
+func NewWeatherUseCase(weatherRepo repositories.WeatherRepository) *WeatherUseCase {
+	return &WeatherUseCase{
+		weatherRepo: weatherRepo,
+	}
+}
+
+// 指定された地点の天気予報（時間ごと・日ごと）を取得
+func (uc *WeatherUseCase) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	if err := validateCoordinates(latitude, longitude); err != nil {
+		return nil, err
+	}
+
+	forecast, err := uc.weatherRepo.GetForecast(ctx, latitude, longitude)
+	if err != nil {
+		return nil, fmt.Errorf("天気予報の取得に失敗しました: %w", err)
+	}
+
+	return forecast, nil
+}
+
+// 緯度経度が有効な範囲内かを検証
+func validateCoordinates(latitude, longitude float64) error {
+	if latitude < -90 || latitude > 90 {
+		return fmt.Errorf("%w: 緯度は -90〜90 の範囲で指定してください: %v", ErrInvalidCoordinates, latitude)
+	}
+	if longitude < -180 || longitude > 180 {
+		return fmt.Errorf("%w: 経度は -180〜180 の範囲で指定してください: %v", ErrInvalidCoordinates, longitude)
+	}
+	return nil
+}
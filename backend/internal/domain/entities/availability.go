@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// 衣類アイテムの利用状況
+type Availability string
+
+const (
+	AvailabilityAvailable Availability = "available" // 着用可能
+	AvailabilityLaundry   Availability = "laundry"   // 洗濯中
+	AvailabilityCleaners  Availability = "cleaners"  // クリーニングに出している
+	AvailabilityLent      Availability = "lent"      // 貸し出し中
+	AvailabilityStorage   Availability = "storage"   // 収納中（衣替えなど）
+)
+
+// 定義済みの利用状況かを確認
+func (a Availability) IsValid() bool {
+	switch a {
+	case AvailabilityAvailable, AvailabilityLaundry, AvailabilityCleaners, AvailabilityLent, AvailabilityStorage:
+		return true
+	}
+	return false
+}
+
+// 現在の利用状況（未設定の場合は着用可能とみなす）
+func (c *ClothingItem) CurrentAvailability() Availability {
+	if c.Availability == "" {
+		return AvailabilityAvailable
+	}
+	return c.Availability
+}
+
+// 着用可能かを確認
+func (c *ClothingItem) IsAvailable() bool {
+	return c.CurrentAvailability() == AvailabilityAvailable
+}
+
+// 利用状況を変更し、変更日時を記録
+// 状況が変わらない場合は変更日時を更新しない
+func (c *ClothingItem) SetAvailability(availability Availability, now time.Time) error {
+	if !availability.IsValid() {
+		return fmt.Errorf("invalid availability: %s", availability)
+	}
+	if c.CurrentAvailability() == availability {
+		return nil
+	}
+	c.Availability = availability
+	c.AvailabilityChangedAt = now
+	return nil
+}
+
+// 洗濯にかかる期間が経過したアイテムを着用可能に戻す
+// laundryCycle が0以下の場合は自動で戻さない（戻した場合は true を返し、変更日時は洗濯が終わった時点とする）
+func (c *ClothingItem) ReturnFromLaundry(now time.Time, laundryCycle time.Duration) bool {
+	if laundryCycle <= 0 || c.CurrentAvailability() != AvailabilityLaundry {
+		return false
+	}
+	returnAt := c.AvailabilityChangedAt.Add(laundryCycle)
+	if now.Before(returnAt) {
+		return false
+	}
+	c.Availability = AvailabilityAvailable
+	c.AvailabilityChangedAt = returnAt
+	return true
+}
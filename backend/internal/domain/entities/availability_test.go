@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetAvailability(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	item := &ClothingItem{}
+	if !item.IsAvailable() || item.CurrentAvailability() != AvailabilityAvailable {
+		t.Fatalf("unset availability = %q, want available", item.CurrentAvailability())
+	}
+
+	// 状況が変わらない場合は変更日時を更新しない
+	if err := item.SetAvailability(AvailabilityAvailable, now); err != nil || !item.AvailabilityChangedAt.IsZero() {
+		t.Errorf("SetAvailability(available) = %v, changed at %v, want no change", err, item.AvailabilityChangedAt)
+	}
+	if err := item.SetAvailability(AvailabilityLent, now); err != nil || item.IsAvailable() || !item.AvailabilityChangedAt.Equal(now) {
+		t.Errorf("SetAvailability(lent) = %v, item %+v, want lent since now", err, item)
+	}
+	if err := item.SetAvailability("lost", now.Add(time.Hour)); err == nil || item.Availability != AvailabilityLent {
+		t.Errorf("SetAvailability(lost) = %v, availability %q, want an error and no change", err, item.Availability)
+	}
+}
+
+func TestReturnFromLaundry(t *testing.T) {
+	sent := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	cycle := 48 * time.Hour
+	newItem := func(availability Availability) *ClothingItem {
+		return &ClothingItem{Availability: availability, AvailabilityChangedAt: sent}
+	}
+
+	if item := newItem(AvailabilityLaundry); item.ReturnFromLaundry(sent.Add(cycle-time.Minute), cycle) || item.IsAvailable() {
+		t.Error("item returned before the laundry cycle elapsed")
+	}
+	if item := newItem(AvailabilityLaundry); item.ReturnFromLaundry(sent.Add(5*cycle), 0) {
+		t.Error("item returned with automatic return disabled")
+	}
+	if item := newItem(AvailabilityCleaners); item.ReturnFromLaundry(sent.Add(5*cycle), cycle) {
+		t.Error("item at the cleaners returned automatically")
+	}
+
+	// 戻した場合の変更日時は洗濯が終わった時点
+	item := newItem(AvailabilityLaundry)
+	if !item.ReturnFromLaundry(sent.Add(3*cycle), cycle) || !item.IsAvailable() || !item.AvailabilityChangedAt.Equal(sent.Add(cycle)) {
+		t.Errorf("item = %+v, want available since %v", item, sent.Add(cycle))
+	}
+}
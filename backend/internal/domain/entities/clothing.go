@@ -2,21 +2,34 @@ package entities
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
 // ユーザーのクローゼット内の衣類アイテムを表現
 type ClothingItem struct {
-	ID          string    // ユニークな識別子
-	UserID      string    // 所有者のユーザーID
-	Name        string    // アイテム名
-	Type        string    // 衣類の種類（シャツ、パンツなど）
-	Color       string    // 色
-	Category    string    // カテゴリ（トップス、ボトムスなど）
-	Brand       string    // ブランド名
-	WarmthLevel int       // 保温レベル（1-10、天気推奨で使用）
-	ImageURL    string    // アイテムの画像URL
-	CreatedAt   time.Time // 登録日時
+	ID                    string       // ユニークな識別子
+	UserID                string       // 所有者のユーザーID
+	Name                  string       // アイテム名
+	Type                  string       // 衣類の種類（シャツ、パンツなど）
+	Color                 string       // 色
+	Category              string       // カテゴリ（トップス、ボトムスなど）
+	Brand                 string       // ブランド名
+	WarmthLevel           int          // 保温レベル（1-10、天気推奨で使用）
+	Seasons               []Season     // 着用する季節（未設定の場合は通年）
+	Material              string       // 素材（コットン、ウールなど）
+	Waterproof            bool         // 防水・撥水加工があるか
+	Windproof             bool         // 防風性があるか
+	Breathability         int          // 通気性（1-5、0は未設定で素材・名前から推定）
+	Formality             int          // フォーマル度（1: カジュアル〜5: フォーマル、0は未設定）
+	Size                  string       // サイズ表記（M、26cm など）
+	Notes                 string       // メモ
+	ImageURL              string       // アイテムの画像URL
+	ThumbnailURL          string       // 画像のサムネイルURL（アップロードした画像の場合にサーバーで生成）
+	Availability          Availability // 利用状況（洗濯中・貸し出し中などは推奨の対象外）
+	AvailabilityChangedAt time.Time    // 利用状況を最後に変更した日時
+	CreatedAt             time.Time    // 登録日時
+	Version               int          // 楽観的排他制御用のバージョン（更新のたびに1増加）
 }
 
 //有効な衣類カテゴリ定義
@@ -49,6 +62,7 @@ func NewClothingItem(userID, name, itemType, color, category string) (*ClothingI
 	}
 
 	return &ClothingItem{
+		ID:        NewID(),
 		UserID:    userID,
 		Name:      name,
 		Type:      itemType,
@@ -89,13 +103,88 @@ func (c *ClothingItem) Validate() error {
 	if c.Category == "" {
 		return errors.New("category is required")
 	}
-	return nil
+	if c.WarmthLevel < 0 || c.WarmthLevel > MaxWarmthLevel {
+		return errors.New("warmth level must be between 0 and 10")
+	}
+	if c.Availability != "" && !c.Availability.IsValid() {
+		return errors.New("availability must be one of available, laundry, cleaners, lent, storage")
+	}
+	return c.validateAttributes()
+}
+
+// 保温レベルの上限
+const MaxWarmthLevel = 10
+
+// 保温レベル未設定時にカテゴリから推定する値
+var defaultWarmthByCategory = map[ClothingCategory]int{
+	CategoryTops:      3,
+	CategoryBottoms:   3,
+	CategoryOuterwear: 5,
+	CategoryShoes:     2,
+	CategoryAccessory: 1,
+}
+
+// 推奨計算に使用する保温レベル
+// WarmthLevel が未設定（0）の場合はカテゴリごとの標準値を返す
+func (c *ClothingItem) Warmth() int {
+	if c.WarmthLevel > 0 {
+		return c.WarmthLevel
+	}
+	return defaultWarmthByCategory[ClothingCategory(c.Category)]
+}
+
+// 種類・名前・素材から属性を判定するためのキーワード
+// 英字のキーワードは単語単位で照合するため、複合語（raincoat など）は個別に列挙する
+var (
+	waterResistantKeywords = []string{"レイン", "雨", "防水", "撥水", "長靴", "傘", "ゴアテックス", "rain", "raincoat", "rainwear", "rainboot", "waterproof", "umbrella", "gore-tex"}
+	windResistantKeywords  = []string{"ウインドブレーカー", "ウィンドブレーカー", "防風", "マウンテンパーカー", "シェル", "windbreaker", "windproof", "shell"}
+	sunProtectionKeywords  = []string{"帽子", "ハット", "キャップ", "サングラス", "日傘", "hat", "cap", "sunglasses"}
+	umbrellaKeywords       = []string{"傘", "umbrella"}
+	breathableKeywords     = []string{"リネン", "麻", "メッシュ", "ドライ", "接触冷感", "コットン", "綿", "linen", "mesh", "cotton", "dry"}
+	coldProtectionKeywords = []string{"手袋", "マフラー", "ニット帽", "ストール", "ネックウォーマー", "イヤーマフ", "glove", "scarf", "scarves", "beanie", "earmuff"}
+)
+
+// 雨・雪に対応できるアイテムかを判定（防水の指定またはキーワード）
+func (c *ClothingItem) IsWaterResistant() bool {
+	return c.Waterproof || c.matchesAny(waterResistantKeywords)
+}
+
+// 風を防げるアイテムかを判定（防風の指定またはキーワード）
+func (c *ClothingItem) IsWindResistant() bool {
+	return c.Windproof || c.matchesAny(windResistantKeywords)
 }
 
-type Clothing struct {
-	ID       int
-	Name     string
-	Category string
-	Color    string
-	Type     string
+// 日差し対策のアイテムかを判定
+func (c *ClothingItem) IsSunProtection() bool {
+	return c.matchesAny(sunProtectionKeywords)
+}
+
+// 傘かを判定
+func (c *ClothingItem) IsUmbrella() bool {
+	return c.matchesAny(umbrellaKeywords)
+}
+
+// 通気性の良い素材のアイテムかを判定
+// 通気性が設定されている場合はその値を優先し、未設定の場合は素材・名前のキーワードから推定する
+func (c *ClothingItem) IsBreathable() bool {
+	if c.Breathability > 0 {
+		return c.Breathability >= BreathableThreshold
+	}
+	return c.matchesAny(breathableKeywords)
+}
+
+// 防寒小物かを判定
+func (c *ClothingItem) IsColdProtection() bool {
+	return c.matchesAny(coldProtectionKeywords)
+}
+
+// 種類・名前・素材のいずれかにキーワードを含むかを判定
+func (c *ClothingItem) matchesAny(keywords []string) bool {
+	text := strings.ToLower(c.Type + " " + c.Name + " " + c.Material)
+	for _, keyword := range keywords {
+		if containsKeyword(text, keyword) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// 衣類を着用する季節
+type Season string
+
+const (
+	SeasonSpring Season = "春"
+	SeasonSummer Season = "夏"
+	SeasonAutumn Season = "秋"
+	SeasonWinter Season = "冬"
+)
+
+// 定義済みの季節かを確認
+func (s Season) IsValid() bool {
+	return s == SeasonSpring || s == SeasonSummer || s == SeasonAutumn || s == SeasonWinter
+}
+
+// 日付が属する季節（3〜5月を春、6〜8月を夏、9〜11月を秋、12〜2月を冬とする）
+func SeasonOf(t time.Time) Season {
+	switch t.Month() {
+	case time.March, time.April, time.May:
+		return SeasonSpring
+	case time.June, time.July, time.August:
+		return SeasonSummer
+	case time.September, time.October, time.November:
+		return SeasonAutumn
+	default:
+		return SeasonWinter
+	}
+}
+
+// 属性値の上限
+const (
+	// MaxBreathability 通気性の上限（1: 通気性が低い〜5: 非常に高い、0は未設定）
+	MaxBreathability = 5
+
+	// BreathableThreshold 通気性が良いとみなす通気性の下限
+	BreathableThreshold = 4
+
+	// MaxFormality フォーマル度の上限（1: カジュアル〜5: フォーマル、0は未設定）
+	MaxFormality = 5
+
+	maxMaterialLength = 50
+	maxSizeLength     = 20
+	maxNotesLength    = 1000
+)
+
+// 季節・素材・サイズなどの詳細な属性を検証
+func (c *ClothingItem) validateAttributes() error {
+	seen := make(map[Season]bool)
+	for _, season := range c.Seasons {
+		if !season.IsValid() {
+			return fmt.Errorf("season must be one of %s, %s, %s, %s", SeasonSpring, SeasonSummer, SeasonAutumn, SeasonWinter)
+		}
+		if seen[season] {
+			return fmt.Errorf("duplicate season: %s", season)
+		}
+		seen[season] = true
+	}
+	if c.Breathability < 0 || c.Breathability > MaxBreathability {
+		return fmt.Errorf("breathability must be between 0 and %d", MaxBreathability)
+	}
+	if c.Formality < 0 || c.Formality > MaxFormality {
+		return fmt.Errorf("formality must be between 0 and %d", MaxFormality)
+	}
+	if utf8.RuneCountInString(c.Material) > maxMaterialLength {
+		return fmt.Errorf("material must be at most %d characters", maxMaterialLength)
+	}
+	if utf8.RuneCountInString(c.Size) > maxSizeLength {
+		return fmt.Errorf("size must be at most %d characters", maxSizeLength)
+	}
+	if utf8.RuneCountInString(c.Notes) > maxNotesLength {
+		return errors.New("notes are too long")
+	}
+	return nil
+}
+
+// 指定した季節に着用するアイテムかを確認（季節が未設定の場合は通年とみなす）
+func (c *ClothingItem) InSeason(season Season) bool {
+	if len(c.Seasons) == 0 {
+		return true
+	}
+	for _, s := range c.Seasons {
+		if s == season {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeasonOf(t *testing.T) {
+	want := map[time.Month]Season{
+		time.January: SeasonWinter, time.February: SeasonWinter, time.March: SeasonSpring,
+		time.April: SeasonSpring, time.May: SeasonSpring, time.June: SeasonSummer,
+		time.July: SeasonSummer, time.August: SeasonSummer, time.September: SeasonAutumn,
+		time.October: SeasonAutumn, time.November: SeasonAutumn, time.December: SeasonWinter,
+	}
+	for month, season := range want {
+		if got := SeasonOf(time.Date(2024, month, 15, 0, 0, 0, 0, time.UTC)); got != season {
+			t.Errorf("SeasonOf(%s) = %s, want %s", month, got, season)
+		}
+	}
+}
+
+func TestInSeason(t *testing.T) {
+	allYear := &ClothingItem{}
+	winter := &ClothingItem{Seasons: []Season{SeasonAutumn, SeasonWinter}}
+
+	if !allYear.InSeason(SeasonSummer) {
+		t.Error("an item without seasons should be worn all year")
+	}
+	if !winter.InSeason(SeasonWinter) || winter.InSeason(SeasonSummer) {
+		t.Error("autumn/winter item: want in season in winter only")
+	}
+}
+
+func TestValidateAttributes(t *testing.T) {
+	valid := func() *ClothingItem {
+		return &ClothingItem{UserID: "user-1", Name: "Shirt", Type: "シャツ", Color: "white", Category: string(CategoryTops)}
+	}
+	tests := []struct {
+		name   string
+		modify func(*ClothingItem)
+		ok     bool
+	}{
+		{"valid", func(c *ClothingItem) {}, true},
+		{"seasons", func(c *ClothingItem) { c.Seasons = []Season{SeasonSpring, SeasonAutumn} }, true},
+		{"unknown season", func(c *ClothingItem) { c.Seasons = []Season{"rainy"} }, false},
+		{"duplicate season", func(c *ClothingItem) { c.Seasons = []Season{SeasonSummer, SeasonSummer} }, false},
+		{"breathability too high", func(c *ClothingItem) { c.Breathability = MaxBreathability + 1 }, false},
+		{"negative formality", func(c *ClothingItem) { c.Formality = -1 }, false},
+		{"formality", func(c *ClothingItem) { c.Formality = MaxFormality }, true},
+		{"long size", func(c *ClothingItem) { c.Size = "XXXXXXXXXXXXXXXXXXXXL" }, false},
+	}
+	for _, tt := range tests {
+		item := valid()
+		tt.modify(item)
+		if err := item.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
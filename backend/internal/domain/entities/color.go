@@ -0,0 +1,203 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// 正規化した標準パレットの色
+// 衣服の色（自由入力）やユーザーの好みの色をこの色に変換して配色を判定する
+type PaletteColor struct {
+	// Name パレット内の識別名（"navy" など）
+	Name string
+
+	// DisplayName 日本語の表示名
+	DisplayName string
+
+	// Hue 色相（0〜360度、無彩色は0）
+	Hue float64
+
+	// Saturation 彩度（0.0〜1.0）
+	Saturation float64
+
+	// Lightness 明度（0.0〜1.0）
+	Lightness float64
+
+	// Neutral 無彩色・定番色（どの色とも合わせやすい）か
+	Neutral bool
+}
+
+// 標準パレットの定義（RGB から色相・彩度・明度を算出する）
+var paletteDefinitions = []struct {
+	name, displayName string
+	r, g, b           uint8
+	neutral           bool
+	aliases           []string
+}{
+	{"black", "黒", 0x1a, 0x1a, 0x1a, true, []string{"黒", "ブラック", "くろ", "black", "jet"}},
+	{"white", "白", 0xf7, 0xf7, 0xf5, true, []string{"白", "ホワイト", "しろ", "white", "snow"}},
+	{"gray", "グレー", 0x80, 0x80, 0x80, true, []string{"灰", "グレー", "グレイ", "チャコール", "gray", "grey", "charcoal"}},
+	{"navy", "ネイビー", 0x1f, 0x2a, 0x4d, true, []string{"紺", "ネイビー", "インディゴ", "navy", "indigo"}},
+	{"beige", "ベージュ", 0xd8, 0xc8, 0xa8, true, []string{"ベージュ", "生成り", "アイボリー", "オフホワイト", "サンド", "beige", "ivory", "ecru", "off-white", "sand", "cream", "クリーム"}},
+	{"brown", "ブラウン", 0x6b, 0x45, 0x2a, true, []string{"茶", "ブラウン", "キャメル", "モカ", "チョコ", "brown", "camel", "mocha", "tan"}},
+	{"khaki", "カーキ", 0x8a, 0x86, 0x5d, true, []string{"カーキ", "オリーブ", "khaki", "olive"}},
+	{"denim", "デニムブルー", 0x4a, 0x6f, 0x9c, true, []string{"デニム", "denim"}},
+	{"red", "赤", 0xc6, 0x28, 0x28, false, []string{"赤", "レッド", "red", "scarlet"}},
+	{"burgundy", "ボルドー", 0x6d, 0x1a, 0x2e, false, []string{"ボルドー", "ワイン", "えんじ", "臙脂", "バーガンディ", "ワインレッド", "burgundy", "wine", "maroon"}},
+	{"pink", "ピンク", 0xf0, 0x8c, 0xb0, false, []string{"ピンク", "桃", "pink", "rose"}},
+	{"orange", "オレンジ", 0xf0, 0x7d, 0x1e, false, []string{"オレンジ", "橙", "orange"}},
+	{"yellow", "黄", 0xf2, 0xd0, 0x24, false, []string{"黄", "イエロー", "からし", "マスタード", "yellow", "mustard"}},
+	{"green", "緑", 0x2e, 0x8b, 0x57, false, []string{"緑", "グリーン", "green"}},
+	{"mint", "ミント", 0x9f, 0xe2, 0xbf, false, []string{"ミント", "ミントグリーン", "mint"}},
+	{"teal", "ティール", 0x00, 0x80, 0x80, false, []string{"ティール", "ターコイズ", "teal", "turquoise"}},
+	{"blue", "青", 0x1e, 0x5b, 0xc6, false, []string{"青", "ブルー", "blue", "cobalt"}},
+	{"lightblue", "水色", 0x9c, 0xc9, 0xeb, false, []string{"水色", "ライトブルー", "サックス", "スカイブルー", "light blue", "sky blue", "sax"}},
+	{"purple", "紫", 0x6a, 0x3d, 0x9a, false, []string{"紫", "パープル", "purple", "violet"}},
+	{"lavender", "ラベンダー", 0xc3, 0xb1, 0xe1, false, []string{"ラベンダー", "藤色", "lavender", "lilac"}},
+}
+
+// 標準パレットと表記の対応表（初期化時に構築）
+var (
+	palette      = make(map[string]PaletteColor)
+	paletteOrder []PaletteColor
+	colorAliases []colorAlias
+)
+
+type colorAlias struct {
+	text  string
+	color string
+}
+
+func init() {
+	for _, def := range paletteDefinitions {
+		color := newPaletteColor(def.name, def.displayName, def.r, def.g, def.b, def.neutral)
+		palette[def.name] = color
+		paletteOrder = append(paletteOrder, color)
+		for _, alias := range append([]string{def.name, def.displayName}, def.aliases...) {
+			colorAliases = append(colorAliases, colorAlias{text: strings.ToLower(alias), color: def.name})
+		}
+	}
+	// 長い表記から照合する（"ライトブルー" を "ブルー" より優先）
+	sort.SliceStable(colorAliases, func(i, j int) bool {
+		return len(colorAliases[i].text) > len(colorAliases[j].text)
+	})
+}
+
+// 自由入力の色名を標準パレットの色に変換
+// 日本語の色名（部分一致）・英語の色名（単語単位、"tan" は "titanium" に一致しない）を長い表記を優先して照合し、
+// "#RRGGBB" 形式も受け付ける。該当しない場合は false を返す
+func ParseColor(text string) (PaletteColor, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(text))
+	if normalized == "" {
+		return PaletteColor{}, false
+	}
+
+	if strings.HasPrefix(normalized, "#") && len(normalized) == 7 {
+		var r, g, b uint8
+		if _, err := fmt.Sscanf(normalized, "#%02x%02x%02x", &r, &g, &b); err == nil {
+			return NearestPaletteColor(r, g, b), true
+		}
+	}
+
+	for _, alias := range colorAliases {
+		if containsKeyword(normalized, alias.text) {
+			return palette[alias.color], true
+		}
+	}
+	return PaletteColor{}, false
+}
+
+// 色の検索・保存に使用する標準パレットの識別名（該当しない場合は空文字列）
+func ColorKey(text string) string {
+	color, ok := ParseColor(text)
+	if !ok {
+		return ""
+	}
+	return color.Name
+}
+
+// 無彩色とみなす彩度の上限
+const achromaticSaturation = 0.12
+
+// RGB 値に最も近い標準パレットの色を返す
+func NearestPaletteColor(r, g, b uint8) PaletteColor {
+	target := newPaletteColor("", "", r, g, b, false)
+
+	// 彩度がほとんどない色は明度だけで無彩色に振り分ける
+	if target.Saturation < achromaticSaturation {
+		switch {
+		case target.Lightness < 0.25:
+			return palette["black"]
+		case target.Lightness > 0.85:
+			return palette["white"]
+		default:
+			return palette["gray"]
+		}
+	}
+
+	best, bestDistance := paletteOrder[0], math.Inf(1)
+	for _, color := range paletteOrder {
+		if d := colorDistance(target, color); d < bestDistance {
+			best, bestDistance = color, d
+		}
+	}
+	return best
+}
+
+// 標準パレットの全ての色を定義順に返す
+func Palette() []PaletteColor {
+	return append([]PaletteColor(nil), paletteOrder...)
+}
+
+// 色相の差（0〜180度）
+func HueDistance(a, b PaletteColor) float64 {
+	d := math.Abs(a.Hue - b.Hue)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+// HSL 空間での色の距離（色相を重視し、彩度が低い色同士では色相の差を小さく扱う）
+func colorDistance(a, b PaletteColor) float64 {
+	chroma := math.Min(a.Saturation, b.Saturation)
+	hue := HueDistance(a, b) / 90 * chroma
+	saturation := (a.Saturation - b.Saturation) / 2
+	lightness := a.Lightness - b.Lightness
+	return math.Sqrt(hue*hue + saturation*saturation + lightness*lightness)
+}
+
+// RGB 値からパレットの色を作成（HSL に変換）
+func newPaletteColor(name, displayName string, r, g, b uint8, neutral bool) PaletteColor {
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+	max := math.Max(rf, math.Max(gf, bf))
+	min := math.Min(rf, math.Min(gf, bf))
+	lightness := (max + min) / 2
+
+	var hue, saturation float64
+	if delta := max - min; delta > 0 {
+		saturation = delta / (1 - math.Abs(2*lightness-1))
+		switch max {
+		case rf:
+			hue = 60 * math.Mod((gf-bf)/delta, 6)
+		case gf:
+			hue = 60 * ((bf-rf)/delta + 2)
+		default:
+			hue = 60 * ((rf-gf)/delta + 4)
+		}
+		if hue < 0 {
+			hue += 360
+		}
+	}
+
+	return PaletteColor{
+		Name:        name,
+		DisplayName: displayName,
+		Hue:         math.Round(hue),
+		Saturation:  math.Round(saturation*100) / 100,
+		Lightness:   math.Round(lightness*100) / 100,
+		Neutral:     neutral,
+	}
+}
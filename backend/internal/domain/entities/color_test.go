@@ -0,0 +1,64 @@
+package entities
+
+import "testing"
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		text string
+		want string // 空の場合は該当なし
+	}{
+		{"ネイビー", "navy"},
+		{"濃紺", "navy"},
+		{"Navy Blue", "navy"},
+		{"ライトブルー", "lightblue"},
+		{"ブルー", "blue"},
+		{"light blue", "lightblue"},
+		{"Tan", "brown"},
+		{"tan chinos", "brown"},
+		{"titanium", ""},
+		{"tangerine", ""},
+		{"Rosewood", ""},
+		{"rose", "pink"},
+		{"Off-White", "beige"},
+		{"white", "white"},
+		{"wine red", "burgundy"},
+		{"ワインレッド", "burgundy"},
+		{"Greys", "gray"},
+		{"#1F2A4D", "navy"},
+		{"#ffffff", "white"},
+		{"#zzzzzz", ""},
+		{"  ", ""},
+		{"不明", ""},
+	}
+	for _, tt := range tests {
+		color, ok := ParseColor(tt.text)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("ParseColor(%q) = %s, want no match", tt.text, color.Name)
+			}
+			continue
+		}
+		if !ok || color.Name != tt.want {
+			t.Errorf("ParseColor(%q) = %s (%v), want %s", tt.text, color.Name, ok, tt.want)
+		}
+	}
+}
+
+func TestNearestPaletteColor(t *testing.T) {
+	tests := []struct {
+		r, g, b uint8
+		want    string
+	}{
+		{0x00, 0x00, 0x00, "black"},
+		{0xff, 0xff, 0xff, "white"},
+		{0x80, 0x80, 0x80, "gray"},
+		{0xc0, 0x20, 0x20, "red"},
+		{0x20, 0x8b, 0x50, "green"},
+		{0x20, 0x2a, 0x50, "navy"},
+	}
+	for _, tt := range tests {
+		if got := NearestPaletteColor(tt.r, tt.g, tt.b); got.Name != tt.want {
+			t.Errorf("NearestPaletteColor(%#02x, %#02x, %#02x) = %s, want %s", tt.r, tt.g, tt.b, got.Name, tt.want)
+		}
+	}
+}
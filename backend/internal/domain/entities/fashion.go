@@ -13,7 +13,8 @@ type WeatherCondition struct {
 	
 	Description string
 	
-	Condition   string
+	// Condition プロバイダーに依存しない天気状況
+	Condition   WeatherConditionCode
 	
 	Humidity    int
 	
@@ -21,9 +22,58 @@ type WeatherCondition struct {
 	
 	CloudCover  int
 	
+	// PrecipitationProbability 降水確率（0.0〜1.0、予報データのみ）
+	PrecipitationProbability float64
+	
+	// Pressure 気圧（hPa）
+	Pressure    int
+	
+	// WindDirection 風向（度、北を0とした時計回り）
+	WindDirection int
+	
+	// Visibility 視程（メートル）
+	Visibility  int
+	
 	Location    string
 	
 	DateTime    time.Time
+	
+	// Stale 天気サービスに接続できず、キャッシュに残っていた古い情報を代わりに使用している場合に true
+	Stale       bool
+}
+
+// 指定地点の天気予報を表現するエンティティ
+type WeatherForecast struct {
+	Latitude  float64
+	
+	Longitude float64
+	
+	Location  string
+	
+	// Hourly 時間ごとの予報（プロバイダーにより1時間または3時間間隔）
+	Hourly    []WeatherCondition
+	
+	// Daily 日ごとに集計した予報
+	Daily     []DailyWeather
+	
+	// UpdatedAt 予報データの取得日時
+	UpdatedAt time.Time
+	
+	// Stale 天気サービスに接続できず、キャッシュに残っていた古い予報を代わりに使用している場合に true
+	Stale     bool
+}
+
+// 1日分に集計した天気予報
+// 埋め込まれた WeatherCondition には日中の代表値が入る
+type DailyWeather struct {
+	WeatherCondition
+	
+	// Date 現地日付（YYYY-MM-DD）
+	Date           string
+	
+	MinTemperature float64
+	
+	MaxTemperature float64
 }
 
 // ファッション推奨結果を表現するエンティティ
@@ -34,26 +84,171 @@ type FashionRecommendation struct {
 	
 	Style     string
 	
+	// Strategy 推奨の生成に使用した戦略名
+	Strategy  string
+	
+	// Items 最上位のコーディネートに含まれるアイテム（Outfits[0].Items と同じ）
 	Items     []RecommendedItem
 	
+	// Outfits スコア順に並べたコーディネートの候補
+	Outfits   []Outfit
+	
 	Weather   WeatherCondition
 	
 	Reason    string
 	
 	Location  string
 	
+	// DayPlan 外出時間帯を指定した推奨の場合の時間帯の気象条件（時点指定の場合は nil）
+	DayPlan   *DayPlan
+	
+	// Advisories 暑さ指数・ウィンドチルから求めた健康上の注意喚起（深刻度の高い順）
+	Advisories []WeatherAdvisory
+	
+	// Feedback ユーザーから寄せられたフィードバック（未回答の場合は nil）
+	Feedback  *RecommendationFeedback
+	
 	CreatedAt time.Time
+	
+	// Version 楽観的排他制御用のバージョン（更新のたびに1増加）
+	Version   int
+}
+
+// 外出する時間帯の気象条件の幅（時間ごとの予報から集計）
+// 推奨はこの時間帯で最も厳しい条件に合わせ、暖かくなる時間帯には脱げるアイテムを示す
+type DayPlan struct {
+	DepartureAt time.Time
+	
+	ReturnAt    time.Time
+	
+	MinFeelsLike float64
+	
+	MaxFeelsLike float64
+	
+	// MaxPrecipitationProbability 時間帯内の最大降水確率（0.0〜1.0）
+	MaxPrecipitationProbability float64
+	
+	MaxWindSpeed float64
+	
+	// Hourly 時間帯に含まれる予報（複数地点の場合は Location に地点名が入る）
+	Hourly      []WeatherCondition
+	
+	// Waypoints 立ち寄る地点ごとの気象条件（地点を指定した場合のみ）
+	Waypoints   []WaypointWeather
+}
+
+// 立ち寄る地点と滞在時間帯の気象条件
+type WaypointWeather struct {
+	Name        string
+	
+	Latitude    float64
+	
+	Longitude   float64
+	
+	ArriveAt    time.Time
+	
+	LeaveAt     time.Time
+	
+	MinFeelsLike float64
+	
+	MaxFeelsLike float64
+	
+	MaxPrecipitationProbability float64
+	
+	MaxWindSpeed float64
+	
+	// Condition 滞在中で最も雨風への備えが必要な天気
+	Condition   WeatherConditionCode
 }
 
 // 推奨される衣服アイテムの詳細
 type RecommendedItem struct {
-	Category string
+	// ClothingID 推奨元のクローゼットアイテムID
+	ClothingID  string
+	
+	// Slot コーディネート内での役割
+	Slot        OutfitSlot
+	
+	Category    string
+	
+	Name        string
+	
+	Color       string
 	
-	Name     string
+	// WarmthLevel 推奨計算に使用した保温レベル
+	WarmthLevel int
 	
-	Color    string
+	// Removable 気温が上がる時間帯に脱いで調整できるアイテム
+	Removable   bool
 	
-	Reason   string
+	Reason      string
+}
+
+// コーディネート内でのアイテムの役割
+type OutfitSlot string
+
+const (
+	SlotBaseLayer OutfitSlot = "base_layer" // 肌着・Tシャツなど一番内側のトップス
+	
+	SlotMidLayer  OutfitSlot = "mid_layer"  // ニット・スウェットなど重ね着するトップス
+	
+	SlotBottom    OutfitSlot = "bottom"
+	
+	SlotShoes     OutfitSlot = "shoes"
+	
+	SlotOuterwear OutfitSlot = "outerwear"
+	
+	SlotAccessory OutfitSlot = "accessory"
+)
+
+// 1通りの完全なコーディネート
+// ボトムスとシューズを1点ずつ、トップスを1点以上（ベース・ミドルレイヤー）含み、アウターと小物は任意
+type Outfit struct {
+	// Rank 候補内での順位（1が最上位）
+	Rank        int
+	
+	// Score 推奨戦略による総合スコア（高いほど良い）
+	Score       float64
+	
+	// Breakdown 総合スコアの内訳
+	Breakdown   ScoreBreakdown
+	
+	// TotalWarmth 含まれるアイテムの保温レベルの合計
+	TotalWarmth int
+	
+	// TargetWarmth 気象条件から求めた目標保温レベル
+	TargetWarmth int
+	
+	Items       []RecommendedItem
+	
+	Reason      string
+}
+
+// コーディネートのスコア内訳
+// 各項目は推奨戦略ごとの尺度で算出され、Total は戦略の重みで合算した値
+type ScoreBreakdown struct {
+	ThermalFit        float64 // 気温への適合度
+	
+	WeatherProtection float64 // 雨・風への備え
+	
+	ColorHarmony      float64 // 色の調和
+	
+	UserPreference    float64 // ユーザーの好み（色・ブランド）との一致
+	
+	Recency           float64 // 最近着用していないアイテムほど高い
+	
+	Total             float64
+}
+
+// 指定した役割のアイテムを返す
+func (o *Outfit) ItemsInSlot(slot OutfitSlot) []RecommendedItem {
+	var items []RecommendedItem
+	for _, item := range o.Items {
+		if item.Slot == slot {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // ユーザーの outfit 投稿を表現するエンティティ
@@ -78,9 +273,15 @@ type OutfitPost struct {
 	
 	ImageURL    string
 
+	// ThumbnailURL 画像のサムネイルURL（アップロードした画像の場合にサーバーで生成）
+	ThumbnailURL string
+
 	CreatedAt   time.Time
 	
 	Likes       int
+	
+	// Version 楽観的排他制御用のバージョン（更新のたびに1増加）
+	Version     int
 }
 
 // outfit投稿データの検証
@@ -94,34 +295,6 @@ func (o *OutfitPost) Validate() error {
 	return nil
 }
 
-// WeatherData 現在の気象情報を表現する簡易エンティティ
-type WeatherData struct {
-	Temperature float64
-	
-	Condition   string
-	
-	Humidity    int
-	
-	WindSpeed   float64
-	
-	Location    string
-	
-	DateTime    time.Time
-}
-
-// OutfitSuggestion 完全なoutfit提案を表現するエンティティ
-type OutfitSuggestion struct {
-	WeatherCondition string
-	
-	Temperature      float64
-	
-	ClothingItems    []Clothing
-	
-	Description      string
-	
-	CreatedAt        time.Time
-}
-
 // ファッションスタイルの種類を定義
 type Style string
 
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// 推奨されたコーディネートを着た際の体感
+type ThermalComfort string
+
+const (
+	ComfortTooCold   ThermalComfort = "too_cold"   // 寒かった
+	ComfortJustRight ThermalComfort = "just_right" // ちょうどよかった
+	ComfortTooHot    ThermalComfort = "too_hot"    // 暑かった
+)
+
+// 定義済みの体感かを確認
+func (c ThermalComfort) IsValid() bool {
+	return c == ComfortTooCold || c == ComfortJustRight || c == ComfortTooHot
+}
+
+// アイテムごとの評価（サムズアップ・ダウン）
+type ItemRating string
+
+const (
+	RatingUp   ItemRating = "up"
+	RatingDown ItemRating = "down"
+)
+
+// 定義済みの評価かを確認
+func (r ItemRating) IsValid() bool {
+	return r == RatingUp || r == RatingDown
+}
+
+// 推奨アイテム1点に対する評価
+type ItemFeedback struct {
+	ClothingID string
+
+	Rating ItemRating
+}
+
+// 推奨結果に対するユーザーのフィードバック
+type RecommendationFeedback struct {
+	// Comfort コーディネート全体の体感
+	Comfort ThermalComfort
+
+	// ItemRatings アイテムごとの評価（任意）
+	ItemRatings []ItemFeedback
+
+	Comment string
+
+	SubmittedAt time.Time
+}
+
+// 体感補正の1回あたりの調整幅（摂氏）
+const ComfortOffsetStep = 1.0
+
+// 体感補正の上限（摂氏、正負とも）
+const MaxComfortOffset = 5.0
+
+// フィードバックの内容を検証
+// recommendation に含まれないアイテムへの評価はエラーとする
+func (f *RecommendationFeedback) Validate(recommendation *FashionRecommendation) error {
+	if !f.Comfort.IsValid() {
+		return fmt.Errorf("体感は %s・%s・%s のいずれかを指定してください", ComfortTooCold, ComfortJustRight, ComfortTooHot)
+	}
+
+	recommended := make(map[string]bool)
+	for _, outfit := range recommendation.Outfits {
+		for _, item := range outfit.Items {
+			recommended[item.ClothingID] = true
+		}
+	}
+	for _, item := range recommendation.Items {
+		recommended[item.ClothingID] = true
+	}
+
+	rated := make(map[string]bool)
+	for _, rating := range f.ItemRatings {
+		if !rating.Rating.IsValid() {
+			return fmt.Errorf("アイテムの評価は %s または %s を指定してください", RatingUp, RatingDown)
+		}
+		if !recommended[rating.ClothingID] {
+			return fmt.Errorf("アイテム %s はこの推奨に含まれていません", rating.ClothingID)
+		}
+		if rated[rating.ClothingID] {
+			return fmt.Errorf("アイテム %s の評価が重複しています", rating.ClothingID)
+		}
+		rated[rating.ClothingID] = true
+	}
+	return nil
+}
+
+// 体感補正の変化量（摂氏）
+// 寒かった場合は正（次回から暖かい服装）、暑かった場合は負
+func (f *RecommendationFeedback) OffsetDelta() float64 {
+	switch f.Comfort {
+	case ComfortTooCold:
+		return ComfortOffsetStep
+	case ComfortTooHot:
+		return -ComfortOffsetStep
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// エンティティIDの生成器
+// 全てのエンティティとリポジトリで共通の生成器を使用し、ID の衝突を防止する
+type IDGenerator interface {
+	// NewID 衝突しない新しいIDを生成します
+	NewID() string
+}
+
+// 関数を IDGenerator として扱うためのアダプター
+type IDGeneratorFunc func() string
+
+// NewID 関数を呼び出してIDを生成します
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// UUIDv7 によるID生成器
+// 時刻順にソート可能なため、インデックスの局所性が高い
+type UUIDv7Generator struct{}
+
+// NewID UUIDv7 形式のIDを生成します
+func (UUIDv7Generator) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+var (
+	idGeneratorMutex sync.RWMutex
+	idGenerator      IDGenerator = UUIDv7Generator{}
+)
+
+// アプリケーション全体で使用するID生成器を差し替え
+// テストで固定IDを使用する場合などに利用する
+func SetIDGenerator(generator IDGenerator) {
+	idGeneratorMutex.Lock()
+	defer idGeneratorMutex.Unlock()
+
+	idGenerator = generator
+}
+
+// 現在のID生成器で新しいIDを生成
+func NewID() string {
+	idGeneratorMutex.RLock()
+	defer idGeneratorMutex.RUnlock()
+
+	return idGenerator.NewID()
+}
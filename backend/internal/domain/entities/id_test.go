@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewIDIsUniqueAcrossGoroutines(t *testing.T) {
+	const workers, perWorker = 8, 500
+	ids := make(chan string, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ids <- NewID()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestNewIDIsSortableUUIDv7(t *testing.T) {
+	previous := ""
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		parsed, err := uuid.Parse(id)
+		if err != nil || parsed.Version() != 7 {
+			t.Fatalf("NewID() = %q, want a UUIDv7", id)
+		}
+		// 同じミリ秒内でも生成順に並ぶ
+		if id <= previous {
+			t.Fatalf("NewID() = %s after %s, want increasing IDs", id, previous)
+		}
+		previous = id
+	}
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	defer SetIDGenerator(UUIDv7Generator{})
+
+	SetIDGenerator(IDGeneratorFunc(func() string { return "fixed-id" }))
+	if id := NewID(); id != "fixed-id" {
+		t.Errorf("NewID() = %q, want the replaced generator's ID", id)
+	}
+	item, err := NewClothingItem("user-1", "shirt", "シャツ", "navy", string(CategoryTops))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ID != "fixed-id" {
+		t.Errorf("NewClothingItem ID = %q, want the replaced generator's ID", item.ID)
+	}
+}
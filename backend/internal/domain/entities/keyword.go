@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 小文字化したテキストにキーワードが含まれるかを判定
+// 英字のキーワードは単語単位で照合し（"rain" は "trainer" に一致しない、複数形の s・es は許容）、
+// 日本語などのキーワードは単語の区切りがないため部分一致で照合する
+func containsKeyword(text, keyword string) bool {
+	if keyword == "" {
+		return false
+	}
+	if !isLatinKeyword(keyword) {
+		return strings.Contains(text, keyword)
+	}
+
+	for start := 0; start < len(text); {
+		i := strings.Index(text[start:], keyword)
+		if i < 0 {
+			return false
+		}
+		i += start
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		if i == 0 || !isWordRune(before) {
+			rest := text[i+len(keyword):]
+			if endsWord(rest) || endsWord(strings.TrimPrefix(rest, "s")) || endsWord(strings.TrimPrefix(rest, "es")) {
+				return true
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		start = i + size
+	}
+	return false
+}
+
+// キーワードが英字（ASCII）のみで構成されるかを判定
+func isLatinKeyword(keyword string) bool {
+	for i := 0; i < len(keyword); i++ {
+		if keyword[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// 単語の一部となる文字か（英数字とアクセント付きのラテン文字、日本語などは区切りとして扱う）
+func isWordRune(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) || unicode.Is(unicode.Latin, r)
+}
+
+// 残りのテキストが単語の区切りから始まるかを判定
+func endsWord(rest string) bool {
+	r, _ := utf8.DecodeRuneInString(rest)
+	return rest == "" || !isWordRune(r)
+}
@@ -0,0 +1,76 @@
+package entities
+
+import "testing"
+
+func TestContainsKeyword(t *testing.T) {
+	tests := []struct {
+		text    string
+		keyword string
+		want    bool
+	}{
+		{"rain jacket", "rain", true},
+		{"trainer", "rain", false},
+		{"training shoes", "rain", false},
+		{"brain-print tee", "rain", false},
+		{"capri pants", "cap", false},
+		{"cape", "cap", false},
+		{"baseball cap", "cap", true},
+		{"caps", "cap", true},
+		{"that's my chat tee", "hat", false},
+		{"bucket hat", "hat", true},
+		{"laundry bag", "dry", false},
+		{"quick-dry tee", "dry", true},
+		{"dry,fit", "dry", true},
+		{"wool gloves", "glove", true},
+		{"sunglasses", "sunglasses", true},
+		{"gore-tex shell", "gore-tex", true},
+		{"eggshell knit", "shell", false},
+		{"レインコート", "レイン", true},
+		{"トレーナー", "レイン", false},
+		{"キャップ（黒）", "キャップ", true},
+		{"雨用capジャケット", "cap", true},
+		{"capé", "cap", false},
+		{"", "rain", false},
+		{"rain", "", false},
+	}
+	for _, tt := range tests {
+		if got := containsKeyword(tt.text, tt.keyword); got != tt.want {
+			t.Errorf("containsKeyword(%q, %q) = %v, want %v", tt.text, tt.keyword, got, tt.want)
+		}
+	}
+}
+
+func TestClothingItemAttributeKeywords(t *testing.T) {
+	tests := []struct {
+		name string
+		item ClothingItem
+		fn   func(*ClothingItem) bool
+		want bool
+	}{
+		{"raincoat is water resistant", ClothingItem{Name: "Raincoat"}, (*ClothingItem).IsWaterResistant, true},
+		{"trainer is not water resistant", ClothingItem{Type: "Trainer", Name: "Grey Trainer"}, (*ClothingItem).IsWaterResistant, false},
+		{"レインブーツ is water resistant", ClothingItem{Name: "レインブーツ"}, (*ClothingItem).IsWaterResistant, true},
+		{"waterproof flag", ClothingItem{Name: "Boots", Waterproof: true}, (*ClothingItem).IsWaterResistant, true},
+		{"capri pants are not sun protection", ClothingItem{Name: "Capri Pants"}, (*ClothingItem).IsSunProtection, false},
+		{"cape is not sun protection", ClothingItem{Name: "Wool Cape"}, (*ClothingItem).IsSunProtection, false},
+		{"chat tee is not sun protection", ClothingItem{Name: "Chat T-shirt"}, (*ClothingItem).IsSunProtection, false},
+		{"bucket hat is sun protection", ClothingItem{Name: "Bucket Hat"}, (*ClothingItem).IsSunProtection, true},
+		{"laundry bag is not breathable", ClothingItem{Name: "Laundry Bag"}, (*ClothingItem).IsBreathable, false},
+		{"dry tee is breathable", ClothingItem{Name: "Dry Tee"}, (*ClothingItem).IsBreathable, true},
+		{"cotton material is breathable", ClothingItem{Name: "Shirt", Material: "Cotton"}, (*ClothingItem).IsBreathable, true},
+		{"breathability overrides keywords", ClothingItem{Name: "Linen Shirt", Breathability: 1}, (*ClothingItem).IsBreathable, false},
+		{"eggshell sweater is not wind resistant", ClothingItem{Name: "Eggshell Sweater"}, (*ClothingItem).IsWindResistant, false},
+		{"shell jacket is wind resistant", ClothingItem{Name: "Shell Jacket"}, (*ClothingItem).IsWindResistant, true},
+		{"scarves are cold protection", ClothingItem{Name: "Scarves"}, (*ClothingItem).IsColdProtection, true},
+		{"マフラー is cold protection", ClothingItem{Name: "ウールのマフラー"}, (*ClothingItem).IsColdProtection, true},
+		{"umbrella", ClothingItem{Name: "Folding Umbrella"}, (*ClothingItem).IsUmbrella, true},
+		{"日傘 is an umbrella", ClothingItem{Name: "日傘"}, (*ClothingItem).IsUmbrella, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(&tt.item); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// 晴天時の日射量の目安（kW/m²、日中を想定した安全側の値）
+const clearSkySolarRadiation = 0.8
+
+// 推定暑さ指数（WBGT、摂氏）
+// 環境省の簡易推定式（小野・登内）に気温・湿度・風速と、雲量から推定した日射量を当てはめる
+// 日射量は日中を前提に見積もるため、夜間は実際より高めの値になる
+func (w *WeatherCondition) EstimatedWBGT() float64 {
+	cloud := math.Min(math.Max(float64(w.CloudCover)/100, 0), 1)
+	solar := clearSkySolarRadiation * (1 - 0.75*math.Pow(cloud, 3.4))
+	ta, rh := w.Temperature, float64(w.Humidity)
+
+	wbgt := 0.735*ta + 0.0374*rh + 0.00292*ta*rh + 7.619*solar - 4.557*solar*solar - 0.0572*w.WindSpeed - 4.064
+	return math.Round(wbgt*10) / 10
+}
+
+// ウィンドチル指数を適用する条件（気温の上限・風速の下限）
+const (
+	windChillMaxTemperature = 10  // ℃
+	windChillMinWindSpeed   = 4.8 // km/h
+)
+
+// 風速を考慮した体感温度（ウィンドチル指数、摂氏）
+// 気温10℃以下かつ風速1.3m/s（4.8km/h）以上で適用し、それ以外は気温をそのまま返す
+func (w *WeatherCondition) WindChill() float64 {
+	if !w.WindChillApplies() {
+		return w.Temperature
+	}
+
+	speed := w.WindSpeed * 3.6 // km/h
+
+	v := math.Pow(speed, 0.16)
+	chill := 13.12 + 0.6215*w.Temperature - 11.37*v + 0.3965*w.Temperature*v
+	return math.Round(chill*10) / 10
+}
+
+// 風によって体感温度が気温より下がる条件か（ウィンドチル指数の適用範囲）
+func (w *WeatherCondition) WindChillApplies() bool {
+	return w.Temperature <= windChillMaxTemperature && w.WindSpeed*3.6 >= windChillMinWindSpeed
+}
+
+// 注意喚起の種類
+type AdvisoryKind string
+
+const (
+	AdvisoryHeatstroke AdvisoryKind = "heatstroke"  // 熱中症（暑さ指数による）
+	AdvisoryColdStress AdvisoryKind = "cold_stress" // 寒冷ストレス（ウィンドチルによる）
+)
+
+// 注意喚起の深刻度
+type AdvisorySeverity string
+
+const (
+	SeverityCaution AdvisorySeverity = "caution" // 注意
+	SeverityWarning AdvisorySeverity = "warning" // 警戒
+	SeveritySevere  AdvisorySeverity = "severe"  // 厳重警戒
+	SeverityDanger  AdvisorySeverity = "danger"  // 危険
+)
+
+// 深刻度の順位（注意喚起なしは0、大きいほど深刻）
+var advisorySeverityRanks = map[AdvisorySeverity]int{
+	SeverityCaution: 1,
+	SeverityWarning: 2,
+	SeveritySevere:  3,
+	SeverityDanger:  4,
+}
+
+// 深刻度の順位を返す（未定義・空の場合は0）
+func (s AdvisorySeverity) Rank() int {
+	return advisorySeverityRanks[s]
+}
+
+// 指定した深刻度以上かを確認
+func (s AdvisorySeverity) AtLeast(other AdvisorySeverity) bool {
+	return s.Rank() > 0 && s.Rank() >= other.Rank()
+}
+
+// 気象条件から求めた健康上の注意喚起
+type WeatherAdvisory struct {
+	Kind AdvisoryKind
+
+	Severity AdvisorySeverity
+
+	// Index 判定に使用した指標の値（熱中症は WBGT、寒冷ストレスはウィンドチル）
+	Index float64
+
+	Message string
+
+	// Actions 推奨する対策（水分補給、通気性の良い素材、帽子など）
+	Actions []string
+
+	// DateTime 指標が最も厳しくなる日時
+	DateTime time.Time
+}
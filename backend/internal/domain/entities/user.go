@@ -3,6 +3,7 @@ package entities
 import (
 	"time"
 	"errors"
+	"math"
 )
 
 type User struct {
@@ -13,8 +14,10 @@ type User struct {
 	Gender      string
 	Age         int
 	Preferences *UserPreferences
+	ComfortOffset float64 // フィードバックから学習した体感補正（摂氏、正の値ほど寒がり）
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Version     int // 楽観的排他制御用のバージョン（更新のたびに1増加）
 }
 
 // ユーザーのファッション関連の設定を表現
@@ -24,6 +27,7 @@ type UserPreferences struct {
 	PreferredColors []string  // 特に好む色の組み合わせ
 	PreferredBrands []string  // 好みのブランド
 	Style           string    // メインのスタイル指向
+	RecommendationStrategy string // 使用する推奨戦略（空の場合はサーバーの既定値）
 }
 
 // バリデーション付きで新しいユーザーを作成
@@ -39,6 +43,7 @@ func NewUser(name, email, password string) (*User, error) {
 	}
 
 	return &User{
+		ID:        NewID(),
 		Name:      name,
 		Email:     email,
 		Password:  password,
@@ -54,6 +59,11 @@ func (u *User) UpdatePreferences(styles, colors []string) {
 	}
 }
 
+// フィードバックによる変化量を体感補正に反映（上限 MaxComfortOffset で制限）
+func (u *User) AdjustComfortOffset(delta float64) {
+	u.ComfortOffset = math.Max(-MaxComfortOffset, math.Min(MaxComfortOffset, u.ComfortOffset+delta))
+}
+
 // 年齢が有効な範囲内であるかを確認
 func (u *User) IsValidAge() bool {
 	return u.Age >= 0 && u.Age <= 150
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// 着用記録の登録元
+type WearSource string
+
+const (
+	WearSourceRecommendation WearSource = "recommendation" // 推奨されたコーディネートを採用
+	WearSourceOutfitPost     WearSource = "outfit_post"    // outfit投稿で着用を記録
+)
+
+// 定義済みの登録元かを確認
+func (s WearSource) IsValid() bool {
+	return s == WearSourceRecommendation || s == WearSourceOutfitPost
+}
+
+// 衣服アイテムを着用した記録
+// 同じアイテムの着用は1日につき1件として記録する
+type WearLogEntry struct {
+	ID string
+
+	UserID string
+
+	ClothingID string
+
+	// WornOn 着用日（時刻を含まない UTC の0時）
+	WornOn time.Time
+
+	Source WearSource
+
+	// SourceID 登録元の推奨ID・outfit投稿ID
+	SourceID string
+
+	CreatedAt time.Time
+}
+
+// 日時から着用日を求める（t のタイムゾーンでの日付を UTC の0時で表す）
+func WearDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// 着用記録の検証
+func (e *WearLogEntry) Validate() error {
+	if e.UserID == "" {
+		return errors.New("ユーザーIDは必須です")
+	}
+	if e.ClothingID == "" {
+		return errors.New("衣服アイテムIDは必須です")
+	}
+	if e.WornOn.IsZero() {
+		return errors.New("着用日は必須です")
+	}
+	if !e.Source.IsValid() {
+		return errors.New("着用記録の登録元が不正です")
+	}
+	return nil
+}
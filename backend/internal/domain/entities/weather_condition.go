@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"strings"
+)
+
+// プロバイダーに依存しない天気状況の種類
+// 各天気プロバイダーは独自の天気コードをこの値に変換して WeatherCondition.Condition に設定する
+type WeatherConditionCode string
+
+const (
+	ConditionClear        WeatherConditionCode = "clear"        // 晴れ
+	ConditionClouds       WeatherConditionCode = "clouds"       // 曇り
+	ConditionDrizzle      WeatherConditionCode = "drizzle"      // 霧雨
+	ConditionRain         WeatherConditionCode = "rain"         // 雨
+	ConditionThunderstorm WeatherConditionCode = "thunderstorm" // 雷雨
+	ConditionSnow         WeatherConditionCode = "snow"         // 雪
+	ConditionSleet        WeatherConditionCode = "sleet"        // みぞれ
+	ConditionMist         WeatherConditionCode = "mist"         // もや
+	ConditionFog          WeatherConditionCode = "fog"          // 霧
+	ConditionHaze         WeatherConditionCode = "haze"         // 煙霧・砂塵など視界不良
+	ConditionSquall       WeatherConditionCode = "squall"       // スコール・突風
+	ConditionTornado      WeatherConditionCode = "tornado"      // 竜巻
+	ConditionUnknown      WeatherConditionCode = "unknown"      // 不明
+)
+
+// 表示名の言語
+const (
+	LanguageJapanese = "ja"
+	LanguageEnglish  = "en"
+)
+
+// 天気状況ごとの表示名（言語 → 表示名）
+var weatherConditionNames = map[WeatherConditionCode]map[string]string{
+	ConditionClear:        {LanguageJapanese: "晴れ", LanguageEnglish: "Clear"},
+	ConditionClouds:       {LanguageJapanese: "曇り", LanguageEnglish: "Cloudy"},
+	ConditionDrizzle:      {LanguageJapanese: "霧雨", LanguageEnglish: "Drizzle"},
+	ConditionRain:         {LanguageJapanese: "雨", LanguageEnglish: "Rain"},
+	ConditionThunderstorm: {LanguageJapanese: "雷雨", LanguageEnglish: "Thunderstorm"},
+	ConditionSnow:         {LanguageJapanese: "雪", LanguageEnglish: "Snow"},
+	ConditionSleet:        {LanguageJapanese: "みぞれ", LanguageEnglish: "Sleet"},
+	ConditionMist:         {LanguageJapanese: "もや", LanguageEnglish: "Mist"},
+	ConditionFog:          {LanguageJapanese: "霧", LanguageEnglish: "Fog"},
+	ConditionHaze:         {LanguageJapanese: "視界不良", LanguageEnglish: "Haze"},
+	ConditionSquall:       {LanguageJapanese: "突風", LanguageEnglish: "Squall"},
+	ConditionTornado:      {LanguageJapanese: "竜巻", LanguageEnglish: "Tornado"},
+	ConditionUnknown:      {LanguageJapanese: "不明", LanguageEnglish: "Unknown"},
+}
+
+// 旧データや外部入力で使われていた表記からの変換表
+var weatherConditionAliases = map[string]WeatherConditionCode{
+	"sunny":  ConditionClear,
+	"cloudy": ConditionClouds,
+	"rainy":  ConditionRain,
+	"snowy":  ConditionSnow,
+	"foggy":  ConditionFog,
+	"smoke":  ConditionHaze,
+	"dust":   ConditionHaze,
+	"sand":   ConditionHaze,
+	"ash":    ConditionHaze,
+	"晴れ":     ConditionClear,
+	"快晴":     ConditionClear,
+	"曇り":     ConditionClouds,
+	"雨":      ConditionRain,
+	"雪":      ConditionSnow,
+	"雷雨":     ConditionThunderstorm,
+	"霧":      ConditionFog,
+}
+
+// 文字列を天気状況に変換
+// 定義済みの値に加え、旧来の英語・日本語表記（"Rain"、"雨" など）も受け付け、該当しない場合は ConditionUnknown を返す
+func ParseWeatherConditionCode(value string) WeatherConditionCode {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "" {
+		return ConditionUnknown
+	}
+
+	code := WeatherConditionCode(normalized)
+	if code.IsValid() {
+		return code
+	}
+	if alias, exists := weatherConditionAliases[normalized]; exists {
+		return alias
+	}
+	return ConditionUnknown
+}
+
+// 定義済みの天気状況かを確認
+func (c WeatherConditionCode) IsValid() bool {
+	_, exists := weatherConditionNames[c]
+	return exists
+}
+
+// 指定言語での表示名を返す（未対応の言語は日本語）
+func (c WeatherConditionCode) DisplayName(language string) string {
+	names, exists := weatherConditionNames[c]
+	if !exists {
+		names = weatherConditionNames[ConditionUnknown]
+	}
+	if name, exists := names[language]; exists {
+		return name
+	}
+	return names[LanguageJapanese]
+}
+
+// 雨具が必要な降水（霧雨・雨・雷雨）かを確認
+func (c WeatherConditionCode) IsRainy() bool {
+	return c == ConditionDrizzle || c == ConditionRain || c == ConditionThunderstorm
+}
+
+// 雪が降る状況（雪・みぞれ）かを確認
+func (c WeatherConditionCode) IsSnowy() bool {
+	return c == ConditionSnow || c == ConditionSleet
+}
+
+// 日差しが強い状況かを確認
+func (c WeatherConditionCode) IsSunny() bool {
+	return c == ConditionClear
+}
+
+// JSON などのテキスト形式から変換（保存済みの旧表記も正規化する）
+func (c *WeatherConditionCode) UnmarshalText(text []byte) error {
+	*c = ParseWeatherConditionCode(string(text))
+	return nil
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseWeatherConditionCode(t *testing.T) {
+	tests := map[string]WeatherConditionCode{
+		"rain":    ConditionRain,
+		" Rain ":  ConditionRain,
+		"sunny":   ConditionClear,
+		"雨":       ConditionRain,
+		"快晴":      ConditionClear,
+		"dust":    ConditionHaze,
+		"":        ConditionUnknown,
+		"acid":    ConditionUnknown,
+		"TORNADO": ConditionTornado,
+	}
+	for value, want := range tests {
+		if got := ParseWeatherConditionCode(value); got != want {
+			t.Errorf("ParseWeatherConditionCode(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestWeatherConditionCodeDisplayName(t *testing.T) {
+	tests := []struct {
+		code     WeatherConditionCode
+		language string
+		want     string
+	}{
+		{ConditionRain, LanguageJapanese, "雨"},
+		{ConditionRain, LanguageEnglish, "Rain"},
+		{ConditionSnow, "fr", "雪"},
+		{WeatherConditionCode("acid"), LanguageEnglish, "Unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.code.DisplayName(tt.language); got != tt.want {
+			t.Errorf("%q.DisplayName(%q) = %q, want %q", tt.code, tt.language, got, tt.want)
+		}
+	}
+}
+
+func TestWeatherConditionCodeCategories(t *testing.T) {
+	for _, code := range []WeatherConditionCode{ConditionDrizzle, ConditionRain, ConditionThunderstorm} {
+		if !code.IsRainy() || code.IsSnowy() {
+			t.Errorf("%q: rainy %v snowy %v, want rainy only", code, code.IsRainy(), code.IsSnowy())
+		}
+	}
+	for _, code := range []WeatherConditionCode{ConditionSnow, ConditionSleet} {
+		if !code.IsSnowy() || code.IsRainy() {
+			t.Errorf("%q: rainy %v snowy %v, want snowy only", code, code.IsRainy(), code.IsSnowy())
+		}
+	}
+	if !ConditionClear.IsSunny() || ConditionClouds.IsSunny() {
+		t.Error("only clear skies should be sunny")
+	}
+}
+
+func TestWeatherConditionUnmarshalsLegacyValues(t *testing.T) {
+	// 保存済みの旧表記も読み込み時に正規化する
+	var condition WeatherCondition
+	if err := json.Unmarshal([]byte(`{"Condition":"Rainy","Temperature":12}`), &condition); err != nil {
+		t.Fatal(err)
+	}
+	if condition.Condition != ConditionRain {
+		t.Errorf("condition = %q, want %q", condition.Condition, ConditionRain)
+	}
+}
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 衣服アイテムの並び順
+type ClothingSort string
+
+const (
+	// ClothingSortCreated 登録順（ID が時刻順の UUIDv7 のため ID 順で並べる）
+	ClothingSortCreated ClothingSort = "created"
+
+	// ClothingSortName 名前順
+	ClothingSortName ClothingSort = "name"
+
+	// ClothingSortWarmth 保温レベル順
+	ClothingSortWarmth ClothingSort = "warmth"
+
+	// ClothingSortLastWorn 最後に着用した日の順（着用記録のないアイテムは最も古いものとして扱う）
+	ClothingSortLastWorn ClothingSort = "last_worn"
+)
+
+// 定義済みの並び順かを確認
+func (s ClothingSort) IsValid() bool {
+	switch s {
+	case ClothingSortCreated, ClothingSortName, ClothingSortWarmth, ClothingSortLastWorn:
+		return true
+	}
+	return false
+}
+
+// 衣服アイテムの並び替えキー（ページの境界となるアイテムの位置を表す）
+// 並び順が同じアイテム同士は ID の順に並べる
+type ClothingCursor struct {
+	ID          string
+	Name        string
+	WarmthLevel int
+
+	// LastWornOn 最後に着用した日（着用記録がない場合はゼロ値）
+	LastWornOn time.Time
+}
+
+// 並び順 s での a と b の比較結果（昇順で a が先なら負、後なら正）
+func (s ClothingSort) Compare(a, b ClothingCursor) int {
+	var c int
+	switch s {
+	case ClothingSortName:
+		c = strings.Compare(a.Name, b.Name)
+	case ClothingSortWarmth:
+		c = a.WarmthLevel - b.WarmthLevel
+	case ClothingSortLastWorn:
+		c = a.LastWornOn.Compare(b.LastWornOn)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// 衣服アイテムの検索条件
+// 空の条件は絞り込みを行わず、複数の値を指定した条件はいずれかに一致するアイテムを対象とする
+type ClothingQuery struct {
+	UserID string
+
+	Categories []string
+
+	// Colors 標準パレットの色の識別名（アイテムの色を ParseColor で変換して照合）
+	Colors []string
+
+	// Brands ブランド名（大文字・小文字を区別しない）
+	Brands []string
+
+	// MinWarmth, MaxWarmth 保温レベルの範囲（0 の場合は制限なし）
+	MinWarmth int
+	MaxWarmth int
+
+	// Season 指定した季節に着用するアイテム（季節が未設定のアイテムは通年として含める）
+	Season entities.Season
+
+	Availabilities []entities.Availability
+
+	// Text 名前・ブランドの部分一致（大文字・小文字を区別しない）
+	Text string
+
+	// LaundryReturnCutoff この日時以前に洗濯に出したアイテムを着用可能として扱う（ゼロ値の場合は扱わない）
+	LaundryReturnCutoff time.Time
+
+	Sort       ClothingSort
+	Descending bool
+
+	// Limit 1ページの件数
+	Limit int
+
+	// After 前のページの最後のアイテム（nil の場合は先頭から）
+	After *ClothingCursor
+}
+
+// 衣服アイテムの検索結果の1ページ
+type ClothingPage struct {
+	Items []*entities.ClothingItem
+
+	// Next 次のページの取得に使う位置（最後のページの場合は nil）
+	Next *ClothingCursor
+}
+
+// 洗濯の自動返却を考慮した利用状況
+func (q *ClothingQuery) EffectiveAvailability(item *entities.ClothingItem) entities.Availability {
+	availability := item.CurrentAvailability()
+	if availability == entities.AvailabilityLaundry && !q.LaundryReturnCutoff.IsZero() &&
+		!item.AvailabilityChangedAt.After(q.LaundryReturnCutoff) {
+		return entities.AvailabilityAvailable
+	}
+	return availability
+}
+
+// アイテムが検索条件（並び順・ページ以外）に一致するかを確認
+// SQL に条件を渡せないリポジトリの実装で使用する
+func (q *ClothingQuery) Matches(item *entities.ClothingItem) bool {
+	if item.UserID != q.UserID {
+		return false
+	}
+	if len(q.Categories) > 0 && !containsString(q.Categories, item.Category) {
+		return false
+	}
+	if len(q.Colors) > 0 {
+		color, ok := entities.ParseColor(item.Color)
+		if !ok || !containsString(q.Colors, color.Name) {
+			return false
+		}
+	}
+	if len(q.Brands) > 0 {
+		matched := false
+		for _, brand := range q.Brands {
+			if strings.EqualFold(brand, item.Brand) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if q.MinWarmth > 0 && item.WarmthLevel < q.MinWarmth {
+		return false
+	}
+	if q.MaxWarmth > 0 && item.WarmthLevel > q.MaxWarmth {
+		return false
+	}
+	if q.Season != "" && !item.InSeason(q.Season) {
+		return false
+	}
+	if len(q.Availabilities) > 0 {
+		availability := q.EffectiveAvailability(item)
+		matched := false
+		for _, a := range q.Availabilities {
+			if a == availability {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if q.Text != "" {
+		text := strings.ToLower(q.Text)
+		if !strings.Contains(strings.ToLower(item.Name), text) && !strings.Contains(strings.ToLower(item.Brand), text) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
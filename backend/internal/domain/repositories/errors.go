@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateKey 既に存在するIDや一意制約のある値で作成しようとした場合のエラー
+// Create は既存データを上書きせず、このエラーをラップして返します
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// ErrWeatherUnavailable 天気データの取得元が一時的に利用できない場合のエラー
+// タイムアウト・リトライ上限到達・サーキットブレーカー遮断時に返されます
+var ErrWeatherUnavailable = errors.New("weather service unavailable")
+
+// ErrBlobNotFound 指定したキーのファイルが BlobStore に存在しない場合のエラー
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrConflict 楽観的排他制御で更新が競合した場合のエラー
+// errors.Is(err, ErrConflict) で ConflictError を判定できます
+var ErrConflict = errors.New("version conflict")
+
+// ConflictError Update に渡されたエンティティのバージョンが保存済みのバージョンと一致しない場合のエラー
+// 他のリクエストによって先に更新されたことを示すため、呼び出し側は再取得してからやり直す必要があります
+type ConflictError struct {
+	// Entity 競合したエンティティの種類
+	Entity string
+
+	// ID 競合したエンティティのID
+	ID string
+
+	// ExpectedVersion 呼び出し側が保持していたバージョン
+	ExpectedVersion int
+
+	// CurrentVersion 保存済みの最新バージョン
+	CurrentVersion int
+}
+
+// Error エラーメッセージを返します
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s は他の更新と競合しました (expected version %d, current version %d)",
+		e.Entity, e.ID, e.ExpectedVersion, e.CurrentVersion)
+}
+
+// Is ErrConflict との比較を可能にします
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
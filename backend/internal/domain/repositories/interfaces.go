@@ -1,6 +1,10 @@
 package repositories
 
 import (
+	"context"
+	"io"
+	"time"
+
 	"forecast-app/internal/domain/entities"
 )
 
@@ -21,6 +25,7 @@ type UserRepository interface {
 	
 	// Update 既存のユーザー情報を更新します
 	// 存在しないユーザーの場合はエラーを返します
+	// user.Version が保存済みのバージョンと異なる場合は ConflictError を返し、成功時は Version を1増加させます
 	Update(user *entities.User) error
 	
 	// Delete ユーザーIDでユーザーを削除します
@@ -41,8 +46,13 @@ type ClothingRepository interface {
 	// GetByID 衣服アイテムIDで特定のアイテムを取得します
 	GetByID(id string) (*entities.ClothingItem, error)
 	
+	// Query 検索条件に一致するユーザーの衣服アイテムを、指定した並び順で1ページ分取得します
+	// SQL の実装では絞り込み・並び替え・ページ分割をクエリで行います
+	Query(query ClothingQuery) (*ClothingPage, error)
+	
 	// Update 既存の衣服アイテム情報を更新します
 	// 着用回数やお気に入り状態の更新などに使用されます
+	// item.Version が保存済みのバージョンと異なる場合は ConflictError を返し、成功時は Version を1増加させます
 	Update(item *entities.ClothingItem) error
 	
 	// Delete 衣服アイテムをクローゼットから削除します
@@ -54,7 +64,13 @@ type WeatherRepository interface {
 	// GetByLocation 緯度経度から現在の天気情報を取得します
 	// 気温、湿度、風速、降水確率、天気状況などの詳細情報を返します
 	// ファッション推奨エンジンで使用される主要なデータソースです
-	GetByLocation(latitude, longitude float64) (*entities.WeatherCondition, error)
+	// 取得元が利用できない場合は ErrWeatherUnavailable をラップしたエラーを返します
+	GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error)
+	
+	// GetForecast 緯度経度から今後数日間の天気予報を取得します
+	// 時間ごとの予報（最低でも5日間・3時間間隔）と日ごとの集計を返します
+	// 翌日のコーディネート提案などに使用されます
+	GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error)
 }
 
 // FashionRecommendationRepository ファッション推奨データアクセスのためのリポジトリインターフェース
@@ -74,6 +90,7 @@ type FashionRecommendationRepository interface {
 	
 	// Update 既存のファッション推奨を更新します
 	// ユーザーフィードバックによる評価更新などに使用されます
+	// recommendation.Version が保存済みのバージョンと異なる場合は ConflictError を返し、成功時は Version を1増加させます
 	Update(recommendation *entities.FashionRecommendation) error
 	
 	// Delete ファッション推奨を削除します
@@ -101,8 +118,69 @@ type OutfitPostRepository interface {
 	
 	// Update 既存のoutfit投稿を更新します
 	// いいね数やコメントの更新などに使用されます
+	// post.Version が保存済みのバージョンと異なる場合は ConflictError を返し、成功時は Version を1増加させます
 	Update(post *entities.OutfitPost) error
 	
 	// Delete outfit投稿を削除します
 	Delete(id string) error
 }
+
+// WearLogRepository 着用記録データアクセスのためのリポジトリインターフェース
+// どの衣服アイテムをいつ着用したかを記録し、着用履歴の表示や最近着たアイテムの判定に使用されます。
+type WearLogRepository interface {
+	// Create 新しい着用記録を追加します
+	// 同じアイテム・同じ着用日の記録が既にある場合は追加せず ErrDuplicateKey を返します
+	// （トランザクションを中断しないため、重複は一意制約違反ではなく追加件数で判定します）
+	Create(entry *entities.WearLogEntry) error
+	
+	// GetByClothingID 指定した衣服アイテムの着用記録を着用日の新しい順に取得します
+	GetByClothingID(clothingID string) ([]*entities.WearLogEntry, error)
+	
+	// GetLastWornByUserID 指定したユーザーのアイテムIDごとの最終着用日を取得します
+	// 推奨エンジンで最近着たアイテムを減点するために使用されます
+	GetLastWornByUserID(userID string) (map[string]time.Time, error)
+}
+
+// BlobStore 画像などのファイルを保存するストレージのインターフェース
+// ローカルファイルシステムや S3 互換のオブジェクトストレージで実装されます。
+// キーは "/" 区切りの相対パスで、".." や先頭の "/" を含むキーは受け付けません。
+type BlobStore interface {
+	// Put key にファイルを保存します（同じキーが存在する場合は上書きします）
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	
+	// Get key のファイルを取得します
+	// 存在しない場合は ErrBlobNotFound を返します。呼び出し側は Blob.Body を閉じる必要があります
+	Get(ctx context.Context, key string) (*Blob, error)
+	
+	// Delete key のファイルを削除します（存在しない場合もエラーにしません）
+	Delete(ctx context.Context, key string) error
+}
+
+// Blob BlobStore から取得したファイル
+type Blob struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// UnitOfWork 複数リポジトリにまたがる操作をアトミックに実行するためのインターフェース
+// ユーザー登録やユーザー削除時の関連データ削除など、複数ステップのユースケースで使用されます。
+type UnitOfWork interface {
+	// Do fn をトランザクション内で実行します
+	// fn がエラーを返した場合は fn 内で行われた全ての変更がロールバックされます
+	Do(fn func(tx Transaction) error) error
+}
+
+// Transaction トランザクション内で利用可能なリポジトリ群
+// ここで取得したリポジトリへの操作は全て同一トランザクションで実行されます。
+type Transaction interface {
+	Users() UserRepository
+
+	Clothing() ClothingRepository
+
+	FashionRecommendations() FashionRecommendationRepository
+
+	OutfitPosts() OutfitPostRepository
+
+	WearLogs() WearLogRepository
+}
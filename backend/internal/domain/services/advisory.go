@@ -0,0 +1,106 @@
+package services
+
+import (
+	"sort"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 指標の値に応じた注意喚起の段階
+type advisoryLevel struct {
+	threshold float64
+	severity  entities.AdvisorySeverity
+	message   string
+	actions   []string
+}
+
+// 暑さ指数（WBGT）による熱中症の注意喚起の段階（日本生気象学会の指針に基づく、深刻な順）
+var heatstrokeLevels = []advisoryLevel{
+	{31, entities.SeverityDanger, "熱中症の危険性が極めて高い状態です。外出はなるべく避け、涼しい室内で過ごしてください。",
+		[]string{"こまめな水分・塩分補給", "通気性の良い素材", "帽子・日傘", "不要な外出を控える"}},
+	{28, entities.SeveritySevere, "熱中症の厳重警戒レベルです。炎天下を避け、こまめに休憩をとってください。",
+		[]string{"こまめな水分・塩分補給", "通気性の良い素材", "帽子・日傘"}},
+	{25, entities.SeverityWarning, "熱中症に警戒してください。積極的に休憩と水分補給をとりましょう。",
+		[]string{"こまめな水分補給", "通気性の良い素材", "帽子"}},
+	{21, entities.SeverityCaution, "熱中症に注意してください。運動や長時間の外出では水分補給を忘れずに。",
+		[]string{"水分補給", "通気性の良い素材"}},
+}
+
+// ウィンドチルによる寒冷ストレスの注意喚起の段階（カナダ環境省の基準に基づく、深刻な順）
+var coldStressLevels = []advisoryLevel{
+	{-40, entities.SeverityDanger, "数分で凍傷になるおそれがあります。外出は最小限にし、肌を一切露出しないでください。",
+		[]string{"防風性のあるアウター", "手袋・帽子・マフラーで肌を覆う", "不要な外出を控える"}},
+	{-28, entities.SeveritySevere, "数十分で凍傷になるおそれがあります。肌を露出しないようにしてください。",
+		[]string{"防風性のあるアウター", "手袋・帽子・マフラーで肌を覆う"}},
+	{-10, entities.SeverityWarning, "厳しい冷え込みです。防風性のあるアウターと防寒小物で肌の露出を減らしましょう。",
+		[]string{"防風性のあるアウター", "手袋・マフラー"}},
+	{0, entities.SeverityCaution, "風で体感温度が下がります。防風性のあるアウターがおすすめです。",
+		[]string{"防風性のあるアウター"}},
+}
+
+// 風が弱く（ウィンドチルが気温と同じ）、気温が氷点下の場合の注意喚起（風には触れない）
+var calmColdCaution = advisoryLevel{0, entities.SeverityCaution, "氷点下の冷え込みです。保温性のあるアウターで暖かくしましょう。",
+	[]string{"保温性のあるアウター", "手袋"}}
+
+// 気象条件から熱中症・寒冷ストレスの注意喚起を判定（深刻度の高い順）
+// 複数時点を渡した場合は、それぞれ指標が最も厳しい時点で判定する
+func AssessAdvisories(samples []entities.WeatherCondition) []entities.WeatherAdvisory {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	hottest, coldest := samples[0], samples[0]
+	for _, sample := range samples[1:] {
+		if sample.EstimatedWBGT() > hottest.EstimatedWBGT() {
+			hottest = sample
+		}
+		if sample.WindChill() < coldest.WindChill() {
+			coldest = sample
+		}
+	}
+
+	var advisories []entities.WeatherAdvisory
+	wbgt := hottest.EstimatedWBGT()
+	for _, level := range heatstrokeLevels {
+		if wbgt >= level.threshold {
+			advisories = append(advisories, newAdvisory(entities.AdvisoryHeatstroke, level, wbgt, hottest))
+			break
+		}
+	}
+	chill := coldest.WindChill()
+	for _, level := range coldStressLevels {
+		if chill <= level.threshold {
+			if level.severity == entities.SeverityCaution && !coldest.WindChillApplies() {
+				level = calmColdCaution
+			}
+			advisories = append(advisories, newAdvisory(entities.AdvisoryColdStress, level, chill, coldest))
+			break
+		}
+	}
+
+	sort.SliceStable(advisories, func(i, j int) bool {
+		return advisories[i].Severity.Rank() > advisories[j].Severity.Rank()
+	})
+	return advisories
+}
+
+func newAdvisory(kind entities.AdvisoryKind, level advisoryLevel, index float64, sample entities.WeatherCondition) entities.WeatherAdvisory {
+	return entities.WeatherAdvisory{
+		Kind:     kind,
+		Severity: level.severity,
+		Index:    index,
+		Message:  level.message,
+		Actions:  append([]string(nil), level.actions...),
+		DateTime: sample.DateTime,
+	}
+}
+
+// 注意喚起のうち指定した種類の深刻度（該当なしの場合は空）
+func advisorySeverity(advisories []entities.WeatherAdvisory, kind entities.AdvisoryKind) entities.AdvisorySeverity {
+	for _, advisory := range advisories {
+		if advisory.Kind == kind {
+			return advisory.Severity
+		}
+	}
+	return ""
+}
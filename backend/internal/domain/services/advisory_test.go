@@ -0,0 +1,82 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func coldStressAdvisory(advisories []entities.WeatherAdvisory) *entities.WeatherAdvisory {
+	for i := range advisories {
+		if advisories[i].Kind == entities.AdvisoryColdStress {
+			return &advisories[i]
+		}
+	}
+	return nil
+}
+
+func TestAssessAdvisoriesColdCautionWind(t *testing.T) {
+	// 風速 3m/s（10.8km/h）ではウィンドチルが適用され、風に関するメッセージになる
+	windy := coldStressAdvisory(AssessAdvisories([]entities.WeatherCondition{{Temperature: 1, WindSpeed: 3}}))
+	if windy == nil || windy.Severity != entities.SeverityCaution {
+		t.Fatalf("windy advisory = %+v, want a caution", windy)
+	}
+	if !strings.Contains(windy.Message, "風") || windy.Actions[0] != "防風性のあるアウター" {
+		t.Errorf("windy advisory = %q %v, want the wind message", windy.Message, windy.Actions)
+	}
+
+	// 風速 1m/s（3.6km/h）ではウィンドチルは気温と同じで、風には触れない
+	calm := coldStressAdvisory(AssessAdvisories([]entities.WeatherCondition{{Temperature: -1, WindSpeed: 1}}))
+	if calm == nil || calm.Severity != entities.SeverityCaution || calm.Index != -1 {
+		t.Fatalf("calm advisory = %+v, want a caution with index -1", calm)
+	}
+	if strings.Contains(calm.Message, "風") {
+		t.Errorf("calm advisory message = %q, want no mention of wind", calm.Message)
+	}
+}
+
+func TestAssessAdvisoriesLevels(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition entities.WeatherCondition
+		kind      entities.AdvisoryKind
+		severity  entities.AdvisorySeverity
+	}{
+		{"mild", entities.WeatherCondition{Temperature: 15, Humidity: 50, WindSpeed: 2}, "", ""},
+		{"extreme cold", entities.WeatherCondition{Temperature: -30, WindSpeed: 10}, entities.AdvisoryColdStress, entities.SeverityDanger},
+		{"cold wind", entities.WeatherCondition{Temperature: -8, WindSpeed: 5}, entities.AdvisoryColdStress, entities.SeverityWarning},
+		{"humid heat", entities.WeatherCondition{Temperature: 35, Humidity: 80}, entities.AdvisoryHeatstroke, entities.SeverityDanger},
+	}
+	for _, test := range tests {
+		advisories := AssessAdvisories([]entities.WeatherCondition{test.condition})
+		if test.kind == "" {
+			if len(advisories) != 0 {
+				t.Errorf("%s: advisories = %+v, want none", test.name, advisories)
+			}
+			continue
+		}
+		if got := advisorySeverity(advisories, test.kind); got != test.severity {
+			t.Errorf("%s: %s severity = %q, want %q", test.name, test.kind, got, test.severity)
+		}
+	}
+}
+
+func TestWindChillApplies(t *testing.T) {
+	tests := []struct {
+		condition entities.WeatherCondition
+		want      bool
+	}{
+		{entities.WeatherCondition{Temperature: 5, WindSpeed: 1.4}, true},
+		{entities.WeatherCondition{Temperature: 5, WindSpeed: 1.3}, false},
+		{entities.WeatherCondition{Temperature: 12, WindSpeed: 8}, false},
+	}
+	for _, test := range tests {
+		if got := test.condition.WindChillApplies(); got != test.want {
+			t.Errorf("WindChillApplies(%+v) = %v, want %v", test.condition, got, test.want)
+		}
+		if !test.want && test.condition.WindChill() != test.condition.Temperature {
+			t.Errorf("WindChill(%+v) = %v, want the air temperature", test.condition, test.condition.WindChill())
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/rand"
+	"sort"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 色抽出の設定
+const (
+	// DefaultColorClusters k-means で分ける色の数
+	DefaultColorClusters = 5
+
+	// 抽出に使う画素数の上限（これを超える場合は間引いて標本にする）
+	maxColorSamples = 4096
+
+	// k-means の反復回数の上限
+	maxKMeansIterations = 20
+
+	// 画像の外周とみなす幅（幅・高さに対する割合）
+	colorBorderRatio = 0.1
+
+	// 外周の画素のうちこの割合以上を占める色は背景とみなす
+	backgroundBorderShare = 0.6
+
+	// 外周以外の画素に占める割合がこれ未満の色に限り背景とみなす（画面いっぱいに写した衣服を背景として除外しない）
+	backgroundInteriorShare = 0.5
+
+	// 割合がこれ未満の色は結果に含めない
+	minColorShare = 0.05
+)
+
+// 画像から抽出した主要な色
+type DominantColor struct {
+	// Color 最も近い標準パレットの色
+	Color entities.PaletteColor
+
+	// Hex 抽出した色の平均値（"#RRGGBB"）
+	Hex string
+
+	// Share 背景を除いた画素に占める割合（0.0〜1.0）
+	Share float64
+}
+
+// 色の標本（画素の RGB 値と外周に位置するか）
+type colorSample struct {
+	rgb    [3]float64
+	border bool
+}
+
+// 画像の主要な色を k-means で抽出し、割合の大きい順に返す
+// 同じパレットの色に対応するクラスタはまとめ、画像の外周の大半を占め、内側では少数の色は背景（撮影時の床や壁）として除外する
+func ExtractDominantColors(img image.Image, k int) []DominantColor {
+	samples := sampleColors(toRGBA(img))
+	if len(samples) == 0 || k <= 0 {
+		return nil
+	}
+	if k > len(samples) {
+		k = len(samples)
+	}
+
+	centers, assignments := kMeans(samples, k)
+
+	// クラスタを最も近いパレットの色ごとにまとめる（代表色は画素数の最も多いクラスタの色）
+	type colorGroup struct {
+		color       entities.PaletteColor
+		hex         string
+		count       int
+		largest     int
+		borderCount int
+	}
+	clusterCounts := make([]int, len(centers))
+	for _, cluster := range assignments {
+		clusterCounts[cluster]++
+	}
+	groupOf := make([]*colorGroup, len(centers))
+	groups := make(map[string]*colorGroup)
+	for cluster, center := range centers {
+		r, g, b := uint8(math.Round(center[0])), uint8(math.Round(center[1])), uint8(math.Round(center[2]))
+		color := entities.NearestPaletteColor(r, g, b)
+		group, ok := groups[color.Name]
+		if !ok {
+			group = &colorGroup{color: color}
+			groups[color.Name] = group
+		}
+		if clusterCounts[cluster] > group.largest {
+			group.largest = clusterCounts[cluster]
+			group.hex = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+		}
+		groupOf[cluster] = group
+	}
+	totalBorder := 0
+	for i, cluster := range assignments {
+		groupOf[cluster].count++
+		if samples[i].border {
+			groupOf[cluster].borderCount++
+			totalBorder++
+		}
+	}
+
+	// 外周の画素の大半を占め、内側の画素では少数の色を背景とみなして除外
+	// 内側でも大半を占める色は画面いっぱいに写した衣服であり、他に色がない場合も除外しない
+	totalInterior := len(samples) - totalBorder
+	var background *colorGroup
+	for _, group := range groups {
+		if totalBorder == 0 || totalInterior == 0 || group.count == len(samples) {
+			continue
+		}
+		borderShare := float64(group.borderCount) / float64(totalBorder)
+		interiorShare := float64(group.count-group.borderCount) / float64(totalInterior)
+		if borderShare >= backgroundBorderShare && interiorShare < backgroundInteriorShare {
+			background = group
+		}
+	}
+	total := len(samples)
+	if background != nil {
+		total -= background.count
+	}
+
+	var colors []DominantColor
+	for _, group := range groups {
+		if group == background || group.count == 0 {
+			continue
+		}
+		share := float64(group.count) / float64(total)
+		if share < minColorShare {
+			continue
+		}
+		colors = append(colors, DominantColor{
+			Color: group.color,
+			Hex:   group.hex,
+			Share: math.Round(share*100) / 100,
+		})
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i].Share != colors[j].Share {
+			return colors[i].Share > colors[j].Share
+		}
+		return colors[i].Color.Name < colors[j].Color.Name
+	})
+	return colors
+}
+
+// 画素を間引いて標本を作成（半透明・透明の画素は除外）
+func sampleColors(img *image.RGBA) []colorSample {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	step := int(math.Ceil(math.Sqrt(float64(width*height) / maxColorSamples)))
+	if step < 1 {
+		step = 1
+	}
+	borderX := int(float64(width) * colorBorderRatio)
+	borderY := int(float64(height) * colorBorderRatio)
+
+	var samples []colorSample
+	for y := step / 2; y < height; y += step {
+		for x := step / 2; x < width; x += step {
+			p := img.Pix[y*img.Stride+x*4 : y*img.Stride+x*4+4]
+			if p[3] < 128 {
+				continue
+			}
+			// RGBA は乗算済みアルファのため元の色に戻す
+			alpha := float64(p[3]) / 255
+			samples = append(samples, colorSample{
+				rgb:    [3]float64{float64(p[0]) / alpha, float64(p[1]) / alpha, float64(p[2]) / alpha},
+				border: x < borderX || x >= width-borderX || y < borderY || y >= height-borderY,
+			})
+		}
+	}
+	return samples
+}
+
+// k-means++ で初期値を選んで k-means を実行し、各クラスタの中心と標本の割り当てを返す
+// 同じ画像から常に同じ結果が得られるよう、乱数の種は固定する
+func kMeans(samples []colorSample, k int) ([][3]float64, []int) {
+	random := rand.New(rand.NewSource(1))
+
+	centers := make([][3]float64, 0, k)
+	centers = append(centers, samples[random.Intn(len(samples))].rgb)
+	distances := make([]float64, len(samples))
+	for len(centers) < k {
+		sum := 0.0
+		for i, sample := range samples {
+			distances[i] = math.Inf(1)
+			for _, center := range centers {
+				distances[i] = math.Min(distances[i], squaredDistance(sample.rgb, center))
+			}
+			sum += distances[i]
+		}
+		if sum == 0 {
+			break // 残りの標本が全て既存の中心と同じ色
+		}
+		target := random.Float64() * sum
+		chosen := len(samples) - 1
+		for i, d := range distances {
+			if target -= d; target <= 0 {
+				chosen = i
+				break
+			}
+		}
+		centers = append(centers, samples[chosen].rgb)
+	}
+
+	assignments := make([]int, len(samples))
+	for iteration := 0; iteration < maxKMeansIterations; iteration++ {
+		changed := false
+		for i, sample := range samples {
+			nearest, nearestDistance := 0, math.Inf(1)
+			for c, center := range centers {
+				if d := squaredDistance(sample.rgb, center); d < nearestDistance {
+					nearest, nearestDistance = c, d
+				}
+			}
+			if iteration == 0 || assignments[i] != nearest {
+				assignments[i] = nearest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][3]float64, len(centers))
+		counts := make([]int, len(centers))
+		for i, sample := range samples {
+			cluster := assignments[i]
+			for channel := range sample.rgb {
+				sums[cluster][channel] += sample.rgb[channel]
+			}
+			counts[cluster]++
+		}
+		for c := range centers {
+			if counts[c] == 0 {
+				continue // 空のクラスタは中心を動かさない
+			}
+			for channel := range centers[c] {
+				centers[c][channel] = sums[c][channel] / float64(counts[c])
+			}
+		}
+	}
+	return centers, assignments
+}
+
+func squaredDistance(a, b [3]float64) float64 {
+	dr, dg, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+var (
+	testNavy  = color.RGBA{R: 0x1f, G: 0x2a, B: 0x4d, A: 0xff}
+	testWhite = color.RGBA{R: 0xf7, G: 0xf7, B: 0xf5, A: 0xff}
+	testRed   = color.RGBA{R: 0xc6, G: 0x28, B: 0x28, A: 0xff}
+)
+
+// 塗りつぶした画像に矩形を重ねる
+func filledImage(w, h int, background color.Color, rects ...coloredRect) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, background)
+		}
+	}
+	for _, r := range rects {
+		for y := r.rect.Min.Y; y < r.rect.Max.Y; y++ {
+			for x := r.rect.Min.X; x < r.rect.Max.X; x++ {
+				img.Set(x, y, r.color)
+			}
+		}
+	}
+	return img
+}
+
+type coloredRect struct {
+	rect  image.Rectangle
+	color color.Color
+}
+
+func colorNames(colors []DominantColor) []string {
+	names := make([]string, len(colors))
+	for i, c := range colors {
+		names[i] = c.Color.Name
+	}
+	return names
+}
+
+func TestExtractDominantColorsFullFrameGarment(t *testing.T) {
+	// 画面いっぱいのネイビーの衣服に白いロゴ（外周は全てネイビー）
+	img := filledImage(100, 100, testNavy, coloredRect{image.Rect(40, 30, 60, 70), testWhite})
+
+	colors := ExtractDominantColors(img, DefaultColorClusters)
+	if len(colors) != 2 {
+		t.Fatalf("colors = %v, want navy and white", colorNames(colors))
+	}
+	if colors[0].Color.Name != "navy" || colors[0].Share != 0.92 {
+		t.Errorf("first color = %s share %v, want navy share 0.92", colors[0].Color.Name, colors[0].Share)
+	}
+	if colors[1].Color.Name != "white" || colors[1].Share != 0.08 {
+		t.Errorf("second color = %s share %v, want white share 0.08", colors[1].Color.Name, colors[1].Share)
+	}
+}
+
+func TestExtractDominantColorsPlainBackdrop(t *testing.T) {
+	// 白い背景の中央にネイビーの衣服と赤いワンポイント
+	img := filledImage(100, 100, testWhite,
+		coloredRect{image.Rect(20, 20, 80, 80), testNavy},
+		coloredRect{image.Rect(40, 40, 60, 60), testRed},
+	)
+
+	colors := ExtractDominantColors(img, DefaultColorClusters)
+	names := colorNames(colors)
+	if len(colors) != 2 || names[0] != "navy" || names[1] != "red" {
+		t.Fatalf("colors = %v, want [navy red] with the white backdrop removed", names)
+	}
+	if colors[0].Share != 0.89 {
+		t.Errorf("navy share = %v, want 0.89 of the non-background pixels", colors[0].Share)
+	}
+}
+
+func TestExtractDominantColorsSolidImage(t *testing.T) {
+	colors := ExtractDominantColors(filledImage(50, 50, testWhite), DefaultColorClusters)
+	if len(colors) != 1 || colors[0].Color.Name != "white" || colors[0].Share != 1 {
+		t.Errorf("colors = %+v, want only white with share 1", colors)
+	}
+}
+
+func TestExtractDominantColorsTransparentPNG(t *testing.T) {
+	transparent := color.RGBA{}
+
+	// 透明な背景に切り抜いた衣服（透明な画素は無視される）
+	cutout := filledImage(100, 100, transparent, coloredRect{image.Rect(30, 30, 70, 70), testRed})
+	colors := ExtractDominantColors(cutout, DefaultColorClusters)
+	if len(colors) != 1 || colors[0].Color.Name != "red" || colors[0].Share != 1 {
+		t.Errorf("cutout colors = %+v, want only red with share 1", colors)
+	}
+
+	// 外周まで写った衣服の周りだけが透明な場合も衣服の色を背景としない
+	framed := filledImage(100, 100, testNavy,
+		coloredRect{image.Rect(0, 0, 100, 5), transparent},
+		coloredRect{image.Rect(45, 45, 55, 55), testWhite},
+	)
+	colors = ExtractDominantColors(framed, DefaultColorClusters)
+	if len(colors) == 0 || colors[0].Color.Name != "navy" {
+		t.Errorf("framed colors = %v, want navy first", colorNames(colors))
+	}
+
+	// 半透明の画素は乗算済みアルファを戻した色で扱う
+	translucent := filledImage(20, 20, color.NRGBA{R: 0xc6, G: 0x28, B: 0x28, A: 0xc0})
+	colors = ExtractDominantColors(translucent, DefaultColorClusters)
+	if len(colors) != 1 || colors[0].Color.Name != "red" {
+		t.Errorf("translucent colors = %v, want [red]", colorNames(colors))
+	}
+
+	if colors := ExtractDominantColors(filledImage(10, 10, transparent), DefaultColorClusters); colors != nil {
+		t.Errorf("fully transparent image colors = %v, want none", colorNames(colors))
+	}
+}
+
+func TestExtractDominantColorsIsDeterministic(t *testing.T) {
+	img := filledImage(120, 80, testWhite,
+		coloredRect{image.Rect(10, 10, 60, 70), testNavy},
+		coloredRect{image.Rect(60, 10, 110, 70), testRed},
+	)
+	first := ExtractDominantColors(img, DefaultColorClusters)
+	for i := 0; i < 3; i++ {
+		again := ExtractDominantColors(img, DefaultColorClusters)
+		if len(again) != len(first) {
+			t.Fatalf("run %d: colors = %v, want %v", i, colorNames(again), colorNames(first))
+		}
+		for j := range first {
+			if again[j] != first[j] {
+				t.Errorf("run %d: color %d = %+v, want %+v", i, j, again[j], first[j])
+			}
+		}
+	}
+}
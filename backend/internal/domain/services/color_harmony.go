@@ -0,0 +1,176 @@
+package services
+
+import (
+	"math"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 配色の種類
+type ColorScheme string
+
+const (
+	SchemeNeutral       ColorScheme = "neutral"        // 無彩色・定番色のみ
+	SchemeNeutralAccent ColorScheme = "neutral_accent" // 定番色に差し色を1色
+	SchemeMonochrome    ColorScheme = "monochrome"     // 同系色の濃淡
+	SchemeAnalogous     ColorScheme = "analogous"      // 隣り合う色相
+	SchemeComplementary ColorScheme = "complementary"  // 補色
+	SchemeClash         ColorScheme = "clash"          // ぶつかり合う配色
+	SchemeUnknown       ColorScheme = ""               // 色が判定できない
+)
+
+// 配色の説明
+var colorSchemeDescriptions = map[ColorScheme]string{
+	SchemeNeutral:       "定番色でまとめた落ち着いた配色",
+	SchemeNeutralAccent: "定番色に差し色を効かせた配色",
+	SchemeMonochrome:    "同系色の濃淡でまとめた配色",
+	SchemeAnalogous:     "近い色合いでまとめた配色",
+	SchemeComplementary: "補色を組み合わせたメリハリのある配色",
+	SchemeClash:         "色同士がぶつかりやすい配色",
+}
+
+// 配色判定の色相の目安（度）
+const (
+	monochromeHueRange    = 15.0
+	analogousHueRange     = 45.0
+	complementaryHueRange = 30.0 // 180度からの許容差
+)
+
+// 配色の評価結果
+type ColorHarmonyResult struct {
+	Scheme ColorScheme
+	Score  float64 // 0.0〜1.0（高いほど調和している）
+}
+
+// 説明文（判定できない場合は空）
+func (r ColorHarmonyResult) Description() string {
+	return colorSchemeDescriptions[r.Scheme]
+}
+
+// アイテムの色を標準パレットの色に変換する関数
+type colorLookup func(item *entities.ClothingItem) (entities.PaletteColor, bool)
+
+// アイテムの色をその都度 ParseColor で変換
+func parseItemColor(item *entities.ClothingItem) (entities.PaletteColor, bool) {
+	return entities.ParseColor(item.Color)
+}
+
+// アイテムの色の組み合わせを評価
+// 定番色＋差し色・同系色・補色を高く、色相がぶつかる鮮やかな色同士を低く評価する（色が判定できないアイテムは除外）
+func EvaluateColorHarmony(items []*entities.ClothingItem) ColorHarmonyResult {
+	return evaluateColorHarmony(items, parseItemColor)
+}
+
+// 変換済みの色を使って配色を評価（同じアイテムを何度も評価する候補の採点で使用）
+func evaluateColorHarmony(items []*entities.ClothingItem, lookup colorLookup) ColorHarmonyResult {
+	var accents []entities.PaletteColor
+	known := 0
+	for _, item := range items {
+		color, ok := lookup(item)
+		if !ok {
+			continue
+		}
+		known++
+		if !color.Neutral {
+			accents = append(accents, color)
+		}
+	}
+
+	switch {
+	case known == 0:
+		return ColorHarmonyResult{Scheme: SchemeUnknown, Score: 0.7}
+	case len(accents) == 0:
+		return ColorHarmonyResult{Scheme: SchemeNeutral, Score: 0.85}
+	}
+
+	distinct := distinctColors(accents)
+	if len(distinct) == 1 {
+		if len(accents) > 1 && len(accents) == known {
+			return ColorHarmonyResult{Scheme: SchemeMonochrome, Score: 0.9}
+		}
+		return ColorHarmonyResult{Scheme: SchemeNeutralAccent, Score: 1}
+	}
+
+	spread := maxHueDistance(distinct)
+	switch {
+	case spread <= monochromeHueRange:
+		return ColorHarmonyResult{Scheme: SchemeMonochrome, Score: 0.95}
+	case spread <= analogousHueRange:
+		return ColorHarmonyResult{Scheme: SchemeAnalogous, Score: 0.85 - 0.1*float64(len(distinct)-2)}
+	case len(distinct) == 2 && math.Abs(spread-180) <= complementaryHueRange:
+		return ColorHarmonyResult{Scheme: SchemeComplementary, Score: 0.75}
+	}
+
+	// 色相が離れた色同士は、鮮やかな色が多いほどぶつかる
+	score := 0.5 - 0.15*float64(len(distinct)-2)
+	for _, color := range distinct {
+		if color.Saturation >= 0.6 && color.Lightness >= 0.35 && color.Lightness <= 0.65 {
+			score -= 0.1
+		}
+	}
+	return ColorHarmonyResult{Scheme: SchemeClash, Score: math.Max(score, 0)}
+}
+
+// ユーザーの好みの色に一致するアイテムの割合（0.0〜1.0）
+// 好みの色・アイテムの色とも標準パレットに正規化して比較する（"紺" と "navy" は一致）
+func PreferredColorMatch(items []*entities.ClothingItem, preferred []string) float64 {
+	return preferredColorMatch(items, paletteColorSet(preferred), parseItemColor)
+}
+
+// 標準パレットの識別名の集合に含まれる色のアイテムの割合
+func preferredColorMatch(items []*entities.ClothingItem, wanted map[string]bool, lookup colorLookup) float64 {
+	if len(items) == 0 || len(wanted) == 0 {
+		return 0
+	}
+
+	matched := 0
+	for _, item := range items {
+		if color, ok := lookup(item); ok && wanted[color.Name] {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(items))
+}
+
+// 色名を標準パレットの識別名の集合に変換（判定できない色名は除く）
+func paletteColorSet(texts []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, text := range texts {
+		if color, ok := entities.ParseColor(text); ok {
+			set[color.Name] = true
+		}
+	}
+	return set
+}
+
+// ユーザー設定から好みの色を集める
+func preferredColors(preferences *entities.UserPreferences) []string {
+	if preferences == nil {
+		return nil
+	}
+	return append(append([]string(nil), preferences.PreferredColors...), preferences.Colors...)
+}
+
+// 重複を除いた色の一覧
+func distinctColors(colors []entities.PaletteColor) []entities.PaletteColor {
+	seen := make(map[string]bool)
+	var distinct []entities.PaletteColor
+	for _, color := range colors {
+		if !seen[color.Name] {
+			seen[color.Name] = true
+			distinct = append(distinct, color)
+		}
+	}
+	return distinct
+}
+
+// 色同士の色相差の最大値
+func maxHueDistance(colors []entities.PaletteColor) float64 {
+	spread := 0.0
+	for i := range colors {
+		for j := i + 1; j < len(colors); j++ {
+			spread = math.Max(spread, entities.HueDistance(colors[i], colors[j]))
+		}
+	}
+	return spread
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func coloredItems(colors ...string) []*entities.ClothingItem {
+	items := make([]*entities.ClothingItem, len(colors))
+	for i, color := range colors {
+		items[i] = &entities.ClothingItem{ID: color, Color: color}
+	}
+	return items
+}
+
+func TestEvaluateColorHarmony(t *testing.T) {
+	tests := []struct {
+		colors []string
+		want   ColorScheme
+	}{
+		{[]string{"黒", "white", "紺"}, SchemeNeutral},
+		{[]string{"black", "white", "赤"}, SchemeNeutralAccent},
+		{[]string{"red", "レッド", "赤"}, SchemeMonochrome},
+		{[]string{"red", "orange", "beige"}, SchemeAnalogous},
+		{[]string{"blue", "orange"}, SchemeComplementary},
+		{[]string{"red", "green", "blue"}, SchemeClash},
+		{[]string{"謎の色", ""}, SchemeUnknown},
+	}
+	for _, test := range tests {
+		if got := EvaluateColorHarmony(coloredItems(test.colors...)); got.Scheme != test.want {
+			t.Errorf("EvaluateColorHarmony(%v) = %q, want %q", test.colors, got.Scheme, test.want)
+		}
+	}
+
+	// 定番色＋差し色はぶつかる配色より高く評価する
+	accent := EvaluateColorHarmony(coloredItems("black", "white", "red"))
+	clash := EvaluateColorHarmony(coloredItems("red", "green", "blue"))
+	if accent.Score <= clash.Score {
+		t.Errorf("neutral accent score %v <= clash score %v", accent.Score, clash.Score)
+	}
+	if clash.Description() == "" || (ColorHarmonyResult{}).Description() != "" {
+		t.Errorf("descriptions = %q and %q, want one only for known schemes", clash.Description(), (ColorHarmonyResult{}).Description())
+	}
+}
+
+func TestPreferredColorMatch(t *testing.T) {
+	items := coloredItems("navy", "白", "red", "謎の色")
+	if got := PreferredColorMatch(items, []string{"紺", "ホワイト"}); got != 0.5 {
+		t.Errorf("PreferredColorMatch = %v, want 0.5", got)
+	}
+	if got := PreferredColorMatch(items, []string{"謎の色"}); got != 0 {
+		t.Errorf("PreferredColorMatch with unknown preferences = %v, want 0", got)
+	}
+}
+
+func TestColorHarmonyAffectsScore(t *testing.T) {
+	red := &entities.ClothingItem{ID: "red", Category: string(entities.CategoryTops), Color: "red", WarmthLevel: 3}
+	white := &entities.ClothingItem{ID: "white", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 3}
+	green := &entities.ClothingItem{ID: "green", Category: string(entities.CategoryBottoms), Color: "green", WarmthLevel: 3}
+	blue := &entities.ClothingItem{ID: "blue", Category: string(entities.CategoryShoes), Color: "blue", WarmthLevel: 2}
+
+	ctx := &ScoringContext{Needs: NewWeatherNeeds(testWeather(18), 0), Now: time.Now()}
+	for _, strategy := range []RecommendationStrategy{RuleBasedStrategy{}, NewRecommendationStrategy(StrategyWeighted)} {
+		clashing := strategy.Score(ctx, &CandidateOutfit{Tops: []*entities.ClothingItem{red}, Bottom: green, Shoes: blue})
+		calmer := strategy.Score(ctx, &CandidateOutfit{Tops: []*entities.ClothingItem{white}, Bottom: green, Shoes: blue})
+		if calmer.ColorHarmony <= clashing.ColorHarmony || calmer.Total <= clashing.Total {
+			t.Errorf("%s: white top %+v, red top %+v, want the calmer palette to score higher", strategy.Name(), calmer, clashing)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// UmbrellaProbabilityThreshold 外出時間帯のいずれかでこの降水確率を超える場合に傘を推奨
+// 長時間の外出では途中で降られる可能性が高いため、時点指定の雨具の閾値より低くする
+const UmbrellaProbabilityThreshold = 0.3
+
+// 予報の間隔が不明な場合（予報が1件のみなど）に仮定する間隔
+const defaultForecastInterval = time.Hour
+
+// ErrNoForecastInWindow 指定された時間帯の予報が取得できない場合のエラー
+var ErrNoForecastInWindow = errors.New("指定された時間帯の天気予報がありません")
+
+// 雨風への備えを優先する天気の順位（値が大きいほど厳しい）
+var conditionSeverity = map[entities.WeatherConditionCode]int{
+	entities.ConditionClear:        0,
+	entities.ConditionClouds:       1,
+	entities.ConditionMist:         2,
+	entities.ConditionHaze:         2,
+	entities.ConditionFog:          3,
+	entities.ConditionDrizzle:      4,
+	entities.ConditionRain:         5,
+	entities.ConditionSleet:        6,
+	entities.ConditionSnow:         7,
+	entities.ConditionSquall:       8,
+	entities.ConditionThunderstorm: 9,
+	entities.ConditionTornado:      10,
+}
+
+// 時間ごとの予報から外出時間帯（departure〜return）の気象条件を集計
+// 各予報は次の予報の時刻までの期間を表すものとして、時間帯と重なる予報を全て含める
+func NewDayPlan(hourly []entities.WeatherCondition, departure, ret time.Time) (*entities.DayPlan, error) {
+	plan := &entities.DayPlan{
+		DepartureAt:  departure,
+		ReturnAt:     ret,
+		MinFeelsLike: math.Inf(1),
+		MaxFeelsLike: math.Inf(-1),
+	}
+
+	for i, sample := range hourly {
+		end := sample.DateTime.Add(defaultForecastInterval)
+		if i+1 < len(hourly) {
+			end = hourly[i+1].DateTime
+		} else if i > 0 {
+			end = sample.DateTime.Add(sample.DateTime.Sub(hourly[i-1].DateTime))
+		}
+		if sample.DateTime.After(ret) || !end.After(departure) {
+			continue
+		}
+
+		plan.Hourly = append(plan.Hourly, sample)
+		plan.MinFeelsLike = math.Min(plan.MinFeelsLike, sample.FeelsLike)
+		plan.MaxFeelsLike = math.Max(plan.MaxFeelsLike, sample.FeelsLike)
+		plan.MaxPrecipitationProbability = math.Max(plan.MaxPrecipitationProbability, sample.PrecipitationProbability)
+		plan.MaxWindSpeed = math.Max(plan.MaxWindSpeed, sample.WindSpeed)
+	}
+
+	if len(plan.Hourly) == 0 {
+		return nil, ErrNoForecastInWindow
+	}
+	return plan, nil
+}
+
+// 立ち寄る地点ごとの滞在時間帯と時間ごとの予報
+type WaypointForecast struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	ArriveAt  time.Time
+	LeaveAt   time.Time
+	Hourly    []entities.WeatherCondition
+}
+
+// 複数地点の予報から、各地点の滞在時間帯をつなげた外出時間帯の気象条件を集計
+// 地点は滞在順に並んでいるものとし、各地点の集計結果を Waypoints に記録する
+func NewWaypointDayPlan(waypoints []WaypointForecast) (*entities.DayPlan, error) {
+	if len(waypoints) == 0 {
+		return nil, ErrNoForecastInWindow
+	}
+
+	plan := &entities.DayPlan{
+		DepartureAt:  waypoints[0].ArriveAt,
+		ReturnAt:     waypoints[len(waypoints)-1].LeaveAt,
+		MinFeelsLike: math.Inf(1),
+		MaxFeelsLike: math.Inf(-1),
+	}
+	for _, waypoint := range waypoints {
+		segment, err := NewDayPlan(waypoint.Hourly, waypoint.ArriveAt, waypoint.LeaveAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", waypoint.Name, err)
+		}
+		for i := range segment.Hourly {
+			segment.Hourly[i].Location = waypoint.Name
+		}
+
+		plan.Hourly = append(plan.Hourly, segment.Hourly...)
+		plan.MinFeelsLike = math.Min(plan.MinFeelsLike, segment.MinFeelsLike)
+		plan.MaxFeelsLike = math.Max(plan.MaxFeelsLike, segment.MaxFeelsLike)
+		plan.MaxPrecipitationProbability = math.Max(plan.MaxPrecipitationProbability, segment.MaxPrecipitationProbability)
+		plan.MaxWindSpeed = math.Max(plan.MaxWindSpeed, segment.MaxWindSpeed)
+
+		condition, _ := mostSevereCondition(segment.Hourly)
+		plan.Waypoints = append(plan.Waypoints, entities.WaypointWeather{
+			Name:                        waypoint.Name,
+			Latitude:                    waypoint.Latitude,
+			Longitude:                   waypoint.Longitude,
+			ArriveAt:                    waypoint.ArriveAt,
+			LeaveAt:                     waypoint.LeaveAt,
+			MinFeelsLike:                segment.MinFeelsLike,
+			MaxFeelsLike:                segment.MaxFeelsLike,
+			MaxPrecipitationProbability: segment.MaxPrecipitationProbability,
+			MaxWindSpeed:                segment.MaxWindSpeed,
+			Condition:                   condition,
+		})
+	}
+	return plan, nil
+}
+
+// 時間帯全体をカバーするための最も厳しい気象条件
+// 体感温度が最も低い時点をもとに、時間帯内の最大の降水確率・風速と最も厳しい天気を合成する
+func worstCaseCondition(plan *entities.DayPlan) *entities.WeatherCondition {
+	worst := plan.Hourly[0]
+	for _, sample := range plan.Hourly[1:] {
+		if sample.FeelsLike < worst.FeelsLike {
+			worst = sample
+		}
+	}
+
+	worst.Condition, worst.Description = mostSevereCondition(plan.Hourly)
+	worst.PrecipitationProbability = plan.MaxPrecipitationProbability
+	worst.WindSpeed = plan.MaxWindSpeed
+	return &worst
+}
+
+// 最も雨風への備えが必要な天気とその説明
+func mostSevereCondition(samples []entities.WeatherCondition) (entities.WeatherConditionCode, string) {
+	worst := samples[0]
+	for _, sample := range samples[1:] {
+		if conditionSeverity[sample.Condition] > conditionSeverity[worst.Condition] {
+			worst = sample
+		}
+	}
+	return worst.Condition, worst.Description
+}
+
+// 時間帯内で雨が降る可能性があるか
+func rainExpected(plan *entities.DayPlan) bool {
+	if plan.MaxPrecipitationProbability >= UmbrellaProbabilityThreshold {
+		return true
+	}
+	for _, sample := range plan.Hourly {
+		if sample.Condition.IsRainy() || sample.Condition.IsSnowy() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// start から1時間ごとに feelsLike の体感温度となる予報
+func hourlyForecast(start time.Time, feelsLike ...float64) []entities.WeatherCondition {
+	hourly := make([]entities.WeatherCondition, len(feelsLike))
+	for i, f := range feelsLike {
+		hourly[i] = entities.WeatherCondition{
+			DateTime:  start.Add(time.Duration(i) * time.Hour),
+			FeelsLike: f,
+			WindSpeed: 2,
+			Condition: entities.ConditionClouds,
+		}
+	}
+	return hourly
+}
+
+func TestNewDayPlan(t *testing.T) {
+	start := time.Date(2024, 4, 10, 6, 0, 0, 0, time.UTC)
+	hourly := hourlyForecast(start, 3, 5, 8, 12, 16, 19, 21)
+	hourly[3].PrecipitationProbability = 0.4
+	hourly[4].WindSpeed = 9
+	hourly[6].PrecipitationProbability = 0.9
+
+	// 08:30〜11:00 には 08:00〜11:00 の予報が重なる
+	plan, err := NewDayPlan(hourly, start.Add(150*time.Minute), start.Add(5*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Hourly) != 4 || !plan.Hourly[0].DateTime.Equal(start.Add(2*time.Hour)) {
+		t.Fatalf("hourly = %d samples from %v, want 4 from 08:00", len(plan.Hourly), plan.Hourly[0].DateTime)
+	}
+	if plan.MinFeelsLike != 8 || plan.MaxFeelsLike != 19 || plan.MaxPrecipitationProbability != 0.4 || plan.MaxWindSpeed != 9 {
+		t.Errorf("plan = feels like %v〜%v precipitation %v wind %v, want 8〜19, 0.4 and 9",
+			plan.MinFeelsLike, plan.MaxFeelsLike, plan.MaxPrecipitationProbability, plan.MaxWindSpeed)
+	}
+
+	if _, err := NewDayPlan(hourly, start.Add(-3*time.Hour), start.Add(-time.Hour)); !errors.Is(err, ErrNoForecastInWindow) {
+		t.Errorf("window before the forecast error = %v, want ErrNoForecastInWindow", err)
+	}
+}
+
+func TestGenerateRecommendationDayPlan(t *testing.T) {
+	start := time.Date(2024, 4, 10, 8, 0, 0, 0, time.UTC)
+	plan, err := NewDayPlan(hourlyForecast(start, 4, 8, 14, 20), start, start.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan.Hourly[2].Condition = entities.ConditionRain
+	items := append(warmthCloset(), &entities.ClothingItem{ID: "umbrella", Name: "折りたたみ傘", Category: string(entities.CategoryAccessory), Color: "black"})
+
+	recommendation := NewFashionRecommendationService().GenerateRecommendation(RecommendationInput{DayPlan: plan, Clothing: items, Now: start}, nil)
+	if len(recommendation.Outfits) == 0 {
+		t.Fatalf("no outfits: %s", recommendation.Reason)
+	}
+	outfit := recommendation.Outfits[0]
+
+	// 最も冷え込む時点に合わせ、暖かい時間帯に脱げるアイテムを示す
+	if outfit.TargetWarmth != TargetWarmth(4) {
+		t.Errorf("target warmth = %d, want %d for the coldest hour", outfit.TargetWarmth, TargetWarmth(4))
+	}
+	removable := 0
+	for _, item := range outfit.Items {
+		if item.Removable {
+			removable++
+		}
+	}
+	if removable == 0 {
+		t.Errorf("items = %+v, want a removable layer for the warm afternoon", outfit.Items)
+	}
+	if !strings.Contains(outfit.Reason, "脱いで調整できます") {
+		t.Errorf("reason = %q, want a note on removing layers", outfit.Reason)
+	}
+
+	// 時間帯のいずれかで雨が降る場合は傘を持たせる
+	if !containsItem(outfit.Items, "umbrella") || !strings.Contains(outfit.Reason, "傘") {
+		t.Errorf("outfit = %+v, want the umbrella for the rainy hour", outfit)
+	}
+	if recommendation.Weather.Condition != entities.ConditionRain {
+		t.Errorf("weather condition = %q, want the most severe condition in the window", recommendation.Weather.Condition)
+	}
+}
+
+func TestNewWaypointDayPlan(t *testing.T) {
+	start := time.Date(2024, 4, 10, 7, 0, 0, 0, time.UTC)
+	home := hourlyForecast(start, 2, 4, 6, 8, 10, 12)
+	office := hourlyForecast(start, 9, 11, 13, 15, 17, 19)
+	office[3].Condition = entities.ConditionRain
+	office[3].PrecipitationProbability = 0.8
+
+	plan, err := NewWaypointDayPlan([]WaypointForecast{
+		{Name: "自宅", ArriveAt: start, LeaveAt: start.Add(time.Hour), Hourly: home},
+		{Name: "会社", ArriveAt: start.Add(2 * time.Hour), LeaveAt: start.Add(4 * time.Hour), Hourly: office},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.DepartureAt.Equal(start) || !plan.ReturnAt.Equal(start.Add(4*time.Hour)) {
+		t.Errorf("window = %v〜%v, want the first arrival to the last departure", plan.DepartureAt, plan.ReturnAt)
+	}
+	if plan.MinFeelsLike != 2 || plan.MaxFeelsLike != 17 || plan.MaxPrecipitationProbability != 0.8 {
+		t.Errorf("plan = feels like %v〜%v precipitation %v, want 2〜17 and 0.8", plan.MinFeelsLike, plan.MaxFeelsLike, plan.MaxPrecipitationProbability)
+	}
+	if len(plan.Waypoints) != 2 {
+		t.Fatalf("waypoints = %d, want 2", len(plan.Waypoints))
+	}
+	if w := plan.Waypoints[0]; w.Name != "自宅" || w.MinFeelsLike != 2 || w.MaxFeelsLike != 4 || w.Condition != entities.ConditionClouds {
+		t.Errorf("home = %+v, want feels like 2〜4 and clouds", w)
+	}
+	if w := plan.Waypoints[1]; w.Name != "会社" || w.MinFeelsLike != 13 || w.MaxFeelsLike != 17 || w.Condition != entities.ConditionRain {
+		t.Errorf("office = %+v, want feels like 13〜17 and rain", w)
+	}
+	for _, sample := range plan.Hourly {
+		if sample.Location != "自宅" && sample.Location != "会社" {
+			t.Errorf("sample at %v has location %q, want the waypoint name", sample.DateTime, sample.Location)
+		}
+	}
+
+	// 予報のない地点はその地点名を含めて ErrNoForecastInWindow を返す
+	_, err = NewWaypointDayPlan([]WaypointForecast{
+		{Name: "自宅", ArriveAt: start, LeaveAt: start.Add(time.Hour), Hourly: home},
+		{Name: "旅行先", ArriveAt: start.Add(24 * time.Hour), LeaveAt: start.Add(25 * time.Hour), Hourly: office},
+	})
+	if !errors.Is(err, ErrNoForecastInWindow) || !strings.Contains(err.Error(), "旅行先") {
+		t.Errorf("error = %v, want ErrNoForecastInWindow naming the waypoint", err)
+	}
+}
+
+func TestGenerateRecommendationWaypointNotes(t *testing.T) {
+	start := time.Date(2024, 4, 10, 7, 0, 0, 0, time.UTC)
+	plan, err := NewWaypointDayPlan([]WaypointForecast{
+		{Name: "自宅", ArriveAt: start, LeaveAt: start.Add(time.Hour), Hourly: hourlyForecast(start, 2, 4)},
+		{Name: "会社", ArriveAt: start.Add(time.Hour), LeaveAt: start.Add(2 * time.Hour), Hourly: hourlyForecast(start, 14, 16, 18)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recommendation := NewFashionRecommendationService().GenerateRecommendation(RecommendationInput{DayPlan: plan, Clothing: warmthCloset(), Now: start}, nil)
+	if len(recommendation.Outfits) == 0 {
+		t.Fatalf("no outfits: %s", recommendation.Reason)
+	}
+	reason := recommendation.Outfits[0].Reason
+	// 最も冷え込む地点にだけ、その地点に合わせたことを示す
+	if !strings.Contains(reason, "【自宅 07:00〜08:00】体感温度2〜4℃") || !strings.Contains(reason, "【会社 08:00〜09:00】") {
+		t.Errorf("reason = %q, want notes for both waypoints", reason)
+	}
+	if strings.Count(reason, "最も冷え込むこの地点に合わせています") != 1 || !strings.Contains(reason, "2〜4℃・"+entities.ConditionClouds.DisplayName(entities.LanguageJapanese)+"・降水確率0%（最も冷え込む") {
+		t.Errorf("reason = %q, want only the home waypoint marked as the coldest", reason)
+	}
+}
@@ -1,168 +1,723 @@
 package services
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
 	"forecast-app/internal/domain/entities"
 )
 
+// 推奨ルールで使用する気象条件の閾値
+const (
+	// RainProbabilityThreshold 雨具を推奨する降水確率
+	RainProbabilityThreshold = 0.5
+
+	// WindSpeedThreshold 防風アイテムを推奨する風速（m/s）
+	WindSpeedThreshold = 8.0
+
+	// ColdAccessoryThreshold 防寒小物を推奨する体感温度（摂氏）
+	ColdAccessoryThreshold = 10.0
+
+	// SunAccessoryThreshold 晴天時に日差し対策を推奨する体感温度（摂氏）
+	SunAccessoryThreshold = 22.0
+
+	// HumidityThreshold 通気性の良い素材を優先する湿度（パーセント、HumidFeelsLikeThreshold 以上の体感温度の場合）
+	HumidityThreshold = 70
+
+	// HumidFeelsLikeThreshold 蒸し暑さを考慮する体感温度（摂氏）
+	HumidFeelsLikeThreshold = 20.0
+)
+
+// 目標保温レベルの範囲
+const (
+	minTargetWarmth = 4
+	maxTargetWarmth = 30
+)
+
+// 推奨するコーディネート候補の最大数
+const MaxOutfitAlternatives = 3
+
+// 組み合わせを列挙する際にカテゴリごとに残す候補数（組み合わせの爆発を防ぐ）
+const candidatesPerCategory = 8
+
+// カテゴリごとの目標保温レベルに対する配分（候補の絞り込みに使用）
+var categoryShares = map[entities.ClothingCategory]float64{
+	entities.CategoryTops:      0.4,
+	entities.CategoryBottoms:   0.3,
+	entities.CategoryShoes:     0.15,
+	entities.CategoryOuterwear: 0.35,
+}
+
+// 天気とクローゼットの内容から服装を推奨するドメインサービス
+// 体感温度から目標とする合計保温レベルを求め、各アイテムの WarmthLevel の合計が近いコーディネートを組み立てる
 type FashionRecommendationService struct{}
 
 func NewFashionRecommendationService() *FashionRecommendationService {
 	return &FashionRecommendationService{}
 }
 
-func (s *FashionRecommendationService) RecommendClothing(weather *entities.WeatherData, userClothing []entities.Clothing) []entities.Clothing {
-	recommendations := []entities.Clothing{}
-	
-	if weather.Temperature <= 0 {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "冬服")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "防寒具")...)
-	} else if weather.Temperature <= 10 {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "秋冬服")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "ジャケット")...)
-	} else if weather.Temperature <= 20 {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "春秋服")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "カーディガン")...)
-	} else if weather.Temperature <= 25 {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "春夏服")...)
-	} else {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "夏服")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "Tシャツ")...)
-	}
-	
-	switch weather.Condition {
-	case "雨":
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "レインコート")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "長靴")...)
-	case "雪":
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "スノーブーツ")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "手袋")...)
-	case "晴れ":
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "サングラス")...)
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "帽子")...)
-	}
-	
-	if weather.WindSpeed > 10 {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "ウインドブレーカー")...)
-	}
-	
-	if weather.Humidity > 70 {
-		recommendations = append(recommendations, s.getClothingByCategory(userClothing, "通気性")...)
-	}
-	
-	return s.removeDuplicates(recommendations)
-}
-
-func (s *FashionRecommendationService) getClothingByCategory(clothing []entities.Clothing, category string) []entities.Clothing {
-	var filtered []entities.Clothing
-	for _, item := range clothing {
-		if item.Category == category {
-			filtered = append(filtered, item)
+// 体感温度から目標とする合計保温レベルを算出
+// 30℃で4（Tシャツ・短パン・サンダル程度）、0℃で25前後となり、寒いほど大きくなる
+func TargetWarmth(feelsLike float64) int {
+	target := int(math.Round((30-feelsLike)*0.7 + minTargetWarmth))
+	if target < minTargetWarmth {
+		return minTargetWarmth
+	}
+	if target > maxTargetWarmth {
+		return maxTargetWarmth
+	}
+	return target
+}
+
+// 推奨計算に使用する気象条件の判定結果
+type WeatherNeeds struct {
+	FeelsLike     float64 // 体感温度（ユーザーの体感補正を反映済み）
+	ComfortOffset float64 // 反映したユーザーの体感補正（摂氏、正の値ほど寒がり）
+	TargetWarmth  int     // 目標とする合計保温レベル
+	Rainy         bool    // 雨具が必要
+	Windy         bool    // 防風対策が必要
+	Cold          bool    // 防寒小物が必要
+	Sunny         bool    // 日差し対策が必要
+	Humid         bool    // 蒸し暑く通気性の良い素材が望ましい
+
+	// 外出時間帯を指定した場合のみ設定（時点指定では MildFeelsLike・MildTargetWarmth は FeelsLike・TargetWarmth と同じ）
+	DayPlan          bool    // 外出時間帯を指定した推奨か
+	MildFeelsLike    float64 // 時間帯で最も暖かい時点の体感温度
+	MildTargetWarmth int     // 時間帯で最も暖かい時点の目標保温レベル
+	Umbrella         bool    // 時間帯のいずれかで雨の可能性があり傘が必要
+
+	Waypoints []entities.WaypointWeather // 立ち寄る地点ごとの気象条件（地点を指定した場合のみ）
+
+	HeatStress entities.AdvisorySeverity // 熱中症の注意喚起の深刻度（該当なしの場合は空）
+	ColdStress entities.AdvisorySeverity // 寒冷ストレスの注意喚起の深刻度（該当なしの場合は空）
+}
+
+// 気象条件から必要な備えを判定
+// comfortOffset はフィードバックから学習したユーザーの体感補正で、体感温度から差し引いて判定する
+func NewWeatherNeeds(weather *entities.WeatherCondition, comfortOffset float64) WeatherNeeds {
+	feelsLike := weather.FeelsLike - comfortOffset
+	return WeatherNeeds{
+		FeelsLike:        feelsLike,
+		ComfortOffset:    comfortOffset,
+		TargetWarmth:     TargetWarmth(feelsLike),
+		MildFeelsLike:    feelsLike,
+		MildTargetWarmth: TargetWarmth(feelsLike),
+		Rainy: weather.Condition.IsRainy() || weather.Condition.IsSnowy() ||
+			weather.PrecipitationProbability >= RainProbabilityThreshold,
+		Windy: weather.WindSpeed >= WindSpeedThreshold,
+		Cold:  feelsLike < ColdAccessoryThreshold,
+		Sunny: weather.Condition.IsSunny() && feelsLike >= SunAccessoryThreshold,
+		Humid: weather.Humidity >= HumidityThreshold && feelsLike >= HumidFeelsLikeThreshold,
+	}
+}
+
+// 外出時間帯の気温の幅と降水の可能性を反映
+func (n WeatherNeeds) withDayPlan(plan *entities.DayPlan) WeatherNeeds {
+	n.DayPlan = true
+	n.MildFeelsLike = plan.MaxFeelsLike - n.ComfortOffset
+	n.MildTargetWarmth = TargetWarmth(n.MildFeelsLike)
+	n.Umbrella = rainExpected(plan)
+	n.Waypoints = plan.Waypoints
+	return n
+}
+
+// 熱中症・寒冷ストレスの注意喚起を反映
+// 注意喚起があれば日差し対策・防寒小物を気温にかかわらず必要とする
+func (n WeatherNeeds) withAdvisories(advisories []entities.WeatherAdvisory) WeatherNeeds {
+	n.HeatStress = advisorySeverity(advisories, entities.AdvisoryHeatstroke)
+	n.ColdStress = advisorySeverity(advisories, entities.AdvisoryColdStress)
+	if n.HeatStress.AtLeast(entities.SeverityCaution) {
+		n.Sunny = true
+	}
+	if n.ColdStress.AtLeast(entities.SeverityWarning) {
+		n.Cold = true
+	}
+	return n
+}
+
+// 熱中症・寒冷ストレスへの備えの充足状況（必要な項目数と満たしている項目数）
+// 警戒以上の暑さでは、アウターを着ないことと通気性の良いトップス1枚であることを求め、
+// 警戒以上の寒さでは防風性のあるアウターを求める
+func (n WeatherNeeds) stressProtection(c *CandidateOutfit) (required, satisfied int) {
+	if n.HeatStress.AtLeast(entities.SeverityWarning) {
+		required += 2
+		if c.Outerwear == nil {
+			satisfied++
+		}
+		if len(c.Tops) == 1 && c.Tops[0].IsBreathable() {
+			satisfied++
 		}
 	}
-	return filtered
+	if n.ColdStress.AtLeast(entities.SeverityWarning) {
+		required++
+		if c.Outerwear != nil && c.Outerwear.IsWindResistant() {
+			satisfied++
+		}
+	}
+	return required, satisfied
+}
+
+// 時間帯内の気温差が大きく、脱ぎ着での調整が必要か
+func (n WeatherNeeds) hasTemperatureSwing() bool {
+	return n.TargetWarmth-n.MildTargetWarmth >= 2
+}
+
+// カテゴリごとの目安となる保温レベル
+func (n WeatherNeeds) slotTarget(category entities.ClothingCategory) float64 {
+	return math.Min(float64(n.TargetWarmth)*categoryShares[category], entities.MaxWarmthLevel)
+}
+
+// 雨風を防ぐアウターが望ましいか
+func (n WeatherNeeds) needsShell() bool {
+	return (n.Rainy || n.Windy) && n.FeelsLike < 25
+}
+
+// 採点対象となるコーディネート候補
+type CandidateOutfit struct {
+	Tops      []*entities.ClothingItem // ベースレイヤー、（あれば）ミドルレイヤーの順
+	Bottom    *entities.ClothingItem
+	Shoes     *entities.ClothingItem
+	Outerwear *entities.ClothingItem // アウターなしの場合は nil
+
+	accessories []accessoryPick
+	score       entities.ScoreBreakdown
 }
 
-func (s *FashionRecommendationService) removeDuplicates(clothing []entities.Clothing) []entities.Clothing {
-	seen := make(map[int]bool)
-	var unique []entities.Clothing
-	
-	for _, item := range clothing {
-		if !seen[item.ID] {
-			seen[item.ID] = true
-			unique = append(unique, item)
+// 天候に応じて追加した小物と理由
+type accessoryPick struct {
+	item   *entities.ClothingItem
+	reason string
+}
+
+// 小物を除いた基本アイテム（トップス・ボトムス・シューズ・アウター）
+func (c *CandidateOutfit) CoreItems() []*entities.ClothingItem {
+	items := append([]*entities.ClothingItem(nil), c.Tops...)
+	items = append(items, c.Bottom, c.Shoes)
+	if c.Outerwear != nil {
+		items = append(items, c.Outerwear)
+	}
+	return items
+}
+
+// 小物を除いた基本アイテムの保温レベル合計
+func (c *CandidateOutfit) CoreWarmth() int {
+	total := 0
+	for _, item := range c.CoreItems() {
+		total += item.Warmth()
+	}
+	return total
+}
+
+// 小物を含む保温レベル合計
+func (c *CandidateOutfit) totalWarmth() int {
+	total := c.CoreWarmth()
+	for _, acc := range c.accessories {
+		total += acc.item.Warmth()
+	}
+	return total
+}
+
+// 暖かい時間帯に脱いで調整できるアイテム（アウター、ミドルレイヤー、防寒小物の順に判定）
+// 脱いだ後の保温レベルが暖かい時点の目標を大きく下回るアイテムは含めない
+func (c *CandidateOutfit) removableLayers(needs WeatherNeeds) []*entities.ClothingItem {
+	excess := c.totalWarmth() - needs.MildTargetWarmth
+	if excess <= 0 {
+		return nil
+	}
+
+	var layers []*entities.ClothingItem
+	if c.Outerwear != nil {
+		layers = append(layers, c.Outerwear)
+	}
+	if len(c.Tops) > 1 {
+		layers = append(layers, c.Tops[len(c.Tops)-1])
+	}
+	for _, acc := range c.accessories {
+		if acc.item.IsColdProtection() {
+			layers = append(layers, acc.item)
 		}
 	}
-	
-	return unique
+
+	var removable []*entities.ClothingItem
+	for _, layer := range layers {
+		if excess <= 0 {
+			break
+		}
+		if layer.Warmth() > excess+removableTolerance {
+			continue
+		}
+		removable = append(removable, layer)
+		excess -= layer.Warmth()
+	}
+	return removable
 }
 
-func (s *FashionRecommendationService) GenerateOutfitSuggestion(weather *entities.WeatherData, userClothing []entities.Clothing) *entities.OutfitSuggestion {
-	recommendations := s.RecommendClothing(weather, userClothing)
-	
-	outfit := &entities.OutfitSuggestion{
-		WeatherCondition: weather.Condition,
-		Temperature:      weather.Temperature,
-		ClothingItems:    recommendations,
-		Description:      s.generateDescription(weather, recommendations),
+// 脱いだ後に暖かい時点の目標保温レベルを下回ってもよい幅
+const removableTolerance = 2
+
+// 脱いで調整した後の保温レベル（暖かい時間帯の保温レベル）
+func (c *CandidateOutfit) adjustedWarmth(needs WeatherNeeds) int {
+	total := c.totalWarmth()
+	for _, layer := range c.removableLayers(needs) {
+		total -= layer.Warmth()
 	}
-	
-	return outfit
+	return total
 }
 
-func (s *FashionRecommendationService) generateDescription(weather *entities.WeatherData, clothing []entities.Clothing) string {
-	description := "今日の天気に適したコーディネートです。"
-	
-	if weather.Temperature <= 10 {
-		description += "寒いので暖かい服装をおすすめします。"
-	} else if weather.Temperature >= 25 {
-		description += "暑いので涼しい服装をおすすめします。"
+// 基本アイテムのID一覧（候補同士の差分判定に使用）
+func (c *CandidateOutfit) coreIDs() []string {
+	var ids []string
+	for _, item := range c.CoreItems() {
+		ids = append(ids, item.ID)
 	}
-	
-	if weather.Condition == "雨" {
-		description += "雨対策も忘れずに。"
+	return ids
+}
+
+// 推奨の生成に使用する入力
+type RecommendationInput struct {
+	Weather       *entities.WeatherCondition
+	Clothing      []*entities.ClothingItem
+	Preferences   *entities.UserPreferences // ユーザーのファッション設定（未設定の場合は nil）
+	LastWorn      map[string]time.Time      // アイテムIDごとの最終着用日時
+	DayPlan       *entities.DayPlan         // 外出時間帯の予報（指定した場合は Weather の代わりに使用）
+	ComfortOffset float64                   // フィードバックから学習した体感補正（摂氏、正の値ほど暖かい服装を推奨）
+	Now           time.Time
+}
+
+// 気象条件とユーザーのクローゼットからファッション推奨を生成
+// 完全なコーディネートを strategy のスコア順に最大 MaxOutfitAlternatives 件返し、最上位のアイテムを Items にも設定する
+// strategy が nil の場合はルールに基づく戦略を使用する
+func (s *FashionRecommendationService) GenerateRecommendation(input RecommendationInput, strategy RecommendationStrategy) *entities.FashionRecommendation {
+	if strategy == nil {
+		strategy = RuleBasedStrategy{}
+	}
+	if input.Now.IsZero() {
+		input.Now = time.Now()
+	}
+
+	weather := input.Weather
+	if input.DayPlan != nil {
+		weather = worstCaseCondition(input.DayPlan)
+	}
+	needs := NewWeatherNeeds(weather, input.ComfortOffset)
+	samples := []entities.WeatherCondition{*weather}
+	if input.DayPlan != nil {
+		needs = needs.withDayPlan(input.DayPlan)
+		samples = input.DayPlan.Hourly
+	}
+	advisories := AssessAdvisories(samples)
+	needs = needs.withAdvisories(advisories)
+
+	ctx := &ScoringContext{
+		Needs:       needs,
+		Preferences: input.Preferences,
+		LastWorn:    input.LastWorn,
+		Now:         input.Now,
+	}
+	ctx.cacheColors(input.Clothing)
+	// 外出する日の季節に合わないアイテムは、同じカテゴリに季節に合うアイテムがあれば候補から除く
+	seasonDate := input.Now
+	if input.DayPlan != nil {
+		seasonDate = input.DayPlan.DepartureAt
+	}
+	closet := preferInSeason(groupByCategory(input.Clothing), entities.SeasonOf(seasonDate))
+	candidates := s.composeOutfits(ctx, strategy, closet)
+
+	recommendation := &entities.FashionRecommendation{
+		Style:      s.determineStyle(weather),
+		Strategy:   strategy.Name(),
+		Weather:    *weather,
+		DayPlan:    input.DayPlan,
+		Advisories: advisories,
+	}
+	if len(candidates) == 0 {
+		recommendation.Reason = missingCategoriesReason(closet)
+		return recommendation
 	}
-	
-	return description
+
+	for i, candidate := range candidates {
+		recommendation.Outfits = append(recommendation.Outfits, s.toOutfit(ctx.Needs, candidate, i+1))
+	}
+	recommendation.Items = recommendation.Outfits[0].Items
+	recommendation.Reason = recommendation.Outfits[0].Reason
+	return recommendation
 }
 
-func (s *FashionRecommendationService) GenerateRecommendation(weather *entities.WeatherCondition, userClothing []*entities.ClothingItem) *entities.FashionRecommendation {
-	var recommendedItems []entities.RecommendedItem
-	
-	var clothing []entities.Clothing
-	for _, item := range userClothing {
-		clothing = append(clothing, entities.Clothing{
-			Name:     item.Name,
-			Category: item.Category,
-			Color:    item.Color,
-			Type:     item.Type,
-		})
+// トップス・ボトムス・シューズ・アウターの組み合わせを列挙し、スコアの高い順に互いに異なる候補を選ぶ
+func (s *FashionRecommendationService) composeOutfits(ctx *ScoringContext, strategy RecommendationStrategy, closet map[entities.ClothingCategory][]*entities.ClothingItem) []*CandidateOutfit {
+	needs := ctx.Needs
+	tops := s.shortlist(needs, entities.CategoryTops, closet[entities.CategoryTops])
+	bottoms := s.shortlist(needs, entities.CategoryBottoms, closet[entities.CategoryBottoms])
+	shoes := s.shortlist(needs, entities.CategoryShoes, closet[entities.CategoryShoes])
+	if len(tops) == 0 || len(bottoms) == 0 || len(shoes) == 0 {
+		return nil
+	}
+	// アウターなし（nil）も候補に含める
+	outers := append([]*entities.ClothingItem{nil}, s.shortlist(needs, entities.CategoryOuterwear, closet[entities.CategoryOuterwear])...)
+
+	var candidates []*CandidateOutfit
+	for _, layers := range topLayers(tops) {
+		for _, bottom := range bottoms {
+			for _, shoe := range shoes {
+				for _, outer := range outers {
+					candidate := &CandidateOutfit{Tops: layers, Bottom: bottom, Shoes: shoe, Outerwear: outer}
+					candidate.score = strategy.Score(ctx, candidate)
+					candidates = append(candidates, candidate)
+				}
+			}
+		}
 	}
-	
-	weatherData := &entities.WeatherData{
-		Temperature: weather.Temperature,
-		Condition:   weather.Condition,
-		Humidity:    weather.Humidity,
-		WindSpeed:   weather.WindSpeed,
-		Location:    weather.Location,
-		DateTime:    weather.DateTime,
-	}
-	
-	recommendations := s.RecommendClothing(weatherData, clothing)
-	
-	for _, item := range recommendations {
-		recommendedItems = append(recommendedItems, entities.RecommendedItem{
-			Category: item.Category,
-			Name:     item.Name,
-			Color:    item.Color,
-			Reason:   s.getReasonForRecommendation(weather, item.Category),
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score.Total > candidates[j].score.Total
+	})
+
+	var picked []*CandidateOutfit
+	for _, candidate := range candidates {
+		if len(picked) == MaxOutfitAlternatives {
+			break
+		}
+		distinct := true
+		for _, p := range picked {
+			if !differsEnough(candidate, p) {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			s.addAccessories(needs, candidate, closet[entities.CategoryAccessory])
+			picked = append(picked, candidate)
+		}
+	}
+	return picked
+}
+
+// カテゴリ内の候補を目標保温レベルへの近さと雨風への備えで絞り込む
+func (s *FashionRecommendationService) shortlist(needs WeatherNeeds, category entities.ClothingCategory, items []*entities.ClothingItem) []*entities.ClothingItem {
+	if len(items) <= candidatesPerCategory {
+		return items
+	}
+
+	target := needs.slotTarget(category)
+	score := func(item *entities.ClothingItem) float64 {
+		sc := -math.Abs(float64(item.Warmth()) - target)
+		if needs.Rainy && item.IsWaterResistant() {
+			sc += 3
+		}
+		if needs.Windy && item.IsWindResistant() {
+			sc += 3
+		}
+		if needs.Humid && category == entities.CategoryTops && item.IsBreathable() {
+			sc += 2
+		}
+		return sc
+	}
+
+	ranked := append([]*entities.ClothingItem(nil), items...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return score(ranked[i]) > score(ranked[j])
+	})
+	return ranked[:candidatesPerCategory]
+}
+
+// 天候に応じた小物を追加
+func (s *FashionRecommendationService) addAccessories(needs WeatherNeeds, c *CandidateOutfit, accessories []*entities.ClothingItem) {
+	chosen := make(map[string]bool)
+	add := func(item *entities.ClothingItem, reason string) {
+		if item == nil || chosen[item.ID] {
+			return
+		}
+		chosen[item.ID] = true
+		c.accessories = append(c.accessories, accessoryPick{item: item, reason: reason})
+	}
+
+	if needs.Umbrella {
+		add(firstMatching(accessories, (*entities.ClothingItem).IsUmbrella), "外出中に雨の可能性があるため")
+	}
+	if needs.Rainy {
+		add(firstMatching(accessories, (*entities.ClothingItem).IsWaterResistant), "雨に備えて")
+	}
+	if remaining := needs.TargetWarmth - c.totalWarmth(); needs.ColdStress.AtLeast(entities.SeverityWarning) {
+		// 凍傷の危険がある寒さでは保温レベルにかかわらず肌を覆う
+		for _, item := range filter(accessories, (*entities.ClothingItem).IsColdProtection) {
+			add(item, "冷え込みから肌を守るため")
+		}
+	} else if needs.Cold && remaining > 0 {
+		add(bestItem(filter(accessories, (*entities.ClothingItem).IsColdProtection), func(item *entities.ClothingItem) float64 {
+			return -math.Abs(float64(item.Warmth() - remaining))
+		}), "寒さ対策の小物として")
+	}
+	if needs.HeatStress.AtLeast(entities.SeverityCaution) {
+		add(firstMatching(accessories, (*entities.ClothingItem).IsSunProtection), "熱中症対策として")
+	} else if needs.Sunny {
+		add(firstMatching(accessories, (*entities.ClothingItem).IsSunProtection), "日差し対策として")
+	}
+}
+
+// 候補をドメインエンティティのコーディネートに変換
+func (s *FashionRecommendationService) toOutfit(needs WeatherNeeds, c *CandidateOutfit, rank int) entities.Outfit {
+	removable := make(map[*entities.ClothingItem]bool)
+	if needs.DayPlan {
+		for _, layer := range c.removableLayers(needs) {
+			removable[layer] = true
+		}
+	}
+
+	var items []entities.RecommendedItem
+	add := func(item *entities.ClothingItem, slot entities.OutfitSlot, reason string) {
+		items = append(items, entities.RecommendedItem{
+			ClothingID:  item.ID,
+			Slot:        slot,
+			Category:    item.Category,
+			Name:        item.Name,
+			Color:       item.Color,
+			WarmthLevel: item.Warmth(),
+			Removable:   removable[item],
+			Reason:      reason,
 		})
 	}
-	
-	return &entities.FashionRecommendation{
-		Style:   s.determineStyle(weather),
-		Items:   recommendedItems,
-		Weather: *weather,
-		Reason:  s.generateDescription(weatherData, recommendations),
+
+	for i, top := range c.Tops {
+		slot := entities.SlotBaseLayer
+		if i > 0 {
+			slot = entities.SlotMidLayer
+		}
+		add(top, slot, s.itemReason(needs, top))
+	}
+	add(c.Bottom, entities.SlotBottom, s.itemReason(needs, c.Bottom))
+	add(c.Shoes, entities.SlotShoes, s.itemReason(needs, c.Shoes))
+	if c.Outerwear != nil {
+		add(c.Outerwear, entities.SlotOuterwear, s.itemReason(needs, c.Outerwear))
+	}
+	for _, acc := range c.accessories {
+		add(acc.item, entities.SlotAccessory, acc.reason)
+	}
+
+	return entities.Outfit{
+		Rank:         rank,
+		Score:        round2(c.score.Total),
+		Breakdown:    roundBreakdown(c.score),
+		TotalWarmth:  c.totalWarmth(),
+		TargetWarmth: needs.TargetWarmth,
+		Items:        items,
+		Reason:       s.generateDescription(needs, c),
+	}
+}
+
+// アイテムごとの推奨理由
+func (s *FashionRecommendationService) itemReason(needs WeatherNeeds, item *entities.ClothingItem) string {
+	switch {
+	case needs.Rainy && item.IsWaterResistant():
+		return "雨に強い素材のため"
+	case needs.Windy && item.IsWindResistant():
+		return "風を防げるため"
+	case needs.Humid && item.IsBreathable():
+		return "通気性の良い素材で蒸し暑さを和らげるため"
+	default:
+		return fmt.Sprintf("体感温度%.0f℃に合う保温レベル（%d）のため", needs.FeelsLike, item.Warmth())
+	}
+}
+
+// コーディネートの説明文を生成
+func (s *FashionRecommendationService) generateDescription(needs WeatherNeeds, c *CandidateOutfit) string {
+	total := c.totalWarmth()
+	var parts []string
+	if needs.DayPlan {
+		parts = append(parts, fmt.Sprintf("外出中の体感温度は%.0f〜%.0f℃の見込みです。", needs.FeelsLike, needs.MildFeelsLike))
+	}
+	parts = append(parts,
+		fmt.Sprintf("体感温度%.0f℃に合わせて、合計保温レベル%dを目安に選びました（このコーディネート: %d）。", needs.FeelsLike, needs.TargetWarmth, total),
+	)
+	switch {
+	case needs.ComfortOffset > 0:
+		parts = append(parts, fmt.Sprintf("これまでのフィードバックから、%.0f℃寒く感じるものとして調整しています。", needs.ComfortOffset))
+	case needs.ComfortOffset < 0:
+		parts = append(parts, fmt.Sprintf("これまでのフィードバックから、%.0f℃暑く感じるものとして調整しています。", -needs.ComfortOffset))
+	}
+	if len(c.Tops) > 1 {
+		parts = append(parts, "重ね着で温度調節できます。")
+	}
+	if total < needs.TargetWarmth-2 {
+		parts = append(parts, "手持ちのアイテムでは保温が不足気味です。")
+	}
+	if layers := c.removableLayers(needs); needs.DayPlan && needs.hasTemperatureSwing() && len(layers) > 0 {
+		var names []string
+		for _, layer := range layers {
+			names = append(names, layer.Name)
+		}
+		parts = append(parts, fmt.Sprintf("暖かくなったら%sを脱いで調整できます。", strings.Join(names, "・")))
+	}
+	if needs.Umbrella {
+		parts = append(parts, "外出中に雨が降る可能性があるため、傘を持って出かけましょう。")
+	} else if needs.Rainy {
+		parts = append(parts, "雨や雪への対策も忘れずに。")
+	}
+	for _, waypoint := range needs.Waypoints {
+		parts = append(parts, waypointNote(needs, waypoint))
+	}
+	if needs.Windy {
+		parts = append(parts, "風が強いので防風性のある服装がおすすめです。")
 	}
+	if harmony := EvaluateColorHarmony(c.CoreItems()); harmony.Scheme == SchemeClash {
+		parts = append(parts, "手持ちのアイテムでは色同士がぶつかりやすい配色になっています。")
+	} else if harmony.Scheme != SchemeUnknown {
+		parts = append(parts, harmony.Description()+"です。")
+	}
+	if needs.HeatStress.AtLeast(entities.SeverityWarning) {
+		parts = append(parts, "熱中症に警戒が必要なため、通気性の良さと涼しさを優先しました。")
+	} else if needs.Humid {
+		parts = append(parts, "湿度が高く蒸し暑いため、通気性の良い素材を優先しました。")
+	}
+	if needs.ColdStress.AtLeast(entities.SeverityWarning) {
+		parts = append(parts, "風による冷え込みが厳しいため、防風性と肌の露出の少なさを優先しました。")
+	}
+	return strings.Join(parts, "")
 }
 
-func (s *FashionRecommendationService) getReasonForRecommendation(weather *entities.WeatherCondition, category string) string {
-	if weather.Temperature <= 10 {
-		return "寒い天気のため"
-	} else if weather.Temperature >= 25 {
-		return "暑い天気のため"
+// 立ち寄る地点ごとの気象条件の説明文
+func waypointNote(needs WeatherNeeds, waypoint entities.WaypointWeather) string {
+	note := fmt.Sprintf("【%s %s〜%s】体感温度%.0f〜%.0f℃・%s・降水確率%.0f%%",
+		waypoint.Name, waypoint.ArriveAt.Format("15:04"), waypoint.LeaveAt.Format("15:04"),
+		waypoint.MinFeelsLike, waypoint.MaxFeelsLike, waypoint.Condition.DisplayName(entities.LanguageJapanese),
+		waypoint.MaxPrecipitationProbability*100)
+	if waypoint.MaxWindSpeed >= WindSpeedThreshold {
+		note += fmt.Sprintf("・風速%.0fm/s", waypoint.MaxWindSpeed)
 	}
-	if weather.Condition == "雨" {
-		return "雨天のため"
+	if waypoint.MinFeelsLike-needs.ComfortOffset <= needs.FeelsLike {
+		note += "（最も冷え込むこの地点に合わせています）"
+	}
+	return note + "。"
+}
+
+// 完全なコーディネートを組めない場合の説明文
+func missingCategoriesReason(closet map[entities.ClothingCategory][]*entities.ClothingItem) string {
+	var missing []string
+	for _, category := range []entities.ClothingCategory{entities.CategoryTops, entities.CategoryBottoms, entities.CategoryShoes} {
+		if len(closet[category]) == 0 {
+			missing = append(missing, string(category))
+		}
 	}
-	return "今日の天気に適しているため"
+	return fmt.Sprintf("コーディネートを組むには%sの登録が必要です。", strings.Join(missing, "・"))
 }
 
 func (s *FashionRecommendationService) determineStyle(weather *entities.WeatherCondition) string {
-	if weather.Temperature <= 10 {
+	if weather.FeelsLike <= 10 {
 		return "warm"
-	} else if weather.Temperature >= 25 {
+	} else if weather.FeelsLike >= 25 {
 		return "cool"
 	}
 	return "casual"
-}
\ No newline at end of file
+}
+
+// 有効なカテゴリごとにアイテムを分類
+func groupByCategory(items []*entities.ClothingItem) map[entities.ClothingCategory][]*entities.ClothingItem {
+	grouped := make(map[entities.ClothingCategory][]*entities.ClothingItem)
+	for _, item := range items {
+		if !item.IsValidCategory() {
+			continue
+		}
+		category := entities.ClothingCategory(item.Category)
+		grouped[category] = append(grouped[category], item)
+	}
+	return grouped
+}
+
+// カテゴリごとに指定した季節に着用するアイテムに絞り込む（季節に合うアイテムがないカテゴリはそのまま残す）
+func preferInSeason(closet map[entities.ClothingCategory][]*entities.ClothingItem, season entities.Season) map[entities.ClothingCategory][]*entities.ClothingItem {
+	for category, items := range closet {
+		var inSeason []*entities.ClothingItem
+		for _, item := range items {
+			if item.InSeason(season) {
+				inSeason = append(inSeason, item)
+			}
+		}
+		if len(inSeason) > 0 {
+			closet[category] = inSeason
+		}
+	}
+	return closet
+}
+
+// スコアが最も高いアイテムを返す（同点の場合は先に登録されたもの）
+func bestItem(items []*entities.ClothingItem, score func(*entities.ClothingItem) float64) *entities.ClothingItem {
+	var best *entities.ClothingItem
+	bestScore := math.Inf(-1)
+	for _, item := range items {
+		if sc := score(item); sc > bestScore {
+			best, bestScore = item, sc
+		}
+	}
+	return best
+}
+
+// 条件に一致する最初のアイテムを返す
+func firstMatching(items []*entities.ClothingItem, match func(*entities.ClothingItem) bool) *entities.ClothingItem {
+	for _, item := range items {
+		if match(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// トップスの重ね方の候補（1枚、または保温レベルの低い順にベース・ミドルの2枚）
+func topLayers(tops []*entities.ClothingItem) [][]*entities.ClothingItem {
+	var layers [][]*entities.ClothingItem
+	for i, base := range tops {
+		layers = append(layers, []*entities.ClothingItem{base})
+		for _, mid := range tops[i+1:] {
+			if mid.Warmth() < base.Warmth() {
+				layers = append(layers, []*entities.ClothingItem{mid, base})
+			} else {
+				layers = append(layers, []*entities.ClothingItem{base, mid})
+			}
+		}
+	}
+	return layers
+}
+
+// 2つの候補が2点以上の基本アイテムで異なるかを判定
+func differsEnough(a, b *CandidateOutfit) bool {
+	shared := make(map[string]bool)
+	for _, id := range b.coreIDs() {
+		shared[id] = true
+	}
+	diff := 0
+	for _, id := range a.coreIDs() {
+		if !shared[id] {
+			diff++
+		}
+	}
+	return diff >= 2
+}
+
+// 小数第2位に丸める
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// スコア内訳を表示用に丸める
+func roundBreakdown(b entities.ScoreBreakdown) entities.ScoreBreakdown {
+	return entities.ScoreBreakdown{
+		ThermalFit:        round2(b.ThermalFit),
+		WeatherProtection: round2(b.WeatherProtection),
+		ColorHarmony:      round2(b.ColorHarmony),
+		UserPreference:    round2(b.UserPreference),
+		Recency:           round2(b.Recency),
+		Total:             round2(b.Total),
+	}
+}
+
+// 条件に一致するアイテムのみを返す
+func filter(items []*entities.ClothingItem, match func(*entities.ClothingItem) bool) []*entities.ClothingItem {
+	var filtered []*entities.ClothingItem
+	for _, item := range items {
+		if match(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
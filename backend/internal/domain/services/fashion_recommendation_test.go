@@ -0,0 +1,453 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// カテゴリごとに n 件ずつのアイテムを持つクローゼット
+func testCloset(n int) []*entities.ClothingItem {
+	colors := []string{"ネイビー", "white", "#c62828", "ベージュ", "black", "olive", "light blue", "burgundy", "tan", "グレー"}
+	categories := []entities.ClothingCategory{entities.CategoryTops, entities.CategoryBottoms, entities.CategoryShoes, entities.CategoryOuterwear}
+	var items []*entities.ClothingItem
+	for _, category := range categories {
+		for i := 0; i < n; i++ {
+			items = append(items, &entities.ClothingItem{
+				ID:          fmt.Sprintf("%s-%d", category, i),
+				UserID:      "user-1",
+				Name:        fmt.Sprintf("%s %d", category, i),
+				Type:        string(category),
+				Color:       colors[i%len(colors)],
+				Category:    string(category),
+				WarmthLevel: 1 + i%5,
+			})
+		}
+	}
+	return items
+}
+
+func testWeather(feelsLike float64) *entities.WeatherCondition {
+	return &entities.WeatherCondition{Temperature: feelsLike, FeelsLike: feelsLike, Humidity: 50, WindSpeed: 2, Condition: entities.ConditionClouds}
+}
+
+func TestCachedColorsScoreLikeParsedColors(t *testing.T) {
+	items := testCloset(10)
+	preferences := &entities.UserPreferences{PreferredColors: []string{"紺", "white"}, PreferredBrands: []string{"acme"}}
+	cached := &ScoringContext{Needs: NewWeatherNeeds(testWeather(12), 0), Preferences: preferences, Now: time.Now()}
+	cached.cacheColors(items)
+	uncached := &ScoringContext{Needs: cached.Needs, Preferences: preferences, Now: cached.Now}
+
+	closet := groupByCategory(items)
+	for _, strategy := range []RecommendationStrategy{RuleBasedStrategy{}, NewRecommendationStrategy(StrategyWeighted)} {
+		for _, top := range closet[entities.CategoryTops] {
+			for _, bottom := range closet[entities.CategoryBottoms] {
+				candidate := &CandidateOutfit{Tops: []*entities.ClothingItem{top}, Bottom: bottom, Shoes: closet[entities.CategoryShoes][0]}
+				if got, want := strategy.Score(cached, candidate), strategy.Score(uncached, candidate); got != want {
+					t.Fatalf("%s: cached score %+v, want %+v", strategy.Name(), got, want)
+				}
+				if got, want := evaluateColorHarmony(candidate.CoreItems(), cached.itemColor), EvaluateColorHarmony(candidate.CoreItems()); got != want {
+					t.Fatalf("cached harmony %+v, want %+v", got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateRecommendationLargeCloset(t *testing.T) {
+	service := NewFashionRecommendationService()
+	recommendation := service.GenerateRecommendation(RecommendationInput{Weather: testWeather(8), Clothing: testCloset(30), Now: time.Now()}, nil)
+
+	if len(recommendation.Outfits) != MaxOutfitAlternatives {
+		t.Fatalf("outfits = %d, want %d", len(recommendation.Outfits), MaxOutfitAlternatives)
+	}
+	for i := 1; i < len(recommendation.Outfits); i++ {
+		if recommendation.Outfits[i].Score > recommendation.Outfits[i-1].Score {
+			t.Errorf("outfit %d scores higher than outfit %d", i+1, i)
+		}
+	}
+}
+
+func BenchmarkGenerateRecommendation(b *testing.B) {
+	service := NewFashionRecommendationService()
+	input := RecommendationInput{
+		Weather:     testWeather(8),
+		Clothing:    testCloset(30),
+		Preferences: &entities.UserPreferences{PreferredColors: []string{"紺"}},
+		Now:         time.Now(),
+	}
+	for _, strategy := range []RecommendationStrategy{RuleBasedStrategy{}, NewRecommendationStrategy(StrategyWeighted)} {
+		b.Run(strategy.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.GenerateRecommendation(input, strategy)
+			}
+		})
+	}
+}
+
+func TestGenerateRecommendationPrefersInSeasonItems(t *testing.T) {
+	items := []*entities.ClothingItem{
+		{ID: "winter-top", Name: "Wool Sweater", Category: string(entities.CategoryTops), Color: "gray", WarmthLevel: 2, Seasons: []entities.Season{entities.SeasonWinter}},
+		{ID: "summer-top", Name: "Linen Shirt", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 2, Seasons: []entities.Season{entities.SeasonSummer}},
+		{ID: "winter-bottom", Name: "Corduroy Pants", Category: string(entities.CategoryBottoms), Color: "brown", WarmthLevel: 2, Seasons: []entities.Season{entities.SeasonWinter}},
+		{ID: "shoes", Name: "Sneakers", Category: string(entities.CategoryShoes), Color: "white", WarmthLevel: 1},
+	}
+	service := NewFashionRecommendationService()
+	july := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	recommendation := service.GenerateRecommendation(RecommendationInput{Weather: testWeather(20), Clothing: items, Now: july}, nil)
+	if len(recommendation.Outfits) == 0 {
+		t.Fatalf("no outfits: %s", recommendation.Reason)
+	}
+	for _, outfit := range recommendation.Outfits {
+		for _, item := range outfit.Items {
+			if item.ClothingID == "winter-top" {
+				t.Errorf("outfit %d includes the winter-only top in July", outfit.Rank)
+			}
+		}
+	}
+	// 季節に合うボトムスがない場合は季節外のアイテムでもコーディネートを組む
+	if !containsItem(recommendation.Items, "winter-bottom") {
+		t.Errorf("items = %+v, want the only bottom even though it is out of season", recommendation.Items)
+	}
+
+	// 外出時間帯を指定した場合は出発日の季節で判定する
+	dayPlan := &entities.DayPlan{
+		DepartureAt:  time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC),
+		ReturnAt:     time.Date(2024, 1, 10, 18, 0, 0, 0, time.UTC),
+		MinFeelsLike: 20,
+		MaxFeelsLike: 20,
+		Hourly:       []entities.WeatherCondition{*testWeather(20)},
+	}
+	recommendation = service.GenerateRecommendation(RecommendationInput{Weather: testWeather(20), DayPlan: dayPlan, Clothing: items, Now: july}, nil)
+	if containsItem(recommendation.Items, "summer-top") {
+		t.Errorf("items = %+v, want no summer-only top for a January outing", recommendation.Items)
+	}
+}
+
+func TestFormalityFit(t *testing.T) {
+	formal := &entities.ClothingItem{Formality: 5}
+	casual := &entities.ClothingItem{Formality: 1}
+	unrated := &entities.ClothingItem{}
+
+	tests := []struct {
+		name        string
+		preferences *entities.UserPreferences
+		items       []*entities.ClothingItem
+		want        float64
+		wantOK      bool
+	}{
+		{"no preferences", nil, []*entities.ClothingItem{formal}, 0, false},
+		{"no style", &entities.UserPreferences{}, []*entities.ClothingItem{formal}, 0, false},
+		{"formal style and formal items", &entities.UserPreferences{Style: "formal"}, []*entities.ClothingItem{formal, unrated}, 1, true},
+		{"formal style and casual items", &entities.UserPreferences{Style: "formal"}, []*entities.ClothingItem{casual}, 0, true},
+		{"mixed items", &entities.UserPreferences{Styles: []string{"カジュアル"}}, []*entities.ClothingItem{formal, casual}, 0.5, true},
+		{"unrated items", &entities.UserPreferences{Style: "casual"}, []*entities.ClothingItem{unrated}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := formalityFit(tt.preferences, tt.items)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("%s: formalityFit = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFormalStylePrefersFormalItems(t *testing.T) {
+	tee := &entities.ClothingItem{ID: "tee", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 2, Formality: 1}
+	shirt := &entities.ClothingItem{ID: "shirt", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 2, Formality: 5}
+	slacks := &entities.ClothingItem{ID: "slacks", Category: string(entities.CategoryBottoms), Color: "navy", WarmthLevel: 2, Formality: 5}
+	loafers := &entities.ClothingItem{ID: "loafers", Category: string(entities.CategoryShoes), Color: "black", WarmthLevel: 1, Formality: 5}
+
+	for _, style := range []string{"formal", "casual"} {
+		ctx := &ScoringContext{Needs: NewWeatherNeeds(testWeather(22), 0), Preferences: &entities.UserPreferences{Style: style}, Now: time.Now()}
+		for _, strategy := range []RecommendationStrategy{RuleBasedStrategy{}, NewRecommendationStrategy(StrategyWeighted)} {
+			withTee := strategy.Score(ctx, &CandidateOutfit{Tops: []*entities.ClothingItem{tee}, Bottom: slacks, Shoes: loafers})
+			withShirt := strategy.Score(ctx, &CandidateOutfit{Tops: []*entities.ClothingItem{shirt}, Bottom: slacks, Shoes: loafers})
+			if style == "formal" && withShirt.Total <= withTee.Total {
+				t.Errorf("%s/%s: shirt %v <= tee %v, want the dress shirt to score higher", style, strategy.Name(), withShirt.Total, withTee.Total)
+			}
+			if style == "casual" && withTee.Total <= withShirt.Total {
+				t.Errorf("%s/%s: tee %v <= shirt %v, want the tee to score higher", style, strategy.Name(), withTee.Total, withShirt.Total)
+			}
+		}
+	}
+}
+
+func containsItem(items []entities.RecommendedItem, id string) bool {
+	for _, item := range items {
+		if item.ClothingID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTargetWarmth(t *testing.T) {
+	tests := []struct {
+		feelsLike float64
+		want      int
+	}{
+		{40, minTargetWarmth},
+		{30, 4},
+		{0, 25},
+		{-40, maxTargetWarmth},
+	}
+	for _, test := range tests {
+		if got := TargetWarmth(test.feelsLike); got != test.want {
+			t.Errorf("TargetWarmth(%v) = %d, want %d", test.feelsLike, got, test.want)
+		}
+	}
+	// 寒いほど目標保温レベルは下がらない
+	for feelsLike := 35.0; feelsLike > -35; feelsLike-- {
+		if TargetWarmth(feelsLike-1) < TargetWarmth(feelsLike) {
+			t.Fatalf("TargetWarmth(%v) < TargetWarmth(%v)", feelsLike-1, feelsLike)
+		}
+	}
+}
+
+// 薄手と厚手のアイテムを1点ずつ持つクローゼット（保温レベル未設定のアイテムと無効なカテゴリのアイテムを含む）
+func warmthCloset() []*entities.ClothingItem {
+	return []*entities.ClothingItem{
+		{ID: "tee", Name: "Tシャツ", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 1},
+		{ID: "sweater", Name: "セーター", Category: string(entities.CategoryTops), Color: "gray", WarmthLevel: 7},
+		{ID: "shorts", Name: "ショーツ", Category: string(entities.CategoryBottoms), Color: "beige", WarmthLevel: 1},
+		{ID: "wool-pants", Name: "ウールパンツ", Category: string(entities.CategoryBottoms), Color: "navy", WarmthLevel: 6},
+		{ID: "sandals", Name: "サンダル", Category: string(entities.CategoryShoes), Color: "brown", WarmthLevel: 1},
+		{ID: "boots", Name: "ブーツ", Category: string(entities.CategoryShoes), Color: "black", WarmthLevel: 4},
+		{ID: "coat", Name: "コート", Category: string(entities.CategoryOuterwear), Color: "black"},
+		{ID: "unknown", Name: "謎のアイテム", Category: "その他", Color: "red", WarmthLevel: 5},
+	}
+}
+
+func TestGenerateRecommendationMatchesWarmthToWeather(t *testing.T) {
+	service := NewFashionRecommendationService()
+	now := time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC)
+
+	hot := service.GenerateRecommendation(RecommendationInput{Weather: testWeather(30), Clothing: warmthCloset(), Now: now}, nil)
+	for _, id := range []string{"tee", "shorts", "sandals"} {
+		if !containsItem(hot.Items, id) {
+			t.Errorf("hot items = %+v, want %s", hot.Items, id)
+		}
+	}
+	if containsItem(hot.Items, "coat") {
+		t.Errorf("hot items = %+v, want no coat", hot.Items)
+	}
+
+	cold := service.GenerateRecommendation(RecommendationInput{Weather: testWeather(0), Clothing: warmthCloset(), Now: now}, nil)
+	for _, id := range []string{"sweater", "wool-pants", "boots", "coat"} {
+		if !containsItem(cold.Items, id) {
+			t.Errorf("cold items = %+v, want %s", cold.Items, id)
+		}
+	}
+	if cold.Outfits[0].TargetWarmth != TargetWarmth(0) || cold.Outfits[0].TotalWarmth <= hot.Outfits[0].TotalWarmth {
+		t.Errorf("cold outfit warmth %d/%d, hot outfit warmth %d, want a warmer outfit for the cold", cold.Outfits[0].TotalWarmth, cold.Outfits[0].TargetWarmth, hot.Outfits[0].TotalWarmth)
+	}
+
+	// 保温レベル未設定のアイテムはカテゴリの標準値で計算し、無効なカテゴリのアイテムは使わない
+	for _, recommendation := range []*entities.FashionRecommendation{hot, cold} {
+		for _, outfit := range recommendation.Outfits {
+			if containsItem(outfit.Items, "unknown") {
+				t.Errorf("outfit %d includes an item with an invalid category", outfit.Rank)
+			}
+			for _, item := range outfit.Items {
+				if item.ClothingID == "coat" && item.WarmthLevel != 5 {
+					t.Errorf("coat warmth = %d, want the outerwear default 5", item.WarmthLevel)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateRecommendationSlotStructure(t *testing.T) {
+	service := NewFashionRecommendationService()
+	recommendation := service.GenerateRecommendation(RecommendationInput{Weather: testWeather(5), Clothing: testCloset(5), Now: time.Now()}, nil)
+	if len(recommendation.Outfits) != MaxOutfitAlternatives {
+		t.Fatalf("outfits = %d, want %d", len(recommendation.Outfits), MaxOutfitAlternatives)
+	}
+
+	coreIDs := make([]map[string]bool, len(recommendation.Outfits))
+	for i, outfit := range recommendation.Outfits {
+		slots := make(map[entities.OutfitSlot]int)
+		coreIDs[i] = make(map[string]bool)
+		warmth := make(map[entities.OutfitSlot]int)
+		for _, item := range outfit.Items {
+			slots[item.Slot]++
+			warmth[item.Slot] = item.WarmthLevel
+			if item.Slot != entities.SlotAccessory {
+				coreIDs[i][item.ClothingID] = true
+			}
+		}
+		if slots[entities.SlotBaseLayer] != 1 || slots[entities.SlotBottom] != 1 || slots[entities.SlotShoes] != 1 ||
+			slots[entities.SlotMidLayer] > 1 || slots[entities.SlotOuterwear] > 1 {
+			t.Errorf("outfit %d slots = %v, want one item per slot", outfit.Rank, slots)
+		}
+		// 重ね着では保温レベルの低いものを内側に着る
+		if slots[entities.SlotMidLayer] == 1 && warmth[entities.SlotMidLayer] < warmth[entities.SlotBaseLayer] {
+			t.Errorf("outfit %d mid layer warmth %d < base layer %d", outfit.Rank, warmth[entities.SlotMidLayer], warmth[entities.SlotBaseLayer])
+		}
+	}
+
+	// 候補同士は基本アイテムが2点以上異なる
+	for i := range coreIDs {
+		for j := i + 1; j < len(coreIDs); j++ {
+			diff := 0
+			for id := range coreIDs[j] {
+				if !coreIDs[i][id] {
+					diff++
+				}
+			}
+			if diff < 2 {
+				t.Errorf("outfits %d and %d differ in %d items, want at least 2", i+1, j+1, diff)
+			}
+		}
+	}
+}
+
+func TestTopLayers(t *testing.T) {
+	warm := &entities.ClothingItem{ID: "warm", Category: string(entities.CategoryTops), WarmthLevel: 5}
+	light := &entities.ClothingItem{ID: "light", Category: string(entities.CategoryTops), WarmthLevel: 2}
+
+	var got []string
+	for _, layers := range topLayers([]*entities.ClothingItem{warm, light}) {
+		var ids []string
+		for _, top := range layers {
+			ids = append(ids, top.ID)
+		}
+		got = append(got, strings.Join(ids, "+"))
+	}
+	if want := "warm,light+warm,light"; strings.Join(got, ",") != want {
+		t.Errorf("topLayers = %v, want %s", got, want)
+	}
+}
+
+func TestRemovableLayers(t *testing.T) {
+	base := &entities.ClothingItem{ID: "base", Category: string(entities.CategoryTops), WarmthLevel: 2}
+	mid := &entities.ClothingItem{ID: "mid", Category: string(entities.CategoryTops), WarmthLevel: 4}
+	bottom := &entities.ClothingItem{ID: "bottom", Category: string(entities.CategoryBottoms), WarmthLevel: 3}
+	shoes := &entities.ClothingItem{ID: "shoes", Category: string(entities.CategoryShoes), WarmthLevel: 2}
+	outer := &entities.ClothingItem{ID: "outer", Category: string(entities.CategoryOuterwear), WarmthLevel: 5}
+	outfit := &CandidateOutfit{Tops: []*entities.ClothingItem{base, mid}, Bottom: bottom, Shoes: shoes, Outerwear: outer}
+
+	tests := []struct {
+		mildTarget int
+		want       string
+	}{
+		{16, ""},
+		{12, "outer"},
+		{6, "outer,mid"},
+	}
+	for _, test := range tests {
+		needs := WeatherNeeds{TargetWarmth: 16, MildTargetWarmth: test.mildTarget}
+		var ids []string
+		for _, layer := range outfit.removableLayers(needs) {
+			ids = append(ids, layer.ID)
+		}
+		if got := strings.Join(ids, ","); got != test.want {
+			t.Errorf("mild target %d: removable = %q, want %q", test.mildTarget, got, test.want)
+		}
+	}
+}
+
+func TestGenerateRecommendationMissingCategories(t *testing.T) {
+	items := []*entities.ClothingItem{
+		{ID: "tee", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 1},
+		{ID: "coat", Category: string(entities.CategoryOuterwear), Color: "black", WarmthLevel: 6},
+	}
+	recommendation := NewFashionRecommendationService().GenerateRecommendation(RecommendationInput{Weather: testWeather(15), Clothing: items, Now: time.Now()}, nil)
+	if len(recommendation.Outfits) != 0 || len(recommendation.Items) != 0 {
+		t.Fatalf("outfits = %+v, want none without bottoms and shoes", recommendation.Outfits)
+	}
+	want := "コーディネートを組むには" + string(entities.CategoryBottoms) + "・" + string(entities.CategoryShoes) + "の登録が必要です。"
+	if recommendation.Reason != want {
+		t.Errorf("reason = %q, want %q", recommendation.Reason, want)
+	}
+}
+
+func TestRecency(t *testing.T) {
+	now := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+	fresh := &entities.ClothingItem{ID: "fresh"}
+	yesterday := &entities.ClothingItem{ID: "yesterday"}
+	weekAgo := &entities.ClothingItem{ID: "week-ago"}
+	lastWorn := map[string]time.Time{
+		"yesterday": now.Add(-24 * time.Hour),
+		"week-ago":  now.Add(-recencyWindow / 2),
+	}
+
+	tests := []struct {
+		items []*entities.ClothingItem
+		want  float64
+	}{
+		{nil, 1},
+		{[]*entities.ClothingItem{fresh}, 1},
+		{[]*entities.ClothingItem{weekAgo}, 0.5},
+		{[]*entities.ClothingItem{fresh, weekAgo}, 0.75},
+	}
+	for _, test := range tests {
+		if got := recency(lastWorn, now, test.items); got != test.want {
+			t.Errorf("recency(%d items) = %v, want %v", len(test.items), got, test.want)
+		}
+	}
+	if got := recency(lastWorn, now, []*entities.ClothingItem{yesterday}); got <= 0 || got >= 0.5 {
+		t.Errorf("recency(yesterday) = %v, want between 0 and 0.5", got)
+	}
+}
+
+func TestRuleBasedStrategyRotatesRecentlyWornJacket(t *testing.T) {
+	now := time.Date(2024, 10, 15, 8, 0, 0, 0, time.UTC)
+	items := []*entities.ClothingItem{
+		{ID: "shirt", Category: string(entities.CategoryTops), Color: "white", WarmthLevel: 3},
+		{ID: "chinos", Category: string(entities.CategoryBottoms), Color: "beige", WarmthLevel: 3},
+		{ID: "sneakers", Category: string(entities.CategoryShoes), Color: "white", WarmthLevel: 2},
+		{ID: "favorite-jacket", Category: string(entities.CategoryOuterwear), Color: "navy", WarmthLevel: 5},
+		{ID: "other-jacket", Category: string(entities.CategoryOuterwear), Color: "navy", WarmthLevel: 4},
+	}
+	// 体感温度17℃の目標保温レベル13にちょうど合うのは favorite-jacket
+	weather := testWeather(17)
+	if TargetWarmth(17) != 13 {
+		t.Fatalf("TargetWarmth(17) = %d, want 13", TargetWarmth(17))
+	}
+	outerwear := func(lastWorn map[string]time.Time) string {
+		recommendation := NewFashionRecommendationService().GenerateRecommendation(RecommendationInput{Weather: weather, Clothing: items, LastWorn: lastWorn, Now: now}, RuleBasedStrategy{})
+		for _, item := range recommendation.Items {
+			if item.Slot == entities.SlotOuterwear {
+				return item.ClothingID
+			}
+		}
+		return ""
+	}
+
+	if got := outerwear(nil); got != "favorite-jacket" {
+		t.Fatalf("outerwear without wear history = %q, want favorite-jacket", got)
+	}
+	// 前日に着たジャケットは、保温レベルが1違っても別のジャケットに入れ替える
+	yesterday := map[string]time.Time{"favorite-jacket": entities.WearDate(now.AddDate(0, 0, -1))}
+	if got := outerwear(yesterday); got != "other-jacket" {
+		t.Errorf("outerwear the day after wearing favorite-jacket = %q, want other-jacket", got)
+	}
+	// 十分前に着たジャケットは減点しない
+	longAgo := map[string]time.Time{"favorite-jacket": now.Add(-recencyWindow)}
+	if got := outerwear(longAgo); got != "favorite-jacket" {
+		t.Errorf("outerwear %v after wearing favorite-jacket = %q, want favorite-jacket", recencyWindow, got)
+	}
+}
+
+func TestRecentWearPenalty(t *testing.T) {
+	now := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+	top := &entities.ClothingItem{ID: "top", Category: string(entities.CategoryTops)}
+	jacket := &entities.ClothingItem{ID: "jacket", Category: string(entities.CategoryOuterwear)}
+	justWorn := map[string]time.Time{"top": now, "jacket": now}
+
+	if got := recentWearPenalty(justWorn, now, []*entities.ClothingItem{top}); got != recentWearWeight {
+		t.Errorf("penalty for a top worn just now = %v, want %v", got, recentWearWeight)
+	}
+	// 1点ずつ減点するため、着たアイテムが多いほど大きくなり、アウターは重い
+	if got := recentWearPenalty(justWorn, now, []*entities.ClothingItem{top, jacket}); got != recentWearWeight+recentOuterwearWeight {
+		t.Errorf("penalty for a top and a jacket = %v, want %v", got, recentWearWeight+recentOuterwearWeight)
+	}
+	if got := recentWearPenalty(nil, now, []*entities.ClothingItem{top, jacket}); got != 0 {
+		t.Errorf("penalty without wear history = %v, want 0", got)
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"mime"
+	"strings"
+)
+
+// 画像のアップロードに関するエラー
+var (
+	// ErrUnsupportedImageType JPEG・PNG 以外の形式、または宣言された形式と内容が一致しない場合のエラー
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+
+	// ErrImageTooLarge ファイルサイズまたは画素数が上限を超える場合のエラー
+	ErrImageTooLarge = errors.New("image too large")
+
+	// ErrInvalidImage 画像として読み込めない場合のエラー
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// 画像処理の設定
+type ImageOptions struct {
+	// MaxBytes アップロードできるファイルサイズの上限（バイト）
+	MaxBytes int64
+
+	// MaxPixels 読み込む画像の画素数の上限（展開後のメモリ使用量を抑える）
+	MaxPixels int
+
+	// ThumbnailSize サムネイルの長辺の最大ピクセル数
+	ThumbnailSize int
+
+	// JPEGQuality JPEG で再保存する際の品質（1〜100）
+	JPEGQuality int
+}
+
+// 画像処理のデフォルト設定
+var DefaultImageOptions = ImageOptions{
+	MaxBytes:      10 << 20,
+	MaxPixels:     40_000_000,
+	ThumbnailSize: 320,
+	JPEGQuality:   85,
+}
+
+// 受け付ける画像形式
+type imageFormat struct {
+	contentType string
+	extension   string
+	magic       []byte
+}
+
+var imageFormats = []imageFormat{
+	{contentType: "image/jpeg", extension: ".jpg", magic: []byte{0xFF, 0xD8, 0xFF}},
+	{contentType: "image/png", extension: ".png", magic: []byte("\x89PNG\r\n\x1a\n")},
+}
+
+// 保存用に処理した画像
+type ProcessedImage struct {
+	// Data メタデータ（EXIF の位置情報など）を取り除いて再保存した画像
+	Data []byte
+
+	// Thumbnail 長辺を ThumbnailSize 以下に縮小した画像（元画像が小さい場合は同じ大きさ）
+	Thumbnail []byte
+
+	// ContentType 画像とサムネイルの形式（image/jpeg または image/png）
+	ContentType string
+
+	// Extension 形式に対応する拡張子（".jpg" など）
+	Extension string
+
+	Width  int
+	Height int
+
+	// Colors 画像の主要な色（割合の大きい順、背景とみなした色は除く）
+	Colors []DominantColor
+}
+
+// アップロードされた画像を検証し、保存用の画像とサムネイルを生成するドメインサービス
+// 画像は EXIF の向きを反映したうえで再エンコードするため、位置情報を含むメタデータは全て取り除かれる
+type ImageService struct {
+	options ImageOptions
+}
+
+func NewImageService(options ImageOptions) *ImageService {
+	if options.MaxBytes <= 0 {
+		options.MaxBytes = DefaultImageOptions.MaxBytes
+	}
+	if options.MaxPixels <= 0 {
+		options.MaxPixels = DefaultImageOptions.MaxPixels
+	}
+	if options.ThumbnailSize <= 0 {
+		options.ThumbnailSize = DefaultImageOptions.ThumbnailSize
+	}
+	if options.JPEGQuality <= 0 || options.JPEGQuality > 100 {
+		options.JPEGQuality = DefaultImageOptions.JPEGQuality
+	}
+	return &ImageService{options: options}
+}
+
+// アップロードできるファイルサイズの上限（バイト）
+func (s *ImageService) MaxBytes() int64 {
+	return s.options.MaxBytes
+}
+
+// 画像を検証して保存用の画像とサムネイルを生成
+// declaredType はクライアントが宣言した Content-Type（空の場合は内容から判定した形式を使用）
+func (s *ImageService) Process(data []byte, declaredType string) (*ProcessedImage, error) {
+	if int64(len(data)) > s.options.MaxBytes {
+		return nil, fmt.Errorf("%w: ファイルサイズは%dMB以下にしてください", ErrImageTooLarge, s.options.MaxBytes>>20)
+	}
+
+	format, err := detectImageFormat(data, declaredType)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width*config.Height > s.options.MaxPixels {
+		return nil, fmt.Errorf("%w: 画素数は%d以下にしてください", ErrImageTooLarge, s.options.MaxPixels)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	img := toRGBA(decoded)
+	if format.contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+
+	processed := &ProcessedImage{
+		ContentType: format.contentType,
+		Extension:   format.extension,
+		Width:       width,
+		Height:      height,
+	}
+	if processed.Data, err = s.encode(img, format); err != nil {
+		return nil, err
+	}
+
+	thumbWidth, thumbHeight := fitWithin(width, height, s.options.ThumbnailSize)
+	thumbnail := resizeRGBA(img, thumbWidth, thumbHeight)
+	if processed.Thumbnail, err = s.encode(thumbnail, format); err != nil {
+		return nil, err
+	}
+
+	// 色の抽出は縮小済みのサムネイルで十分な精度が得られる
+	processed.Colors = ExtractDominantColors(thumbnail, DefaultColorClusters)
+	return processed, nil
+}
+
+// 画像を元の形式で再エンコード（メタデータは引き継がれない）
+func (s *ImageService) encode(img image.Image, format imageFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format.contentType == "image/png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: s.options.JPEGQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("画像の保存に失敗しました: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// 内容（マジックナンバー）から画像形式を判定し、宣言された Content-Type と一致するかを確認
+func detectImageFormat(data []byte, declaredType string) (imageFormat, error) {
+	var detected *imageFormat
+	for i := range imageFormats {
+		if bytes.HasPrefix(data, imageFormats[i].magic) {
+			detected = &imageFormats[i]
+			break
+		}
+	}
+	if detected == nil {
+		return imageFormat{}, fmt.Errorf("%w: JPEG または PNG の画像を指定してください", ErrUnsupportedImageType)
+	}
+
+	if declaredType != "" {
+		mediaType, _, err := mime.ParseMediaType(declaredType)
+		if err != nil {
+			return imageFormat{}, fmt.Errorf("%w: %s", ErrUnsupportedImageType, declaredType)
+		}
+		mediaType = strings.ToLower(mediaType)
+		if mediaType == "image/jpg" || mediaType == "image/pjpeg" {
+			mediaType = "image/jpeg"
+		}
+		if mediaType != detected.contentType {
+			return imageFormat{}, fmt.Errorf("%w: 宣言された形式（%s）と画像の内容が一致しません", ErrUnsupportedImageType, declaredType)
+		}
+	}
+	return *detected, nil
+}
+
+// 縦横比を保って長辺を limit 以下に収めた大きさ（拡大はしない）
+func fitWithin(width, height, limit int) (int, int) {
+	longest := width
+	if height > longest {
+		longest = height
+	}
+	if longest <= limit {
+		return width, height
+	}
+	w := (width*limit + longest/2) / longest
+	h := (height*limit + longest/2) / longest
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return w, h
+}
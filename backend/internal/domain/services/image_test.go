@@ -0,0 +1,238 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// w×h の画像（左上の1画素だけ赤、それ以外は白）
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// JPEG の SOI 直後に Orientation と GPS 情報を含む EXIF（APP1）セグメントを挿入
+func withEXIF(jpegData []byte, orientation uint16) []byte {
+	order := binary.LittleEndian
+	tiff := []byte("II*\x00")
+	tiff = order.AppendUint32(tiff, 8)
+
+	// IFD0: Orientation と GPS IFD へのポインタ
+	tiff = order.AppendUint16(tiff, 2)
+	tiff = order.AppendUint16(tiff, 0x0112)
+	tiff = order.AppendUint16(tiff, 3)
+	tiff = order.AppendUint32(tiff, 1)
+	tiff = order.AppendUint16(tiff, orientation)
+	tiff = order.AppendUint16(tiff, 0)
+	gpsOffset := uint32(8 + 2 + 2*12 + 4)
+	tiff = order.AppendUint16(tiff, 0x8825)
+	tiff = order.AppendUint16(tiff, 4)
+	tiff = order.AppendUint32(tiff, 1)
+	tiff = order.AppendUint32(tiff, gpsOffset)
+	tiff = order.AppendUint32(tiff, 0)
+
+	// GPS IFD: GPSLatitudeRef = "N"
+	tiff = order.AppendUint16(tiff, 1)
+	tiff = order.AppendUint16(tiff, 0x0001)
+	tiff = order.AppendUint16(tiff, 2)
+	tiff = order.AppendUint32(tiff, 2)
+	tiff = append(tiff, 'N', 0, 0, 0)
+	tiff = order.AppendUint32(tiff, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, app1...)
+	return append(out, jpegData[2:]...)
+}
+
+func TestImageServiceProcessPNG(t *testing.T) {
+	service := NewImageService(ImageOptions{ThumbnailSize: 10})
+	processed, err := service.Process(encodePNG(t, testImage(40, 20)), "image/png")
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if processed.ContentType != "image/png" || processed.Extension != ".png" {
+		t.Errorf("format = %s %s, want image/png .png", processed.ContentType, processed.Extension)
+	}
+	if processed.Width != 40 || processed.Height != 20 {
+		t.Errorf("size = %dx%d, want 40x20", processed.Width, processed.Height)
+	}
+
+	thumb, err := png.Decode(bytes.NewReader(processed.Thumbnail))
+	if err != nil {
+		t.Fatalf("decode thumbnail: %v", err)
+	}
+	if got := thumb.Bounds().Size(); got != image.Pt(10, 5) {
+		t.Errorf("thumbnail size = %v, want (10,5)", got)
+	}
+}
+
+func TestImageServiceProcessRejectsMismatchedContentType(t *testing.T) {
+	service := NewImageService(ImageOptions{})
+	pngData := encodePNG(t, testImage(4, 4))
+	jpegData := encodeJPEG(t, testImage(4, 4))
+
+	tests := []struct {
+		name         string
+		data         []byte
+		declaredType string
+	}{
+		{"png declared as jpeg", pngData, "image/jpeg"},
+		{"jpeg declared as png", jpegData, "image/png"},
+		{"gif bytes", []byte("GIF89a\x01\x00\x01\x00"), "image/gif"},
+		{"html declared as png", []byte("<html><script></script></html>"), "image/png"},
+		{"invalid declared type", pngData, "image/png; ="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Process(tt.data, tt.declaredType); !errors.Is(err, ErrUnsupportedImageType) {
+				t.Errorf("Process error = %v, want ErrUnsupportedImageType", err)
+			}
+		})
+	}
+
+	for _, declared := range []string{"", "image/jpg", "IMAGE/JPEG", "image/pjpeg"} {
+		if _, err := service.Process(jpegData, declared); err != nil {
+			t.Errorf("Process(jpeg, %q) error = %v, want nil", declared, err)
+		}
+	}
+}
+
+func TestImageServiceProcessLimits(t *testing.T) {
+	data := encodePNG(t, testImage(20, 20))
+
+	sizeLimited := NewImageService(ImageOptions{MaxBytes: int64(len(data) - 1)})
+	if _, err := sizeLimited.Process(data, "image/png"); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("Process over MaxBytes error = %v, want ErrImageTooLarge", err)
+	}
+
+	pixelLimited := NewImageService(ImageOptions{MaxPixels: 20*20 - 1})
+	if _, err := pixelLimited.Process(data, "image/png"); !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("Process over MaxPixels error = %v, want ErrImageTooLarge", err)
+	}
+
+	exact := NewImageService(ImageOptions{MaxBytes: int64(len(data)), MaxPixels: 20 * 20})
+	if _, err := exact.Process(data, "image/png"); err != nil {
+		t.Errorf("Process at the limits error = %v, want nil", err)
+	}
+}
+
+func TestImageServiceProcessRejectsCorruptImage(t *testing.T) {
+	data := encodePNG(t, testImage(8, 8))
+	truncated := data[:len(data)/2]
+	if _, err := NewImageService(ImageOptions{}).Process(truncated, "image/png"); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("Process(truncated) error = %v, want ErrInvalidImage", err)
+	}
+}
+
+func TestImageServiceProcessAppliesAndStripsEXIF(t *testing.T) {
+	// 40×20 の画像（左半分が赤、右半分が白）
+	src := testImage(40, 20)
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			src.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	original := withEXIF(encodeJPEG(t, src), 6)
+	if got := jpegOrientation(original); got != 6 {
+		t.Fatalf("test image orientation = %d, want 6", got)
+	}
+
+	processed, err := NewImageService(ImageOptions{}).Process(original, "image/jpeg")
+	if err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	// 時計回りに90度回転した向きで保存される
+	if processed.Width != 20 || processed.Height != 40 {
+		t.Errorf("size = %dx%d, want 20x40", processed.Width, processed.Height)
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(processed.Data))
+	if err != nil {
+		t.Fatalf("decode processed image: %v", err)
+	}
+	if got := decoded.Bounds().Size(); got != image.Pt(20, 40) {
+		t.Errorf("decoded size = %v, want (20,40)", got)
+	}
+	// 時計回りに回転すると左半分の赤が上半分になる
+	if r, g, _, _ := decoded.At(10, 5).RGBA(); r>>8 < 200 || g>>8 > 60 {
+		t.Errorf("pixel in the top half = (%d,%d), want red", r>>8, g>>8)
+	}
+	if r, g, _, _ := decoded.At(10, 35).RGBA(); r>>8 < 200 || g>>8 < 200 {
+		t.Errorf("pixel in the bottom half = (%d,%d), want white", r>>8, g>>8)
+	}
+
+	for name, data := range map[string][]byte{"image": processed.Data, "thumbnail": processed.Thumbnail} {
+		if bytes.Contains(data, []byte("Exif\x00\x00")) {
+			t.Errorf("%s still contains EXIF data", name)
+		}
+		if jpegOrientation(data) != 1 {
+			t.Errorf("%s orientation = %d, want 1", name, jpegOrientation(data))
+		}
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 2×1 の画像（左が赤、右が青）
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		red         image.Point
+	}{
+		{1, image.Pt(2, 1), image.Pt(0, 0)},
+		{2, image.Pt(2, 1), image.Pt(1, 0)},
+		{3, image.Pt(2, 1), image.Pt(1, 0)},
+		{6, image.Pt(1, 2), image.Pt(0, 0)},
+		{8, image.Pt(1, 2), image.Pt(0, 1)},
+	}
+	for _, tt := range tests {
+		dst := applyOrientation(src, tt.orientation)
+		if got := dst.Bounds().Size(); got != tt.size {
+			t.Errorf("orientation %d: size = %v, want %v", tt.orientation, got, tt.size)
+			continue
+		}
+		if got := dst.RGBAAt(tt.red.X, tt.red.Y); got != red {
+			t.Errorf("orientation %d: pixel at %v = %v, want red", tt.orientation, tt.red, got)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 推奨戦略名の定義（RECOMMENDATION_STRATEGY 環境変数やユーザー設定で指定）
+const (
+	StrategyRules    = "rules"
+	StrategyWeighted = "weighted"
+)
+
+// コーディネート候補を採点する推奨戦略
+// 戦略を差し替えることで、推奨ロジックの改善を切り替え可能な形で導入・比較できる
+type RecommendationStrategy interface {
+	// Name 戦略名（推奨結果に記録される）
+	Name() string
+
+	// Score コーディネート候補を採点し、内訳と総合スコアを返します
+	Score(ctx *ScoringContext, outfit *CandidateOutfit) entities.ScoreBreakdown
+}
+
+// 採点に使用する情報
+type ScoringContext struct {
+	// Needs 気象条件から求めた必要な備え
+	Needs WeatherNeeds
+
+	// Preferences ユーザーのファッション設定（未設定の場合は nil）
+	Preferences *entities.UserPreferences
+
+	// LastWorn アイテムIDごとの最終着用日時（記録がないアイテムは含まれない）
+	LastWorn map[string]time.Time
+
+	// Now 採点時点の日時
+	Now time.Time
+
+	// colors アイテムの色を標準パレットに変換した結果（候補ごとに同じアイテムを何度も変換しないよう1回の推奨内で使い回す）
+	colors map[*entities.ClothingItem]parsedColor
+
+	// preferred 好みの色の標準パレットの識別名（初回の参照時に Preferences から求める）
+	preferred map[string]bool
+}
+
+// アイテムの色の変換結果
+type parsedColor struct {
+	color entities.PaletteColor
+	ok    bool
+}
+
+// アイテムの色をまとめて変換しておく
+func (ctx *ScoringContext) cacheColors(items []*entities.ClothingItem) {
+	if ctx.colors == nil {
+		ctx.colors = make(map[*entities.ClothingItem]parsedColor, len(items))
+	}
+	for _, item := range items {
+		color, ok := entities.ParseColor(item.Color)
+		ctx.colors[item] = parsedColor{color: color, ok: ok}
+	}
+}
+
+// アイテムの色（変換済みでないアイテムはその場で変換）
+func (ctx *ScoringContext) itemColor(item *entities.ClothingItem) (entities.PaletteColor, bool) {
+	if parsed, exists := ctx.colors[item]; exists {
+		return parsed.color, parsed.ok
+	}
+	return entities.ParseColor(item.Color)
+}
+
+// 好みの色の識別名の集合
+func (ctx *ScoringContext) preferredColorSet() map[string]bool {
+	if ctx.preferred == nil {
+		ctx.preferred = paletteColorSet(preferredColors(ctx.Preferences))
+	}
+	return ctx.preferred
+}
+
+// 各採点項目の重み
+type ScoreWeights struct {
+	ThermalFit        float64
+	WeatherProtection float64
+	ColorHarmony      float64
+	UserPreference    float64
+	Recency           float64
+}
+
+// 重み付けスコア戦略のデフォルトの重み
+var DefaultScoreWeights = ScoreWeights{
+	ThermalFit:        0.45,
+	WeatherProtection: 0.25,
+	ColorHarmony:      0.1,
+	UserPreference:    0.1,
+	Recency:           0.1,
+}
+
+// 名前から推奨戦略を生成（ファクトリ）
+// 未知の名前の場合は nil を返す
+func NewRecommendationStrategy(name string) RecommendationStrategy {
+	switch name {
+	case StrategyRules:
+		return RuleBasedStrategy{}
+	case StrategyWeighted:
+		return NewWeightedStrategy(DefaultScoreWeights)
+	default:
+		return nil
+	}
+}
+
+// 保温レベルと雨風への備えを中心に採点する従来のルールに基づく戦略
+// 目標保温レベルとの差を減点し、雨風に強いアイテムを加点する（配色・好みの色は補助的に加味し、最近着たアイテムはアイテムごとに減点する）
+type RuleBasedStrategy struct{}
+
+// Name 戦略名を返す
+func (RuleBasedStrategy) Name() string {
+	return StrategyRules
+}
+
+// Score 保温レベルの差と雨風への備え、配色で採点
+func (RuleBasedStrategy) Score(ctx *ScoringContext, c *CandidateOutfit) entities.ScoreBreakdown {
+	needs := ctx.Needs
+
+	thermal := -math.Abs(float64(c.CoreWarmth() - needs.TargetWarmth))
+	// 合計が同じでも上下の保温バランスが極端な組み合わせ（ニットに短パンなど）は減点
+	thermal -= 0.3 * math.Abs(float64(c.Bottom.Warmth())-needs.slotTarget(entities.CategoryBottoms))
+	thermal -= 0.3 * math.Abs(float64(c.Shoes.Warmth())-needs.slotTarget(entities.CategoryShoes))
+	if needs.hasTemperatureSwing() {
+		// 気温差がある場合は、脱いで暖かい時間帯の目標に近づけられるコーディネートを優先
+		thermal -= 0.5 * math.Abs(float64(c.adjustedWarmth(needs)-needs.MildTargetWarmth))
+	} else if len(c.Tops) > 1 {
+		// 保温が同程度なら重ね着の少ない方を優先
+		thermal -= 0.5
+	}
+
+	protection := 0.0
+	if needs.Rainy {
+		if c.Shoes.IsWaterResistant() {
+			protection += 2
+		}
+		if c.Outerwear != nil && c.Outerwear.IsWaterResistant() {
+			protection += 3
+		}
+	}
+	if needs.Windy && c.Outerwear != nil && c.Outerwear.IsWindResistant() {
+		protection += 3
+	}
+	if needs.needsShell() && c.Outerwear == nil {
+		protection--
+	}
+	// 蒸し暑い日は通気性の良いトップスを加点し、通気性の悪いトップスを減点
+	if needs.Humid {
+		for _, top := range c.Tops {
+			if top.IsBreathable() {
+				protection++
+			} else {
+				protection--
+			}
+		}
+	}
+	// 熱中症・寒冷ストレスへの備えは満たせば加点、不足すれば減点
+	required, satisfied := needs.stressProtection(c)
+	protection += float64(2*satisfied - (required - satisfied))
+
+	// 配色がぶつかる組み合わせを減点し、好みの色を加点（保温・天候への備えより小さい重み）
+	harmony := evaluateColorHarmony(c.CoreItems(), ctx.itemColor).Score
+	preference := preferredColorMatch(c.CoreItems(), ctx.preferredColorSet(), ctx.itemColor)
+	if fit, ok := formalityFit(ctx.Preferences, c.CoreItems()); ok {
+		preference = (preference + fit) / 2
+	}
+	fresh := recency(ctx.LastWorn, ctx.Now, c.CoreItems())
+
+	return entities.ScoreBreakdown{
+		ThermalFit:        thermal,
+		WeatherProtection: protection,
+		ColorHarmony:      harmony,
+		UserPreference:    preference,
+		Recency:           fresh,
+		Total:             thermal + protection + 1.5*(harmony-1) + preference - recentWearPenalty(ctx.LastWorn, ctx.Now, c.CoreItems()),
+	}
+}
+
+// 各項目を 0〜1 に正規化し、重み付きで合算する戦略
+type WeightedStrategy struct {
+	weights ScoreWeights
+}
+
+// 重み付けスコア戦略を作成
+func NewWeightedStrategy(weights ScoreWeights) *WeightedStrategy {
+	return &WeightedStrategy{weights: weights}
+}
+
+// Name 戦略名を返す
+func (s *WeightedStrategy) Name() string {
+	return StrategyWeighted
+}
+
+// Score 5つの観点で採点し重み付きで合算
+func (s *WeightedStrategy) Score(ctx *ScoringContext, c *CandidateOutfit) entities.ScoreBreakdown {
+	b := entities.ScoreBreakdown{
+		ThermalFit:        thermalFit(ctx.Needs, c),
+		WeatherProtection: weatherProtection(ctx.Needs, c),
+		ColorHarmony:      evaluateColorHarmony(c.CoreItems(), ctx.itemColor).Score,
+		UserPreference:    userPreference(ctx, c.CoreItems()),
+		Recency:           recency(ctx.LastWorn, ctx.Now, c.CoreItems()),
+	}
+	b.Total = s.weights.ThermalFit*b.ThermalFit +
+		s.weights.WeatherProtection*b.WeatherProtection +
+		s.weights.ColorHarmony*b.ColorHarmony +
+		s.weights.UserPreference*b.UserPreference +
+		s.weights.Recency*b.Recency
+	return b
+}
+
+// 気温への適合度（目標保温レベルとの差が0で1、差が大きいほど0に近づく）
+// 外出時間帯に気温差がある場合は、脱いで調整した後の暖かい時点への適合度も加味する
+func thermalFit(needs WeatherNeeds, c *CandidateOutfit) float64 {
+	diff := math.Abs(float64(c.CoreWarmth() - needs.TargetWarmth))
+	imbalance := math.Abs(float64(c.Bottom.Warmth())-needs.slotTarget(entities.CategoryBottoms)) +
+		math.Abs(float64(c.Shoes.Warmth())-needs.slotTarget(entities.CategoryShoes))
+	if needs.hasTemperatureSwing() {
+		// 脱いで調整した後の暖かい時間帯への適合度も考慮
+		diff += 0.5 * math.Abs(float64(c.adjustedWarmth(needs)-needs.MildTargetWarmth))
+	}
+	return 1 / (1 + diff/3 + imbalance/10)
+}
+
+// 雨・風・蒸し暑さと熱中症・寒冷ストレスへの備えの充足率（備えが不要な場合は1）
+func weatherProtection(needs WeatherNeeds, c *CandidateOutfit) float64 {
+	required, satisfied := needs.stressProtection(c)
+	if needs.Humid {
+		for _, top := range c.Tops {
+			required++
+			if top.IsBreathable() {
+				satisfied++
+			}
+		}
+	}
+	if needs.Rainy {
+		required += 2
+		if c.Shoes.IsWaterResistant() {
+			satisfied++
+		}
+		if c.Outerwear != nil && c.Outerwear.IsWaterResistant() {
+			satisfied++
+		}
+	}
+	if needs.Windy {
+		required++
+		if c.Outerwear != nil && c.Outerwear.IsWindResistant() {
+			satisfied++
+		}
+	}
+	if required == 0 {
+		return 1
+	}
+	return float64(satisfied) / float64(required)
+}
+
+// ユーザーの好む色・ブランドに一致する度合い（色の一致と、ブランドが一致するアイテムの割合の平均）
+func userPreference(ctx *ScoringContext, items []*entities.ClothingItem) float64 {
+	preferences := ctx.Preferences
+	if preferences == nil || len(items) == 0 {
+		return 0
+	}
+
+	brands := 0
+	for _, item := range items {
+		if containsFold(preferences.PreferredBrands, item.Brand) {
+			brands++
+		}
+	}
+	colorMatch := preferredColorMatch(items, ctx.preferredColorSet(), ctx.itemColor)
+	score := math.Max(colorMatch, (colorMatch+float64(brands)/float64(len(items)))/2)
+	if fit, ok := formalityFit(preferences, items); ok {
+		score = (score + fit) / 2
+	}
+	return score
+}
+
+// 好みのスタイルに対応するフォーマル度
+var styleFormality = map[string]int{
+	string(entities.StyleFormal): entities.MaxFormality,
+	"フォーマル":                      entities.MaxFormality,
+	string(entities.StyleCasual): 1,
+	"カジュアル":                      1,
+	string(entities.StyleSporty): 1,
+	"スポーティ":                      1,
+}
+
+// ユーザーの好みのスタイルに対するフォーマル度の近さ（0.0〜1.0）
+// スタイルが未設定、またはフォーマル度が設定されたアイテムがない場合は false を返す
+func formalityFit(preferences *entities.UserPreferences, items []*entities.ClothingItem) (float64, bool) {
+	if preferences == nil {
+		return 0, false
+	}
+	target, ok := styleFormality[strings.ToLower(preferences.Style)]
+	for _, style := range preferences.Styles {
+		if ok {
+			break
+		}
+		target, ok = styleFormality[strings.ToLower(style)]
+	}
+	if !ok {
+		return 0, false
+	}
+
+	total, rated := 0.0, 0
+	for _, item := range items {
+		if item.Formality == 0 {
+			continue
+		}
+		total += 1 - math.Abs(float64(item.Formality-target))/float64(entities.MaxFormality-1)
+		rated++
+	}
+	if rated == 0 {
+		return 0, false
+	}
+	return total / float64(rated), true
+}
+
+// 着用間隔の目安（これ以上前に着たアイテムは最近着ていないとみなす）
+const recencyWindow = 14 * 24 * time.Hour
+
+// ルールに基づく戦略で、直近に着たアイテム1点あたりに課す減点
+// 保温レベル1の差より大きくし、前日に着たアイテムは多少保温が合わなくても別のアイテムに入れ替える
+// アウターは見た目の印象を大きく左右し、同じものが続くと目立つため重くする
+const (
+	recentWearWeight      = 1.5
+	recentOuterwearWeight = 2.5
+)
+
+// 最近着用していないアイテムの度合い（全て記録なし、または十分前なら1）
+func recency(lastWorn map[string]time.Time, now time.Time, items []*entities.ClothingItem) float64 {
+	if len(items) == 0 {
+		return 1
+	}
+	total := 0.0
+	for _, item := range items {
+		total += wornAgo(lastWorn, now, item)
+	}
+	return total / float64(len(items))
+}
+
+// 直近に着たアイテムごとの減点の合計（着用から recencyWindow かけて0まで減る）
+func recentWearPenalty(lastWorn map[string]time.Time, now time.Time, items []*entities.ClothingItem) float64 {
+	penalty := 0.0
+	for _, item := range items {
+		weight := recentWearWeight
+		if entities.ClothingCategory(item.Category) == entities.CategoryOuterwear {
+			weight = recentOuterwearWeight
+		}
+		penalty += weight * (1 - wornAgo(lastWorn, now, item))
+	}
+	return penalty
+}
+
+// 最後に着てからの経過の度合い（記録なし、または recencyWindow 以上前なら1、直前なら0）
+func wornAgo(lastWorn map[string]time.Time, now time.Time, item *entities.ClothingItem) float64 {
+	worn, exists := lastWorn[item.ID]
+	if !exists {
+		return 1
+	}
+	return math.Max(math.Min(now.Sub(worn).Hours()/recencyWindow.Hours(), 1), 0)
+}
+
+// 大文字小文字を区別せずに一致する要素があるかを判定
+func containsFold(values []string, target string) bool {
+	if target == "" {
+		return false
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(target)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"forecast-app/internal/domain/repositories"
+)
+
+// ローカルファイルシステムにファイルを保存する BlobStore の実装
+// キーの "/" をディレクトリの区切りとして root 以下に保存する
+type LocalBlobStore struct {
+	root string
+}
+
+// ローカル BlobStore の新しいインスタンスを作成（root が存在しない場合は作成する）
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("保存先ディレクトリの作成に失敗しました: %w", err)
+	}
+	return &LocalBlobStore{root: root}, nil
+}
+
+// Put 一時ファイルに書き込んでから置き換え、書き込み途中のファイルが読まれないようにする
+func (s *LocalBlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("保存先ディレクトリの作成に失敗しました: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("ファイルの保存に失敗しました: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ファイルの保存に失敗しました: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ファイルの保存に失敗しました: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("ファイルの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Get Content-Type はキーの拡張子から判定する
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (*repositories.Blob, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", key, repositories.ErrBlobNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ファイルの読み込みに失敗しました: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("ファイルの読み込みに失敗しました: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &repositories.Blob{Body: file, ContentType: contentType, Size: info.Size()}, nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("ファイルの削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// キーを root 以下のファイルパスに変換
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// キーが "/" 区切りの安全な相対パスかを検証
+// 空のキー、先頭の "/"、"." や ".." を含む要素、制御文字・バックスラッシュを含むキーは受け付けない
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return fmt.Errorf("invalid blob key: %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid blob key: %q", key)
+		}
+	}
+	for _, r := range key {
+		if r < 0x20 || r == 0x7f || r == '\\' {
+			return fmt.Errorf("invalid blob key: %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"forecast-app/internal/domain/repositories"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"images/user/photo.jpg", true},
+		{"photo.png", true},
+		{"images/user/photo_thumb.jpg", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"images/", false},
+		{"images//photo.jpg", false},
+		{"../photo.jpg", false},
+		{"images/../../photo.jpg", false},
+		{"images/./photo.jpg", false},
+		{"images/..", false},
+		{`images\..\photo.jpg`, false},
+		{"images/photo\x00.jpg", false},
+		{"images/photo\n.jpg", false},
+		{"images/photo\x7f.jpg", false},
+	}
+	for _, tt := range tests {
+		err := ValidateKey(tt.key)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateKey(%q) error = %v, want valid = %v", tt.key, err, tt.valid)
+		}
+	}
+}
+
+func TestLocalBlobStorePutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBlobStore(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+
+	key := "images/user-1/photo.png"
+	if err := store.Put(ctx, key, []byte("first"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := store.Put(ctx, key, []byte("second"), "image/png"); err != nil {
+		t.Fatalf("Put (overwrite): %v", err)
+	}
+
+	blob, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	if err != nil {
+		t.Fatalf("read blob: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Get body = %q, want %q", data, "second")
+	}
+	if blob.ContentType != "image/png" {
+		t.Errorf("Get ContentType = %q, want image/png", blob.ContentType)
+	}
+	if blob.Size != int64(len("second")) {
+		t.Errorf("Get Size = %d, want %d", blob.Size, len("second"))
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, repositories.ErrBlobNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrBlobNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key error = %v, want nil", err)
+	}
+}
+
+func TestLocalBlobStoreLeavesNoTemporaryFiles(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalBlobStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+	if err := store.Put(context.Background(), "images/u/a.jpg", []byte("data"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "images", "u"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "a.jpg" {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("files after Put = %v, want [a.jpg]", names)
+	}
+}
+
+func TestLocalBlobStoreRejectsTraversal(t *testing.T) {
+	ctx := context.Background()
+	parent := t.TempDir()
+	root := filepath.Join(parent, "blobs")
+	store, err := NewLocalBlobStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+	secret := filepath.Join(parent, "secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	for _, key := range []string{"../secret.txt", "images/../../secret.txt", "/secret.txt", `..\secret.txt`} {
+		if err := store.Put(ctx, key, []byte("overwritten"), "text/plain"); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", key)
+		}
+		if blob, err := store.Get(ctx, key); err == nil {
+			blob.Body.Close()
+			t.Errorf("Get(%q) succeeded, want error", key)
+		}
+		if err := store.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) succeeded, want error", key)
+		}
+	}
+
+	data, err := os.ReadFile(secret)
+	if err != nil || string(data) != "secret" {
+		t.Errorf("file outside root = %q, %v; want it untouched", data, err)
+	}
+}
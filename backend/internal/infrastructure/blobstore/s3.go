@@ -0,0 +1,281 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/repositories"
+)
+
+// S3 互換ストレージの接続設定
+type S3Options struct {
+	// Endpoint エンドポイントのURL（例: https://s3.ap-northeast-1.amazonaws.com、http://minio:9000）
+	Endpoint string
+
+	Region string
+
+	Bucket string
+
+	AccessKeyID string
+
+	SecretAccessKey string
+
+	// PathStyle バケット名をパスに含める形式でアクセスするか（MinIO ではパス形式を使用する）
+	PathStyle bool
+
+	// Timeout 1リクエストあたりのタイムアウト（0 の場合は30秒）
+	Timeout time.Duration
+}
+
+// S3 互換のオブジェクトストレージ（AWS S3・MinIO など）にファイルを保存する BlobStore の実装
+// 署名バージョン4（SigV4）で署名したリクエストを直接送信する
+type S3BlobStore struct {
+	options S3Options
+	client  *http.Client
+	now     func() time.Time
+}
+
+// S3 BlobStore の新しいインスタンスを作成
+func NewS3BlobStore(options S3Options) (*S3BlobStore, error) {
+	if options.Endpoint == "" || options.Bucket == "" {
+		return nil, fmt.Errorf("S3 のエンドポイントとバケット名は必須です")
+	}
+	if _, err := url.Parse(options.Endpoint); err != nil {
+		return nil, fmt.Errorf("S3 のエンドポイントが不正です: %w", err)
+	}
+	if options.Region == "" {
+		options.Region = "us-east-1"
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second
+	}
+	return &S3BlobStore{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+		now:     time.Now,
+	}, nil
+}
+
+// EnsureBucket バケットが存在しない場合は作成する（起動時に使用）
+func (s *S3BlobStore) EnsureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, "")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = s.do(ctx, http.MethodPut, "", nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return s.responseError("バケットの作成", resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError("ファイルの保存", resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) (*repositories.Blob, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %w", key, repositories.ErrBlobNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s.responseError("ファイルの読み込み", resp)
+	}
+	return &repositories.Blob{
+		Body:        resp.Body,
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+	}, nil
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.responseError("ファイルの削除", resp)
+	}
+	return nil
+}
+
+// 署名付きリクエストを送信
+func (s *S3BlobStore) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("S3 リクエストの作成に失敗しました: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.ContentLength = int64(len(body))
+	signV4(req, body, s.options.Region, s.options.AccessKeyID, s.options.SecretAccessKey, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("S3 へのリクエストに失敗しました: %w", err)
+	}
+	return resp, nil
+}
+
+// オブジェクト（key が空の場合はバケット）のURL
+func (s *S3BlobStore) objectURL(key string) string {
+	endpoint, _ := url.Parse(s.options.Endpoint)
+	objectPath := "/" + escapeKey(key)
+	if s.options.PathStyle {
+		objectPath = "/" + s.options.Bucket + objectPath
+	} else {
+		endpoint.Host = s.options.Bucket + "." + endpoint.Host
+	}
+	if key == "" && s.options.PathStyle {
+		objectPath = strings.TrimSuffix(objectPath, "/")
+	}
+	endpoint.Path = ""
+	endpoint.RawPath = ""
+	return strings.TrimSuffix(endpoint.String(), "/") + objectPath
+}
+
+func (s *S3BlobStore) responseError(operation string, resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("S3 での%sに失敗しました: %s %s", operation, resp.Status, strings.TrimSpace(string(message)))
+}
+
+// キーの各要素を URI エンコード（"/" はそのまま残す）
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// SigV4 で要求される URI エンコード（非予約文字以外を全て %XX に変換）
+func uriEncode(value string) string {
+	var b strings.Builder
+	for _, c := range []byte(value) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// AWS 署名バージョン4でリクエストに署名（リクエストに設定済みの全てのヘッダーと Host を署名対象とする）
+func signV4(req *http.Request, body []byte, region, accessKeyID, secretAccessKey string, now time.Time) {
+	const service = "s3"
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+// パスが空の場合は "/" とする
+func canonicalURI(u *url.URL) string {
+	if escaped := u.EscapedPath(); escaped != "" {
+		return escaped
+	}
+	return "/"
+}
+
+// クエリパラメータを名前順に並べて URI エンコード
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name)+"="+uriEncode(value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
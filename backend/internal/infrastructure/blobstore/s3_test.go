@@ -0,0 +1,110 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+func TestS3ObjectURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		pathStyle bool
+		key       string
+		want      string
+	}{
+		{"path style object", "http://minio:9000", true, "images/u/a b.jpg", "http://minio:9000/bucket/images/u/a%20b.jpg"},
+		{"path style bucket", "http://minio:9000/", true, "", "http://minio:9000/bucket"},
+		{"virtual hosted object", "https://s3.ap-northeast-1.amazonaws.com", false, "images/u/写真.jpg", "https://bucket.s3.ap-northeast-1.amazonaws.com/images/u/%E5%86%99%E7%9C%9F.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewS3BlobStore(S3Options{Endpoint: tt.endpoint, Bucket: "bucket", PathStyle: tt.pathStyle})
+			if err != nil {
+				t.Fatalf("NewS3BlobStore: %v", err)
+			}
+			if got := store.objectURL(tt.key); got != tt.want {
+				t.Errorf("objectURL(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+// docker-compose の MinIO に対して実行する結合テスト
+// 例: S3_TEST_ENDPOINT=http://localhost:9000 go test ./internal/infrastructure/blobstore/
+// 認証情報とバケットは S3_TEST_ACCESS_KEY_ID・S3_TEST_SECRET_ACCESS_KEY・S3_TEST_BUCKET で変更できる
+func TestS3BlobStoreAgainstMinIO(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+
+	store, err := NewS3BlobStore(S3Options{
+		Endpoint:        endpoint,
+		Bucket:          envOr("S3_TEST_BUCKET", "tenkiru-images-test"),
+		AccessKeyID:     envOr("S3_TEST_ACCESS_KEY_ID", "tenkiru"),
+		SecretAccessKey: envOr("S3_TEST_SECRET_ACCESS_KEY", "tenkiru-secret"),
+		PathStyle:       true,
+		Timeout:         10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewS3BlobStore: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.EnsureBucket(ctx); err != nil {
+		t.Fatalf("EnsureBucket: %v", err)
+	}
+	if err := store.EnsureBucket(ctx); err != nil {
+		t.Fatalf("EnsureBucket on an existing bucket: %v", err)
+	}
+
+	key := "test/" + entities.NewID() + "/写真 1.png"
+	t.Cleanup(func() { store.Delete(context.Background(), key) })
+
+	if err := store.Put(ctx, key, []byte("png data"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	blob, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, err := io.ReadAll(blob.Body)
+	blob.Body.Close()
+	if err != nil {
+		t.Fatalf("read blob: %v", err)
+	}
+	if string(data) != "png data" {
+		t.Errorf("Get body = %q, want %q", data, "png data")
+	}
+	if blob.ContentType != "image/png" {
+		t.Errorf("Get ContentType = %q, want image/png", blob.ContentType)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, repositories.ErrBlobNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrBlobNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key error = %v, want nil", err)
+	}
+	if err := store.Put(ctx, "../escape.png", []byte("x"), "image/png"); err == nil {
+		t.Error("Put with a traversal key succeeded, want error")
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// PostgreSQL の一意制約違反エラーコード
+const pgUniqueViolation = "23505"
+
+// SQLデータベースの方言
+type Dialect string
+
+const (
+	DialectSQLite   Dialect = "sqlite"
+	DialectPostgres Dialect = "postgres"
+)
+
+// コネクションプールの設定
+// ゼロ値の項目はドライバーごとのデフォルト値を使用
+type PoolOptions struct {
+	// MaxOpenConns 同時に開く最大接続数
+	MaxOpenConns int
+
+	// MaxIdleConns プールに保持するアイドル接続数
+	MaxIdleConns int
+
+	// ConnMaxLifetime 1接続を使い回す最大時間
+	ConnMaxLifetime time.Duration
+
+	// ConnMaxIdleTime アイドル接続を破棄するまでの時間
+	ConnMaxIdleTime time.Duration
+}
+
+// リポジトリから実行されるクエリの共通インターフェース
+// *DB と *Tx の両方が実装し、リポジトリはトランザクションの有無を意識せずに利用できる
+type Executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+
+	// Rebind "?" プレースホルダーを接続先の方言に合わせて変換
+	Rebind(query string) string
+}
+
+// リポジトリから利用するデータベース接続
+type DB struct {
+	*sql.DB
+
+	// Dialect 接続先データベースの方言
+	Dialect Dialect
+}
+
+// トランザクション中のデータベース接続
+type Tx struct {
+	*sql.Tx
+
+	dialect Dialect
+}
+
+// 指定されたドライバーでデータベースに接続し、スキーママイグレーションを適用
+func Open(driver, dsn string, pool PoolOptions) (*DB, error) {
+	switch Dialect(driver) {
+	case DialectSQLite:
+		return openSQLite(dsn)
+	case DialectPostgres:
+		return openPostgres(dsn, pool)
+	default:
+		return nil, fmt.Errorf("未対応のストレージドライバーです: %s", driver)
+	}
+}
+
+// SQLite データベースを開く
+func openSQLite(dsn string) (*DB, error) {
+	if dsn == "" {
+		dsn = "tenkiru.db"
+	}
+	dsn = strings.TrimPrefix(dsn, "sqlite://")
+
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("データベースのオープンに失敗しました: %w", err)
+	}
+
+	// SQLite は単一ライターのため接続を1本に制限し、書き込み競合を防止
+	sqlDB.SetMaxOpenConns(1)
+
+	for _, pragma := range []string{
+		"PRAGMA foreign_keys = ON",
+		"PRAGMA journal_mode = WAL",
+		"PRAGMA busy_timeout = 5000",
+	} {
+		if _, err := sqlDB.Exec(pragma); err != nil {
+			sqlDB.Close()
+			return nil, fmt.Errorf("データベースの初期設定に失敗しました: %w", err)
+		}
+	}
+
+	db := &DB{DB: sqlDB, Dialect: DialectSQLite}
+	if err := db.Migrate(sqliteMigrations); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// PostgreSQL データベースにコネクションプール付きで接続
+func openPostgres(dsn string, pool PoolOptions) (*DB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("postgres ドライバーには DATABASE_URL の指定が必要です")
+	}
+
+	sqlDB, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("データベースのオープンに失敗しました: %w", err)
+	}
+
+	if pool.MaxOpenConns == 0 {
+		pool.MaxOpenConns = 25
+	}
+	if pool.MaxIdleConns == 0 {
+		pool.MaxIdleConns = 5
+	}
+	if pool.ConnMaxLifetime == 0 {
+		pool.ConnMaxLifetime = 30 * time.Minute
+	}
+	if pool.ConnMaxIdleTime == 0 {
+		pool.ConnMaxIdleTime = 5 * time.Minute
+	}
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	if err := sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("データベースへの接続に失敗しました: %w", err)
+	}
+
+	db := &DB{DB: sqlDB, Dialect: DialectPostgres}
+	if err := db.Migrate(postgresMigrations); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// トランザクションを開始
+func (db *DB) BeginTx() (*Tx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	return &Tx{Tx: tx, dialect: db.Dialect}, nil
+}
+
+// プレースホルダーを接続先の方言に合わせて変換
+// クエリは SQLite 形式の "?" で記述し、必要に応じてここで書き換える
+func (db *DB) Rebind(query string) string {
+	return rebind(db.Dialect, query)
+}
+
+// プレースホルダーを接続先の方言に合わせて変換
+func (tx *Tx) Rebind(query string) string {
+	return rebind(tx.dialect, query)
+}
+
+// "?" を PostgreSQL の "$1, $2, ..." 形式に置き換える
+// 文字列リテラル（'...'）と引用符付き識別子（"..."）の中の "?" はそのまま残す（'' によるエスケープは引用の終了と開始として扱えば同じ結果になる）
+func rebind(dialect Dialect, query string) string {
+	if dialect != DialectPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 10)
+	n := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// 一意制約（主キー・UNIQUE）違反のエラーかを判定
+func IsUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code()
+		return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgUniqueViolation
+	}
+
+	return false
+}
@@ -0,0 +1,24 @@
+package database
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`SELECT * FROM users WHERE id = ? AND email = ?`, `SELECT * FROM users WHERE id = $1 AND email = $2`},
+		{`SELECT * FROM users WHERE name = 'who?' AND id = ?`, `SELECT * FROM users WHERE name = 'who?' AND id = $1`},
+		{`SELECT * FROM users WHERE name = 'it''s ?' AND id = ?`, `SELECT * FROM users WHERE name = 'it''s ?' AND id = $1`},
+		{`SELECT "col?" FROM t WHERE a = ? AND b = '"?' AND c = ?`, `SELECT "col?" FROM t WHERE a = $1 AND b = '"?' AND c = $2`},
+		{`UPDATE t SET note = '？全角' WHERE id = ?`, `UPDATE t SET note = '？全角' WHERE id = $1`},
+	}
+	for _, tt := range tests {
+		if got := rebind(DialectPostgres, tt.query); got != tt.want {
+			t.Errorf("rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+		if got := rebind(DialectSQLite, tt.query); got != tt.query {
+			t.Errorf("rebind for SQLite changed %q to %q", tt.query, got)
+		}
+	}
+}
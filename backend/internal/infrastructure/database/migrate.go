@@ -0,0 +1,119 @@
+package database
+
+import (
+	"fmt"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// スキーママイグレーションの1ステップを表現
+type Migration struct {
+	// Version 適用順序を表す連番（適用済み判定にも使用）
+	Version int
+
+	// Description マイグレーション内容の説明
+	Description string
+
+	// Statements 実行するDDL文
+	Statements []string
+
+	// Apply DDL の後に同じトランザクション内で実行するデータ移行（不要な場合は nil）
+	Apply func(tx *Tx) error
+}
+
+// 未適用のマイグレーションをバージョン順に適用
+func (db *DB) Migrate(migrations []Migration) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("マイグレーション管理テーブルの作成に失敗しました: %w", err)
+	}
+
+	current, err := db.schemaVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := db.apply(m); err != nil {
+			return fmt.Errorf("マイグレーション %d (%s) の適用に失敗しました: %w", m.Version, m.Description, err)
+		}
+	}
+
+	return nil
+}
+
+// 適用済みの最新バージョンを取得
+func (db *DB) schemaVersion() (int, error) {
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("スキーマバージョンの取得に失敗しました: %w", err)
+	}
+	return version, nil
+}
+
+// 1つのマイグレーションをトランザクション内で適用
+func (db *DB) apply(m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range m.Statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	if m.Apply != nil {
+		if err := m.Apply(&Tx{Tx: tx, dialect: db.Dialect}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(
+		db.Rebind(`INSERT INTO schema_migrations (version, description) VALUES (?, ?)`),
+		m.Version, m.Description,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// 既存の衣服アイテムの色を標準パレットの識別名に変換して color_key に保存
+// 変換規則（entities.ParseColor）は Go 側にしかないため、SQL ではなく1件ずつ更新する
+func backfillClothingColorKeys(tx *Tx) error {
+	rows, err := tx.Query(`SELECT id, color FROM clothing_items`)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]string)
+	for rows.Next() {
+		var id, color string
+		if err := rows.Scan(&id, &color); err != nil {
+			rows.Close()
+			return err
+		}
+		if key := entities.ColorKey(color); key != "" {
+			keys[id] = key
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, key := range keys {
+		if _, err := tx.Exec(tx.Rebind(`UPDATE clothing_items SET color_key = ? WHERE id = ?`), key, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackfillClothingColorKeys(t *testing.T) {
+	db, err := Open(string(DialectSQLite), filepath.Join(t.TempDir(), "test.db"), PoolOptions{})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	// color_key を持たない（マイグレーション前に登録された）アイテム
+	for id, color := range map[string]string{"a": "#1F2A4D", "b": "濃紺", "c": "titanium"} {
+		if _, err := db.Exec(`INSERT INTO clothing_items (id, user_id, name, color, category, created_at) VALUES (?, 'user-1', 'shirt', ?, 'トップス', ?)`, id, color, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tx, err := db.BeginTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backfillClothingColorKeys(tx); err != nil {
+		t.Fatalf("backfillClothingColorKeys: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[string]string{"a": "navy", "b": "navy", "c": ""} {
+		var got string
+		if err := db.QueryRow(`SELECT color_key FROM clothing_items WHERE id = ?`, id).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("color_key of %s = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	for i := 0; i < 2; i++ {
+		db, err := Open(string(DialectSQLite), path, PoolOptions{})
+		if err != nil {
+			t.Fatalf("Open #%d: %v", i+1, err)
+		}
+		version, err := db.schemaVersion()
+		db.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := sqliteMigrations[len(sqliteMigrations)-1].Version; version != want {
+			t.Errorf("schema version = %d, want %d", version, want)
+		}
+	}
+	if len(sqliteMigrations) != len(postgresMigrations) {
+		t.Errorf("sqlite has %d migrations, postgres has %d", len(sqliteMigrations), len(postgresMigrations))
+	}
+	for i := range sqliteMigrations {
+		if sqliteMigrations[i].Version != postgresMigrations[i].Version {
+			t.Errorf("migration %d: sqlite version %d, postgres version %d", i, sqliteMigrations[i].Version, postgresMigrations[i].Version)
+		}
+	}
+}
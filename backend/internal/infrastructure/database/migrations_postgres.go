@@ -0,0 +1,164 @@
+package database
+
+// PostgreSQL 用のスキーママイグレーション
+// バージョン番号は SQLite 側と揃え、スキーマ変更時は両方に追加すること
+var postgresMigrations = []Migration{
+	{
+		Version:     1,
+		Description: "create initial tables",
+		Statements: []string{
+			`CREATE TABLE users (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				email TEXT NOT NULL UNIQUE,
+				password TEXT NOT NULL,
+				gender TEXT NOT NULL DEFAULT '',
+				age INTEGER NOT NULL DEFAULT 0,
+				preferences JSONB,
+				created_at TIMESTAMPTZ NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE TABLE clothing_items (
+				id TEXT PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				type TEXT NOT NULL DEFAULT '',
+				color TEXT NOT NULL,
+				category TEXT NOT NULL,
+				brand TEXT NOT NULL DEFAULT '',
+				warmth_level INTEGER NOT NULL DEFAULT 0,
+				image_url TEXT NOT NULL DEFAULT '',
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX idx_clothing_items_user_id ON clothing_items (user_id)`,
+			`CREATE TABLE fashion_recommendations (
+				id TEXT PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				style TEXT NOT NULL DEFAULT '',
+				items JSONB NOT NULL,
+				weather JSONB NOT NULL,
+				reason TEXT NOT NULL DEFAULT '',
+				location TEXT NOT NULL DEFAULT '',
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX idx_fashion_recommendations_user_id ON fashion_recommendations (user_id)`,
+			`CREATE TABLE outfit_posts (
+				id TEXT PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				user_name TEXT NOT NULL DEFAULT '',
+				items JSONB NOT NULL,
+				description TEXT NOT NULL DEFAULT '',
+				tags JSONB NOT NULL,
+				weather JSONB NOT NULL,
+				temperature DOUBLE PRECISION NOT NULL DEFAULT 0,
+				location TEXT NOT NULL DEFAULT '',
+				image_url TEXT NOT NULL DEFAULT '',
+				likes INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX idx_outfit_posts_user_id ON outfit_posts (user_id)`,
+		},
+	},
+	{
+		Version:     2,
+		Description: "add version columns for optimistic locking",
+		Statements: []string{
+			`ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+			`ALTER TABLE clothing_items ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+			`ALTER TABLE fashion_recommendations ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+			`ALTER TABLE outfit_posts ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+		},
+	},
+	{
+		Version:     3,
+		Description: "add outfit alternatives to fashion recommendations",
+		Statements: []string{
+			`ALTER TABLE fashion_recommendations ADD COLUMN outfits JSONB NOT NULL DEFAULT '[]'`,
+		},
+	},
+	{
+		Version:     4,
+		Description: "record recommendation strategy",
+		Statements: []string{
+			`ALTER TABLE fashion_recommendations ADD COLUMN strategy TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version:     5,
+		Description: "add recommendation feedback and learned comfort offset",
+		Statements: []string{
+			`ALTER TABLE fashion_recommendations ADD COLUMN feedback JSONB NOT NULL DEFAULT 'null'`,
+			`ALTER TABLE users ADD COLUMN comfort_offset DOUBLE PRECISION NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		Version:     6,
+		Description: "add day plan to fashion recommendations",
+		Statements: []string{
+			`ALTER TABLE fashion_recommendations ADD COLUMN day_plan JSONB NOT NULL DEFAULT 'null'`,
+		},
+	},
+	{
+		Version:     7,
+		Description: "add weather advisories to fashion recommendations",
+		Statements: []string{
+			`ALTER TABLE fashion_recommendations ADD COLUMN advisories JSONB NOT NULL DEFAULT '[]'`,
+		},
+	},
+	{
+		Version:     8,
+		Description: "add wear logs",
+		Statements: []string{
+			`CREATE TABLE wear_logs (
+				id TEXT PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				clothing_id TEXT NOT NULL,
+				worn_on DATE NOT NULL,
+				source TEXT NOT NULL,
+				source_id TEXT NOT NULL DEFAULT '',
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX idx_wear_logs_clothing_id_worn_on ON wear_logs (clothing_id, worn_on)`,
+			`CREATE INDEX idx_wear_logs_user_id_worn_on ON wear_logs (user_id, worn_on)`,
+		},
+	},
+	{
+		Version:     9,
+		Description: "add availability to clothing items",
+		Statements: []string{
+			`ALTER TABLE clothing_items ADD COLUMN availability TEXT NOT NULL DEFAULT 'available'`,
+			`ALTER TABLE clothing_items ADD COLUMN availability_changed_at TIMESTAMPTZ`,
+		},
+	},
+	{
+		Version:     10,
+		Description: "add detailed attributes to clothing items",
+		Statements: []string{
+			`ALTER TABLE clothing_items ADD COLUMN seasons JSONB NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE clothing_items ADD COLUMN material TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE clothing_items ADD COLUMN waterproof BOOLEAN NOT NULL DEFAULT FALSE`,
+			`ALTER TABLE clothing_items ADD COLUMN windproof BOOLEAN NOT NULL DEFAULT FALSE`,
+			`ALTER TABLE clothing_items ADD COLUMN breathability INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE clothing_items ADD COLUMN formality INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE clothing_items ADD COLUMN size TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE clothing_items ADD COLUMN notes TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version:     11,
+		Description: "add thumbnail urls for uploaded images",
+		Statements: []string{
+			`ALTER TABLE clothing_items ADD COLUMN thumbnail_url TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE outfit_posts ADD COLUMN thumbnail_url TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		Version:     12,
+		Description: "store normalized color keys for clothing items",
+		Statements: []string{
+			`ALTER TABLE clothing_items ADD COLUMN color_key TEXT NOT NULL DEFAULT ''`,
+			`CREATE INDEX idx_clothing_items_user_id_color_key ON clothing_items (user_id, color_key)`,
+		},
+		Apply: backfillClothingColorKeys,
+	},
+}
@@ -0,0 +1,235 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// 天気キャッシュの設定
+type WeatherCacheOptions struct {
+	// TTL キャッシュを新鮮とみなす期間
+	TTL time.Duration
+
+	// StaleTTL TTL 経過後も古いデータを返しつつバックグラウンドで更新する期間（0 で無効）
+	// 天気サービスの障害時は、この期間内であれば更新に失敗した古いデータを Stale を付けて返し続ける
+	StaleTTL time.Duration
+
+	// GeohashPrecision キャッシュキーに使用する geohash の桁数（5桁≒4.9km四方）
+	GeohashPrecision int
+
+	// MaxEntries 保持する最大エントリ数
+	MaxEntries int
+}
+
+// 天気キャッシュの統計情報
+type WeatherCacheStats struct {
+	Hits      int64 `json:"hits"`       // 新鮮なキャッシュを返した回数
+	StaleHits int64 `json:"stale_hits"` // 古いキャッシュを返して更新を開始した回数
+	Misses    int64 `json:"misses"`     // 上流から取得した回数（同時リクエストの合流分を含む）
+	Errors    int64 `json:"errors"`     // 上流からの取得に失敗した回数
+	Entries   int   `json:"entries"`    // 現在のエントリ数
+}
+
+// キャッシュエントリ
+type weatherCacheEntry struct {
+	value     interface{}
+	fetchedAt time.Time
+
+	// refreshFailed TTL 経過後の更新が天気サービスの障害で失敗した場合に true
+	refreshFailed bool
+}
+
+// WeatherRepository にキャッシュを追加するデコレーター
+// 緯度経度を geohash で丸めたセル単位でキャッシュし、同一セルへの同時リクエストは1回の上流呼び出しに合流させる
+type CachedWeatherRepository struct {
+	next    repositories.WeatherRepository
+	options WeatherCacheOptions
+	now     func() time.Time
+
+	entries map[string]*weatherCacheEntry
+	mutex   sync.RWMutex
+
+	// group 同一キーへの同時取得を1回にまとめる
+	group singleflight.Group
+
+	hits      atomic.Int64
+	staleHits atomic.Int64
+	misses    atomic.Int64
+	errors    atomic.Int64
+}
+
+// NewCachedWeatherRepository キャッシュ付き天気リポジトリを作成します
+func NewCachedWeatherRepository(next repositories.WeatherRepository, options WeatherCacheOptions) *CachedWeatherRepository {
+	if options.TTL <= 0 {
+		options.TTL = 10 * time.Minute
+	}
+	if options.GeohashPrecision <= 0 {
+		options.GeohashPrecision = 5
+	}
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = 10000
+	}
+
+	return &CachedWeatherRepository{
+		next:    next,
+		options: options,
+		now:     time.Now,
+		entries: make(map[string]*weatherCacheEntry),
+	}
+}
+
+// GetByLocation キャッシュを利用して現在の天気情報を取得します
+func (r *CachedWeatherRepository) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	value, stale, err := r.get(ctx, r.key("current", latitude, longitude), func(ctx context.Context) (interface{}, error) {
+		return r.next.GetByLocation(ctx, latitude, longitude)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 呼び出し側の変更がキャッシュに影響しないようコピーを返す
+	condition := *value.(*entities.WeatherCondition)
+	condition.Stale = stale
+	return &condition, nil
+}
+
+// GetForecast キャッシュを利用して天気予報を取得します
+func (r *CachedWeatherRepository) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	value, stale, err := r.get(ctx, r.key("forecast", latitude, longitude), func(ctx context.Context) (interface{}, error) {
+		return r.next.GetForecast(ctx, latitude, longitude)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 呼び出し側の変更がキャッシュに影響しないようコピーを返す
+	forecast := *value.(*entities.WeatherForecast)
+	forecast.Hourly = append([]entities.WeatherCondition(nil), forecast.Hourly...)
+	forecast.Daily = append([]entities.DailyWeather(nil), forecast.Daily...)
+	forecast.Stale = stale
+	return &forecast, nil
+}
+
+// Stats キャッシュの統計情報を返します
+func (r *CachedWeatherRepository) Stats() WeatherCacheStats {
+	r.mutex.RLock()
+	entries := len(r.entries)
+	r.mutex.RUnlock()
+
+	return WeatherCacheStats{
+		Hits:      r.hits.Load(),
+		StaleHits: r.staleHits.Load(),
+		Misses:    r.misses.Load(),
+		Errors:    r.errors.Load(),
+		Entries:   entries,
+	}
+}
+
+// 種類と geohash からキャッシュキーを生成
+func (r *CachedWeatherRepository) key(kind string, latitude, longitude float64) string {
+	return kind + ":" + encodeGeohash(latitude, longitude, r.options.GeohashPrecision)
+}
+
+// 上流からの取得処理
+type weatherFetchFunc func(ctx context.Context) (interface{}, error)
+
+// キャッシュからの取得、期限切れ時の更新を行う
+// stale は天気サービスの障害で更新できなかった古いデータを返した場合に true
+func (r *CachedWeatherRepository) get(ctx context.Context, key string, fetch weatherFetchFunc) (value interface{}, stale bool, err error) {
+	r.mutex.RLock()
+	entry, exists := r.entries[key]
+	var refreshFailed bool
+	if exists {
+		refreshFailed = entry.refreshFailed
+	}
+	r.mutex.RUnlock()
+
+	if exists {
+		age := r.now().Sub(entry.fetchedAt)
+		if age < r.options.TTL {
+			r.hits.Add(1)
+			return entry.value, false, nil
+		}
+		if age < r.options.TTL+r.options.StaleTTL {
+			// 古いデータを即座に返し、更新はバックグラウンドで行う（同時更新は singleflight で1回に合流）
+			r.staleHits.Add(1)
+			go r.load(context.Background(), key, fetch)
+			return entry.value, refreshFailed, nil
+		}
+	}
+
+	r.misses.Add(1)
+	value, err = r.load(ctx, key, fetch)
+	return value, false, err
+}
+
+// 上流から取得してキャッシュに保存（同一キーの同時呼び出しは1回に合流）
+// 合流した取得は最初の呼び出し元のキャンセルに巻き込まれないよう切り離して実行し、
+// 各呼び出し元は自身のコンテキストが終了した時点で待機を打ち切る
+func (r *CachedWeatherRepository) load(ctx context.Context, key string, fetch weatherFetchFunc) (interface{}, error) {
+	result := r.group.DoChan(key, func() (interface{}, error) {
+		value, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			r.errors.Add(1)
+			if errors.Is(err, repositories.ErrWeatherUnavailable) {
+				r.markRefreshFailed(key)
+			}
+			return nil, err
+		}
+
+		r.store(key, value)
+		return value, nil
+	})
+
+	select {
+	case res := <-result:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %v", repositories.ErrWeatherUnavailable, ctx.Err())
+	}
+}
+
+// 既存のエントリを更新できなかったことを記録
+func (r *CachedWeatherRepository) markRefreshFailed(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if entry, exists := r.entries[key]; exists {
+		entry.refreshFailed = true
+	}
+}
+
+// キャッシュに保存し、上限を超えた場合は期限切れのエントリを削除
+func (r *CachedWeatherRepository) store(key string, value interface{}) {
+	now := r.now()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.entries) >= r.options.MaxEntries {
+		maxAge := r.options.TTL + r.options.StaleTTL
+		for k, e := range r.entries {
+			if now.Sub(e.fetchedAt) >= maxAge {
+				delete(r.entries, k)
+			}
+		}
+		// 期限切れがなければ任意のエントリを1件削除して上限を守る
+		for k := range r.entries {
+			if len(r.entries) < r.options.MaxEntries {
+				break
+			}
+			delete(r.entries, k)
+		}
+	}
+
+	r.entries[key] = &weatherCacheEntry{value: value, fetchedAt: now}
+}
@@ -0,0 +1,267 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// 呼び出し回数を数え、fail を設定すると障害を返す天気リポジトリ
+type fakeWeatherRepository struct {
+	calls       atomic.Int32
+	temperature atomic.Int32
+	fail        atomic.Bool
+}
+
+func (r *fakeWeatherRepository) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	r.calls.Add(1)
+	if r.fail.Load() {
+		return nil, fmt.Errorf("%w: upstream down", repositories.ErrWeatherUnavailable)
+	}
+	return &entities.WeatherCondition{Temperature: float64(r.temperature.Load())}, nil
+}
+
+func (r *fakeWeatherRepository) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	r.calls.Add(1)
+	if r.fail.Load() {
+		return nil, fmt.Errorf("%w: upstream down", repositories.ErrWeatherUnavailable)
+	}
+	return &entities.WeatherForecast{Hourly: []entities.WeatherCondition{{Temperature: float64(r.temperature.Load())}}}, nil
+}
+
+// 時刻を進められるキャッシュ付きリポジトリを作成
+func newTestCachedWeatherRepository(next repositories.WeatherRepository, options WeatherCacheOptions) (*CachedWeatherRepository, func(time.Duration)) {
+	var mutex sync.Mutex
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	repo := NewCachedWeatherRepository(next, options)
+	repo.now = func() time.Time {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return now
+	}
+	return repo, func(d time.Duration) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		now = now.Add(d)
+	}
+}
+
+// バックグラウンドの更新が終わるまで待つ
+func waitForCalls(t *testing.T, next *fakeWeatherRepository, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for next.calls.Load() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("upstream calls = %d, want %d", next.calls.Load(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// 更新結果の保存が終わるのを待つ
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestCachedWeatherRepositoryHitsSameCell(t *testing.T) {
+	next := &fakeWeatherRepository{}
+	repo, _ := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute})
+
+	if _, err := repo.GetByLocation(context.Background(), 35.6895, 139.6917); err != nil {
+		t.Fatal(err)
+	}
+	// 同じ geohash セル内の近い地点はキャッシュから返す
+	if _, err := repo.GetByLocation(context.Background(), 35.6896, 139.6918); err != nil {
+		t.Fatal(err)
+	}
+	if got := next.calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+	if stats := repo.Stats(); stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("stats = %+v, want 1 hit, 1 miss, 1 entry", stats)
+	}
+
+	// 呼び出し側の変更はキャッシュに影響しない
+	condition, _ := repo.GetByLocation(context.Background(), 35.6895, 139.6917)
+	condition.Temperature = 99
+	if again, _ := repo.GetByLocation(context.Background(), 35.6895, 139.6917); again.Temperature == 99 {
+		t.Error("modifying a returned condition changed the cached value")
+	}
+}
+
+func TestCachedWeatherRepositoryServesStaleDuringOutage(t *testing.T) {
+	next := &fakeWeatherRepository{}
+	next.temperature.Store(20)
+	repo, advance := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute, StaleTTL: time.Hour})
+	ctx := context.Background()
+
+	if _, err := repo.GetByLocation(ctx, 35.6895, 139.6917); err != nil {
+		t.Fatal(err)
+	}
+
+	// 天気サービスが落ちた後、TTL を過ぎた最初の呼び出しは古いデータを返して更新を開始する
+	next.fail.Store(true)
+	advance(2 * time.Minute)
+	condition, err := repo.GetByLocation(ctx, 35.6895, 139.6917)
+	if err != nil {
+		t.Fatalf("GetByLocation during outage: %v", err)
+	}
+	if condition.Temperature != 20 {
+		t.Errorf("temperature = %v, want the cached 20", condition.Temperature)
+	}
+	waitForCalls(t, next, 2)
+
+	// 更新に失敗した後は Stale を付けて返す
+	condition, err = repo.GetByLocation(ctx, 35.6895, 139.6917)
+	if err != nil {
+		t.Fatalf("GetByLocation after a failed refresh: %v", err)
+	}
+	if !condition.Stale || condition.Temperature != 20 {
+		t.Errorf("condition = stale %v temperature %v, want stale 20", condition.Stale, condition.Temperature)
+	}
+	waitForCalls(t, next, 3)
+
+	// 復旧して更新に成功すると Stale は外れる
+	next.fail.Store(false)
+	next.temperature.Store(25)
+	repo.GetByLocation(ctx, 35.6895, 139.6917)
+	waitForCalls(t, next, 4)
+	condition, err = repo.GetByLocation(ctx, 35.6895, 139.6917)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition.Stale || condition.Temperature != 25 {
+		t.Errorf("condition after recovery = stale %v temperature %v, want fresh 25", condition.Stale, condition.Temperature)
+	}
+}
+
+func TestCachedWeatherRepositoryMarksStaleForecast(t *testing.T) {
+	next := &fakeWeatherRepository{}
+	next.temperature.Store(20)
+	repo, advance := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute, StaleTTL: time.Hour})
+	ctx := context.Background()
+
+	forecast, err := repo.GetForecast(ctx, 35.6895, 139.6917)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forecast.Stale {
+		t.Error("fresh forecast is marked stale")
+	}
+
+	// 更新に失敗した後は予報にも Stale を付けて返す
+	next.fail.Store(true)
+	advance(2 * time.Minute)
+	repo.GetForecast(ctx, 35.6895, 139.6917)
+	waitForCalls(t, next, 2)
+	forecast, err = repo.GetForecast(ctx, 35.6895, 139.6917)
+	if err != nil {
+		t.Fatalf("GetForecast after a failed refresh: %v", err)
+	}
+	if !forecast.Stale || forecast.Hourly[0].Temperature != 20 {
+		t.Errorf("forecast = stale %v temperature %v, want stale 20", forecast.Stale, forecast.Hourly[0].Temperature)
+	}
+}
+
+func TestCachedWeatherRepositoryOutageBeyondStaleTTL(t *testing.T) {
+	next := &fakeWeatherRepository{}
+	repo, advance := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute, StaleTTL: time.Hour})
+	ctx := context.Background()
+
+	if _, err := repo.GetByLocation(ctx, 35.6895, 139.6917); err != nil {
+		t.Fatal(err)
+	}
+
+	// StaleTTL を過ぎたデータは返さず、障害をそのまま返す
+	next.fail.Store(true)
+	advance(2 * time.Hour)
+	if _, err := repo.GetByLocation(ctx, 35.6895, 139.6917); !errors.Is(err, repositories.ErrWeatherUnavailable) {
+		t.Errorf("GetByLocation error = %v, want ErrWeatherUnavailable", err)
+	}
+	if stats := repo.Stats(); stats.Errors != 1 {
+		t.Errorf("errors = %d, want 1", stats.Errors)
+	}
+}
+
+func TestCachedWeatherRepositoryMaxEntries(t *testing.T) {
+	next := &fakeWeatherRepository{}
+	repo, _ := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute, MaxEntries: 3})
+
+	for i := 0; i < 10; i++ {
+		if _, err := repo.GetForecast(context.Background(), float64(i), float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := repo.Stats(); stats.Entries != 3 {
+		t.Errorf("entries = %d, want 3", stats.Entries)
+	}
+}
+
+// release が閉じられるまで応答しない天気リポジトリ
+type blockingWeatherRepository struct {
+	fakeWeatherRepository
+	release chan struct{}
+}
+
+func (r *blockingWeatherRepository) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	<-r.release
+	return r.fakeWeatherRepository.GetByLocation(ctx, latitude, longitude)
+}
+
+func TestCachedWeatherRepositoryCoalescesConcurrentRequests(t *testing.T) {
+	next := &blockingWeatherRepository{release: make(chan struct{})}
+	repo, _ := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// 同じセル内の少しずつ異なる地点
+			if _, err := repo.GetByLocation(context.Background(), 35.6895+float64(i)*0.0001, 139.6917); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	if calls := next.calls.Load(); calls != 1 {
+		t.Errorf("upstream calls = %d, want 1 for concurrent requests in the same cell", calls)
+	}
+}
+
+func TestCachedWeatherRepositoryExpiresAfterTTL(t *testing.T) {
+	next := &fakeWeatherRepository{}
+	next.temperature.Store(20)
+	repo, advance := newTestCachedWeatherRepository(next, WeatherCacheOptions{TTL: time.Minute})
+	ctx := context.Background()
+
+	repo.GetByLocation(ctx, 35.6895, 139.6917)
+	next.temperature.Store(25)
+	advance(30 * time.Second)
+	if got, _ := repo.GetByLocation(ctx, 35.6895, 139.6917); got.Temperature != 20 {
+		t.Errorf("temperature within TTL = %v, want the cached 20", got.Temperature)
+	}
+
+	// StaleTTL を設定しない場合は TTL 経過後に取得し直して新しい値を返す
+	advance(time.Minute)
+	got, err := repo.GetByLocation(ctx, 35.6895, 139.6917)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Temperature != 25 || got.Stale {
+		t.Errorf("temperature after TTL = %v (stale %v), want the fresh 25", got.Temperature, got.Stale)
+	}
+
+	// 別のセルは別のエントリ
+	repo.GetByLocation(ctx, 34.6937, 135.5023)
+	if stats := repo.Stats(); stats.Entries != 2 || stats.Misses != 3 || stats.Hits != 1 {
+		t.Errorf("stats = %+v, want 2 entries, 3 misses and 1 hit", stats)
+	}
+}
@@ -3,20 +3,27 @@ package repositories
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
 )
 
 type InMemoryClothingRepository struct {
 	clothing map[string]*entities.ClothingItem
 	mutex    sync.RWMutex
+
+	// wearLogs 最後に着用した日の順で並べる際に参照する着用記録（nil の場合は全て未着用として扱う）
+	wearLogs repositories.WearLogRepository
 }
 
 // NewInMemoryClothingRepository インメモリの衣服リポジトリを初期化します
-func NewInMemoryClothingRepository() *InMemoryClothingRepository {
+func NewInMemoryClothingRepository(wearLogs repositories.WearLogRepository) *InMemoryClothingRepository {
 	return &InMemoryClothingRepository{
 		clothing: make(map[string]*entities.ClothingItem),
+		wearLogs: wearLogs,
 	}
 }
 
@@ -26,10 +33,16 @@ func (r *InMemoryClothingRepository) Create(item *entities.ClothingItem) error {
 	defer r.mutex.Unlock()
 
 	if item.ID == "" {
-		item.ID = fmt.Sprintf("clothing_%d", len(r.clothing)+1)
+		item.ID = entities.NewID()
+	}
+
+	if _, exists := r.clothing[item.ID]; exists {
+		return fmt.Errorf("clothing item %s: %w", item.ID, repositories.ErrDuplicateKey)
 	}
 
-	r.clothing[item.ID] = item
+	item.Version = 1
+	stored := *item
+	r.clothing[item.ID] = &stored
 	return nil
 }
 
@@ -63,16 +76,77 @@ func (r *InMemoryClothingRepository) GetByUserID(userID string) ([]*entities.Clo
 	return userClothing, nil
 }
 
+// Query 検索条件に一致する衣服アイテムを1ページ分取得します
+func (r *InMemoryClothingRepository) Query(query repositories.ClothingQuery) (*repositories.ClothingPage, error) {
+	var lastWorn map[string]time.Time
+	if query.Sort == repositories.ClothingSortLastWorn && r.wearLogs != nil {
+		var err error
+		if lastWorn, err = r.wearLogs.GetLastWornByUserID(query.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	type candidate struct {
+		item *entities.ClothingItem
+		key  repositories.ClothingCursor
+	}
+	// 並び順での比較結果（降順の場合は反転）
+	compare := func(a, b repositories.ClothingCursor) int {
+		if query.Descending {
+			return query.Sort.Compare(b, a)
+		}
+		return query.Sort.Compare(a, b)
+	}
+
+	var candidates []candidate
+	for _, item := range r.clothing {
+		if !query.Matches(item) {
+			continue
+		}
+		key := repositories.ClothingCursor{ID: item.ID, Name: item.Name, WarmthLevel: item.WarmthLevel, LastWornOn: lastWorn[item.ID]}
+		if query.After != nil && compare(key, *query.After) <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{item: item, key: key})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return compare(candidates[i].key, candidates[j].key) < 0
+	})
+
+	page := &repositories.ClothingPage{Items: []*entities.ClothingItem{}}
+	for i, c := range candidates {
+		if query.Limit > 0 && i == query.Limit {
+			next := candidates[i-1].key
+			page.Next = &next
+			break
+		}
+		itemCopy := *c.item
+		page.Items = append(page.Items, &itemCopy)
+	}
+	return page, nil
+}
+
 // Update 既存の衣服アイテム情報を更新します
 func (r *InMemoryClothingRepository) Update(item *entities.ClothingItem) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.clothing[item.ID]; !exists {
+	current, exists := r.clothing[item.ID]
+	if !exists {
 		return errors.New("clothing item not found")
 	}
 
-	r.clothing[item.ID] = item
+	// 楽観的排他制御: 読み込み時点から他の更新が入っていれば拒否
+	if current.Version != item.Version {
+		return &repositories.ConflictError{Entity: "clothing item", ID: item.ID, ExpectedVersion: item.Version, CurrentVersion: current.Version}
+	}
+
+	item.Version++
+	stored := *item
+	r.clothing[item.ID] = &stored
 	return nil
 }
 
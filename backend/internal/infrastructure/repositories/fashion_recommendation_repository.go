@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
 )
 
 type InMemoryFashionRecommendationRepository struct {
@@ -26,10 +27,16 @@ func (r *InMemoryFashionRecommendationRepository) Create(recommendation *entitie
 	defer r.mutex.Unlock()
 
 	if recommendation.ID == "" {
-		recommendation.ID = fmt.Sprintf("recommendation_%d", len(r.recommendations)+1)
+		recommendation.ID = entities.NewID()
 	}
 
-	r.recommendations[recommendation.ID] = recommendation
+	if _, exists := r.recommendations[recommendation.ID]; exists {
+		return fmt.Errorf("fashion recommendation %s: %w", recommendation.ID, repositories.ErrDuplicateKey)
+	}
+
+	recommendation.Version = 1
+	stored := *recommendation
+	r.recommendations[recommendation.ID] = &stored
 	return nil
 }
 
@@ -69,11 +76,19 @@ func (r *InMemoryFashionRecommendationRepository) Update(recommendation *entitie
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.recommendations[recommendation.ID]; !exists {
+	current, exists := r.recommendations[recommendation.ID]
+	if !exists {
 		return errors.New("fashion recommendation not found")
 	}
 
-	r.recommendations[recommendation.ID] = recommendation
+	// 楽観的排他制御: 読み込み時点から他の更新が入っていれば拒否
+	if current.Version != recommendation.Version {
+		return &repositories.ConflictError{Entity: "fashion recommendation", ID: recommendation.ID, ExpectedVersion: recommendation.Version, CurrentVersion: current.Version}
+	}
+
+	recommendation.Version++
+	stored := *recommendation
+	r.recommendations[recommendation.ID] = &stored
 	return nil
 }
 
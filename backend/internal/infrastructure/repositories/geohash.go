@@ -0,0 +1,46 @@
+package repositories
+
+// geohash で使用する base32 文字セット
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// 緯度経度を指定桁数の geohash 文字列に変換
+// 桁数ごとのおおよそのセルサイズ: 4桁≒39km, 5桁≒4.9km, 6桁≒1.2km
+func encodeGeohash(latitude, longitude float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	hash := make([]byte, 0, precision)
+	bit, ch := 0, 0
+	even := true // 経度から交互にビットを割り当てる
+
+	for len(hash) < precision {
+		if even {
+			mid := (lonRange[0] + lonRange[1]) / 2
+			if longitude >= mid {
+				ch = ch<<1 | 1
+				lonRange[0] = mid
+			} else {
+				ch <<= 1
+				lonRange[1] = mid
+			}
+		} else {
+			mid := (latRange[0] + latRange[1]) / 2
+			if latitude >= mid {
+				ch = ch<<1 | 1
+				latRange[0] = mid
+			} else {
+				ch <<= 1
+				latRange[1] = mid
+			}
+		}
+		even = !even
+
+		bit++
+		if bit == 5 {
+			hash = append(hash, geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return string(hash)
+}
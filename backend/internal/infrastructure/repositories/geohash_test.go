@@ -0,0 +1,21 @@
+package repositories
+
+import "testing"
+
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		latitude, longitude float64
+		precision           int
+		want                string
+	}{
+		{57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{35.6895, 139.6917, 5, "xn774"},
+		{-33.8688, 151.2093, 5, "r3gx2"},
+		{0, 0, 4, "s000"},
+	}
+	for _, tt := range tests {
+		if got := encodeGeohash(tt.latitude, tt.longitude, tt.precision); got != tt.want {
+			t.Errorf("encodeGeohash(%v, %v, %d) = %q, want %q", tt.latitude, tt.longitude, tt.precision, got, tt.want)
+		}
+	}
+}
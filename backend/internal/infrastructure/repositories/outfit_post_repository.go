@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
 )
 
 type InMemoryOutfitPostRepository struct {
@@ -24,10 +25,16 @@ func (r *InMemoryOutfitPostRepository) Create(post *entities.OutfitPost) error {
 	defer r.mutex.Unlock()
 
 	if post.ID == "" {
-		post.ID = fmt.Sprintf("outfit_%d", len(r.outfitPosts)+1)
+		post.ID = entities.NewID()
 	}
 
-	r.outfitPosts[post.ID] = post
+	if _, exists := r.outfitPosts[post.ID]; exists {
+		return fmt.Errorf("outfit post %s: %w", post.ID, repositories.ErrDuplicateKey)
+	}
+
+	post.Version = 1
+	stored := *post
+	r.outfitPosts[post.ID] = &stored
 	return nil
 }
 
@@ -76,11 +83,19 @@ func (r *InMemoryOutfitPostRepository) Update(post *entities.OutfitPost) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.outfitPosts[post.ID]; !exists {
+	current, exists := r.outfitPosts[post.ID]
+	if !exists {
 		return errors.New("outfit post not found")
 	}
 
-	r.outfitPosts[post.ID] = post
+	// 楽観的排他制御: 読み込み時点から他の更新が入っていれば拒否
+	if current.Version != post.Version {
+		return &repositories.ConflictError{Entity: "outfit post", ID: post.ID, ExpectedVersion: post.Version, CurrentVersion: current.Version}
+	}
+
+	post.Version++
+	stored := *post
+	r.outfitPosts[post.ID] = &stored
 	return nil
 }
 
@@ -0,0 +1,280 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// 衣服リポジトリのSQL実装
+type SQLClothingRepository struct {
+	db database.Executor
+}
+
+// NewSQLClothingRepository SQL衣服リポジトリを初期化します
+func NewSQLClothingRepository(db database.Executor) *SQLClothingRepository {
+	return &SQLClothingRepository{db: db}
+}
+
+const clothingColumns = `id, user_id, name, type, color, category, brand, warmth_level, seasons, material, waterproof, windproof, breathability, formality, size, notes, image_url, thumbnail_url, availability, availability_changed_at, created_at, version`
+
+// Create 新しい衣服アイテムをリポジトリに追加します
+func (r *SQLClothingRepository) Create(item *entities.ClothingItem) error {
+	if item.ID == "" {
+		item.ID = entities.NewID()
+	}
+
+	seasons, err := encodeSeasons(item.Seasons)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(r.db.Rebind(`INSERT INTO clothing_items (`+clothingColumns+`, color_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		item.ID, item.UserID, item.Name, item.Type, item.Color, item.Category, item.Brand, item.WarmthLevel,
+		seasons, item.Material, item.Waterproof, item.Windproof, item.Breathability, item.Formality, item.Size, item.Notes,
+		item.ImageURL, item.ThumbnailURL, string(item.CurrentAvailability()), nullableTime(item.AvailabilityChangedAt), item.CreatedAt, 1,
+		entities.ColorKey(item.Color),
+	)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return fmt.Errorf("衣服アイテムの保存に失敗しました: %w", repositories.ErrDuplicateKey)
+		}
+		return fmt.Errorf("衣服アイテムの保存に失敗しました: %w", err)
+	}
+	item.Version = 1
+	return nil
+}
+
+// GetByID 指定したIDの衣服アイテムを取得します
+func (r *SQLClothingRepository) GetByID(id string) (*entities.ClothingItem, error) {
+	row := r.db.QueryRow(r.db.Rebind(`SELECT `+clothingColumns+` FROM clothing_items WHERE id = ?`), id)
+	return scanClothingItem(row)
+}
+
+// GetByUserID 指定したユーザーの全ての衣服アイテムを取得します
+func (r *SQLClothingRepository) GetByUserID(userID string) ([]*entities.ClothingItem, error) {
+	rows, err := r.db.Query(r.db.Rebind(`SELECT `+clothingColumns+` FROM clothing_items WHERE user_id = ? ORDER BY created_at`), userID)
+	if err != nil {
+		return nil, fmt.Errorf("衣服アイテムの取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var userClothing []*entities.ClothingItem
+	for rows.Next() {
+		item, err := scanClothingItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		userClothing = append(userClothing, item)
+	}
+	return userClothing, rows.Err()
+}
+
+// Query 検索条件・並び替え・ページ分割を SQL で行い、衣服アイテムを1ページ分取得します
+func (r *SQLClothingRepository) Query(query repositories.ClothingQuery) (*repositories.ClothingPage, error) {
+	var b sqlConditions
+	b.add(`user_id = ?`, query.UserID)
+	if len(query.Categories) > 0 {
+		b.addIn(`category`, stringArgs(query.Categories))
+	}
+	if len(query.Colors) > 0 {
+		// 色は保存時に標準パレットの識別名に変換した color_key で照合する
+		b.addIn(`color_key`, stringArgs(query.Colors))
+	}
+	if len(query.Brands) > 0 {
+		brands := make([]string, len(query.Brands))
+		for i, brand := range query.Brands {
+			brands[i] = strings.ToLower(brand)
+		}
+		b.addIn(`LOWER(brand)`, stringArgs(brands))
+	}
+	if query.MinWarmth > 0 {
+		b.add(`warmth_level >= ?`, query.MinWarmth)
+	}
+	if query.MaxWarmth > 0 {
+		b.add(`warmth_level <= ?`, query.MaxWarmth)
+	}
+	if query.Season != "" {
+		// JSON 配列を文字列として照合（未設定の空配列は通年）
+		b.add(`(CAST(seasons AS TEXT) = '[]' OR CAST(seasons AS TEXT) LIKE ?)`, `%"`+string(query.Season)+`"%`)
+	}
+	if len(query.Availabilities) > 0 {
+		availabilities := make([]interface{}, len(query.Availabilities))
+		for i, a := range query.Availabilities {
+			availabilities[i] = string(a)
+		}
+		if query.LaundryReturnCutoff.IsZero() {
+			b.addIn(`availability`, availabilities)
+		} else {
+			b.addIn(`(CASE WHEN availability = ? AND (availability_changed_at IS NULL OR availability_changed_at <= ?) THEN ? ELSE availability END)`,
+				availabilities, string(entities.AvailabilityLaundry), query.LaundryReturnCutoff, string(entities.AvailabilityAvailable))
+		}
+	}
+	if query.Text != "" {
+		pattern := "%" + escapeLike(strings.ToLower(query.Text)) + "%"
+		b.add(`(LOWER(name) LIKE ? ESCAPE '\' OR LOWER(brand) LIKE ? ESCAPE '\')`, pattern, pattern)
+	}
+
+	// 並び替えキーと、前のページの最後のアイテムより後ろのアイテムに絞り込む条件
+	from := `clothing_items`
+	var sortKey string
+	var sortArgs []interface{}
+	var after interface{}
+	switch query.Sort {
+	case repositories.ClothingSortName:
+		sortKey = `name`
+		if query.After != nil {
+			after = query.After.Name
+		}
+	case repositories.ClothingSortWarmth:
+		sortKey = `warmth_level`
+		if query.After != nil {
+			after = query.After.WarmthLevel
+		}
+	case repositories.ClothingSortLastWorn:
+		from += ` LEFT JOIN (SELECT clothing_id, MAX(worn_on) AS last_worn_on FROM wear_logs GROUP BY clothing_id) w ON w.clothing_id = id`
+		sortKey = `COALESCE(w.last_worn_on, ?)`
+		sortArgs = []interface{}{time.Time{}}
+		if query.After != nil {
+			after = query.After.LastWornOn
+		}
+	}
+
+	direction, comparison := `ASC`, `>`
+	if query.Descending {
+		direction, comparison = `DESC`, `<`
+	}
+	if query.After != nil {
+		if sortKey == "" {
+			b.add(`id `+comparison+` ?`, query.After.ID)
+		} else {
+			args := append(append(append([]interface{}{}, sortArgs...), after), sortArgs...)
+			args = append(args, after, query.After.ID)
+			b.add(`(`+sortKey+` `+comparison+` ? OR (`+sortKey+` = ? AND id `+comparison+` ?))`, args...)
+		}
+	}
+
+	statement := `SELECT ` + clothingColumns + ` FROM ` + from + ` WHERE ` + strings.Join(b.clauses, ` AND `) + ` ORDER BY `
+	args := b.args
+	if sortKey != "" {
+		statement += sortKey + ` ` + direction + `, `
+		args = append(args, sortArgs...)
+	}
+	statement += `id ` + direction
+	if query.Limit > 0 {
+		// 次のページの有無を判定するため1件多く取得する
+		statement += ` LIMIT ?`
+		args = append(args, query.Limit+1)
+	}
+
+	rows, err := r.db.Query(r.db.Rebind(statement), args...)
+	if err != nil {
+		return nil, fmt.Errorf("衣服アイテムの検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	page := &repositories.ClothingPage{Items: []*entities.ClothingItem{}}
+	for rows.Next() {
+		item, err := scanClothingItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("衣服アイテムの検索に失敗しました: %w", err)
+	}
+
+	if query.Limit > 0 && len(page.Items) > query.Limit {
+		page.Items = page.Items[:query.Limit]
+		last := page.Items[len(page.Items)-1]
+		page.Next = &repositories.ClothingCursor{ID: last.ID, Name: last.Name, WarmthLevel: last.WarmthLevel}
+		if query.Sort == repositories.ClothingSortLastWorn {
+			if page.Next.LastWornOn, err = r.lastWornOn(last.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return page, nil
+}
+
+// アイテムを最後に着用した日（着用記録がない場合はゼロ値）
+func (r *SQLClothingRepository) lastWornOn(clothingID string) (time.Time, error) {
+	var wornOn time.Time
+	err := r.db.QueryRow(r.db.Rebind(`SELECT worn_on FROM wear_logs WHERE clothing_id = ? ORDER BY worn_on DESC LIMIT 1`), clothingID).Scan(&wornOn)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("着用記録の取得に失敗しました: %w", err)
+	}
+	return wornOn.UTC(), nil
+}
+
+// Update 既存の衣服アイテム情報を更新します
+func (r *SQLClothingRepository) Update(item *entities.ClothingItem) error {
+	seasons, err := encodeSeasons(item.Seasons)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(r.db.Rebind(`UPDATE clothing_items SET user_id = ?, name = ?, type = ?, color = ?, category = ?, brand = ?, warmth_level = ?, seasons = ?, material = ?, waterproof = ?, windproof = ?, breathability = ?, formality = ?, size = ?, notes = ?, image_url = ?, thumbnail_url = ?, availability = ?, availability_changed_at = ?, color_key = ?, version = version + 1 WHERE id = ? AND version = ?`),
+		item.UserID, item.Name, item.Type, item.Color, item.Category, item.Brand, item.WarmthLevel,
+		seasons, item.Material, item.Waterproof, item.Windproof, item.Breathability, item.Formality, item.Size, item.Notes,
+		item.ImageURL, item.ThumbnailURL, string(item.CurrentAvailability()), nullableTime(item.AvailabilityChangedAt), entities.ColorKey(item.Color), item.ID, item.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("衣服アイテムの更新に失敗しました: %w", err)
+	}
+	if err := requireVersionedUpdate(r.db, result, "clothing_items", "clothing item", item.ID, item.Version, "clothing item not found"); err != nil {
+		return err
+	}
+
+	item.Version++
+	return nil
+}
+
+// Delete 指定したIDの衣服アイテムを削除します
+func (r *SQLClothingRepository) Delete(id string) error {
+	result, err := r.db.Exec(r.db.Rebind(`DELETE FROM clothing_items WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("衣服アイテムの削除に失敗しました: %w", err)
+	}
+	return requireAffected(result, "clothing item not found")
+}
+
+// 季節をJSONカラム用にエンコード（未設定は通年として空配列で保存）
+func encodeSeasons(seasons []entities.Season) (string, error) {
+	if seasons == nil {
+		seasons = []entities.Season{}
+	}
+	return toJSONColumn(seasons)
+}
+
+// 1行分の衣服データをエンティティに変換
+func scanClothingItem(row rowScanner) (*entities.ClothingItem, error) {
+	var item entities.ClothingItem
+	var seasons, availability string
+	var availabilityChangedAt sql.NullTime
+	err := row.Scan(&item.ID, &item.UserID, &item.Name, &item.Type, &item.Color, &item.Category, &item.Brand, &item.WarmthLevel,
+		&seasons, &item.Material, &item.Waterproof, &item.Windproof, &item.Breathability, &item.Formality, &item.Size, &item.Notes,
+		&item.ImageURL, &item.ThumbnailURL, &availability, &availabilityChangedAt, &item.CreatedAt, &item.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("clothing item not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("衣服アイテムの読み込みに失敗しました: %w", err)
+	}
+	if err := fromJSONColumn(seasons, &item.Seasons); err != nil {
+		return nil, err
+	}
+	item.Availability = entities.Availability(availability)
+	item.AvailabilityChangedAt = availabilityChangedAt.Time
+	return &item, nil
+}
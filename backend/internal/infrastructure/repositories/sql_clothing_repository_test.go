@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// テスト用の SQLite データベース（マイグレーション適用済み）
+func openTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.Open(string(database.DialectSQLite), filepath.Join(t.TempDir(), "test.db"), database.PoolOptions{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func createTestItems(t *testing.T, repo repositories.ClothingRepository, userID string, colors ...string) map[string]string {
+	t.Helper()
+	ids := make(map[string]string)
+	for _, color := range colors {
+		item, err := entities.NewClothingItem(userID, color+" shirt", "シャツ", color, string(entities.CategoryTops))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Create(item); err != nil {
+			t.Fatalf("Create(%q): %v", color, err)
+		}
+		ids[item.ID] = color
+	}
+	return ids
+}
+
+func queryColors(t *testing.T, repo repositories.ClothingRepository, userID string, colors ...string) []string {
+	t.Helper()
+	page, err := repo.Query(repositories.ClothingQuery{UserID: userID, Colors: colors, Sort: repositories.ClothingSortCreated})
+	if err != nil {
+		t.Fatalf("Query(%v): %v", colors, err)
+	}
+	var got []string
+	for _, item := range page.Items {
+		got = append(got, item.Color)
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestSQLClothingRepositoryColorFilter(t *testing.T) {
+	repo := NewSQLClothingRepository(openTestDB(t))
+	createTestItems(t, repo, "user-1", "#1F2A4D", "Navy Blue", "ネイビー", "NAVY", "titanium", "Tan")
+	createTestItems(t, repo, "user-2", "navy")
+
+	// "#RRGGBB" や大文字・日本語の色名も保存時に変換した標準パレットの色で照合する
+	if got, want := queryColors(t, repo, "user-1", "navy"), []string{"#1F2A4D", "NAVY", "Navy Blue", "ネイビー"}; !equalStrings(got, want) {
+		t.Errorf("navy items = %v, want %v", got, want)
+	}
+	if got, want := queryColors(t, repo, "user-1", "brown"), []string{"Tan"}; !equalStrings(got, want) {
+		t.Errorf("brown items = %v, want %v", got, want)
+	}
+	if got := queryColors(t, repo, "user-1", "gray"); len(got) != 0 {
+		t.Errorf("gray items = %v, want none (titanium is not a colour name)", got)
+	}
+}
+
+func TestSQLClothingRepositoryUpdateRefreshesColorKey(t *testing.T) {
+	repo := NewSQLClothingRepository(openTestDB(t))
+	ids := createTestItems(t, repo, "user-1", "red")
+
+	for id := range ids {
+		item, err := repo.GetByID(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		item.Color = "#1e5bc6"
+		if err := repo.Update(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := queryColors(t, repo, "user-1", "red"); len(got) != 0 {
+		t.Errorf("red items after update = %v, want none", got)
+	}
+	if got := queryColors(t, repo, "user-1", "blue"); len(got) != 1 {
+		t.Errorf("blue items after update = %v, want 1", got)
+	}
+}
+
+func TestSQLClothingRepositoryQueryWithoutLimitReturnsAll(t *testing.T) {
+	repo := NewSQLClothingRepository(openTestDB(t))
+	colors := make([]string, 60)
+	for i := range colors {
+		colors[i] = "white"
+	}
+	createTestItems(t, repo, "user-1", colors...)
+
+	page, err := repo.Query(repositories.ClothingQuery{UserID: "user-1", Sort: repositories.ClothingSortCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 60 || page.Next != nil {
+		t.Errorf("items = %d next = %v, want all 60 items without a next page", len(page.Items), page.Next)
+	}
+
+	page, err = repo.Query(repositories.ClothingQuery{UserID: "user-1", Sort: repositories.ClothingSortCreated, Limit: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 25 || page.Next == nil {
+		t.Errorf("items = %d next = %v, want 25 items and a next page", len(page.Items), page.Next)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// ファッション推奨リポジトリのSQL実装
+type SQLFashionRecommendationRepository struct {
+	db database.Executor
+}
+
+// NewSQLFashionRecommendationRepository SQLファッション推奨リポジトリを初期化します
+func NewSQLFashionRecommendationRepository(db database.Executor) *SQLFashionRecommendationRepository {
+	return &SQLFashionRecommendationRepository{db: db}
+}
+
+const recommendationColumns = `id, user_id, style, strategy, items, outfits, weather, reason, location, day_plan, advisories, feedback, created_at, version`
+
+// Create 新しいファッション推奨をリポジトリに追加します
+func (r *SQLFashionRecommendationRepository) Create(recommendation *entities.FashionRecommendation) error {
+	if recommendation.ID == "" {
+		recommendation.ID = entities.NewID()
+	}
+
+	columns, err := encodeRecommendationColumns(recommendation)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(r.db.Rebind(`INSERT INTO fashion_recommendations (`+recommendationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		recommendation.ID, recommendation.UserID, recommendation.Style, recommendation.Strategy, columns.items, columns.outfits, columns.weather, recommendation.Reason, recommendation.Location, columns.dayPlan, columns.advisories, columns.feedback, recommendation.CreatedAt, 1,
+	)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return fmt.Errorf("ファッション推奨の保存に失敗しました: %w", repositories.ErrDuplicateKey)
+		}
+		return fmt.Errorf("ファッション推奨の保存に失敗しました: %w", err)
+	}
+	recommendation.Version = 1
+	return nil
+}
+
+// GetByID 指定したIDのファッション推奨を取得します
+func (r *SQLFashionRecommendationRepository) GetByID(id string) (*entities.FashionRecommendation, error) {
+	row := r.db.QueryRow(r.db.Rebind(`SELECT `+recommendationColumns+` FROM fashion_recommendations WHERE id = ?`), id)
+	return scanRecommendation(row)
+}
+
+// GetByUserID 指定したユーザーの全てのファッション推奨を取得します
+func (r *SQLFashionRecommendationRepository) GetByUserID(userID string) ([]*entities.FashionRecommendation, error) {
+	rows, err := r.db.Query(r.db.Rebind(`SELECT `+recommendationColumns+` FROM fashion_recommendations WHERE user_id = ? ORDER BY created_at DESC`), userID)
+	if err != nil {
+		return nil, fmt.Errorf("ファッション推奨の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var userRecommendations []*entities.FashionRecommendation
+	for rows.Next() {
+		recommendation, err := scanRecommendation(rows)
+		if err != nil {
+			return nil, err
+		}
+		userRecommendations = append(userRecommendations, recommendation)
+	}
+	return userRecommendations, rows.Err()
+}
+
+// Update 既存のファッション推奨を更新します
+func (r *SQLFashionRecommendationRepository) Update(recommendation *entities.FashionRecommendation) error {
+	columns, err := encodeRecommendationColumns(recommendation)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(r.db.Rebind(`UPDATE fashion_recommendations SET user_id = ?, style = ?, strategy = ?, items = ?, outfits = ?, weather = ?, reason = ?, location = ?, day_plan = ?, advisories = ?, feedback = ?, version = version + 1 WHERE id = ? AND version = ?`),
+		recommendation.UserID, recommendation.Style, recommendation.Strategy, columns.items, columns.outfits, columns.weather, recommendation.Reason, recommendation.Location, columns.dayPlan, columns.advisories, columns.feedback, recommendation.ID, recommendation.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("ファッション推奨の更新に失敗しました: %w", err)
+	}
+	if err := requireVersionedUpdate(r.db, result, "fashion_recommendations", "fashion recommendation", recommendation.ID, recommendation.Version, "fashion recommendation not found"); err != nil {
+		return err
+	}
+
+	recommendation.Version++
+	return nil
+}
+
+// Delete 指定したIDのファッション推奨を削除します
+func (r *SQLFashionRecommendationRepository) Delete(id string) error {
+	result, err := r.db.Exec(r.db.Rebind(`DELETE FROM fashion_recommendations WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("ファッション推奨の削除に失敗しました: %w", err)
+	}
+	return requireAffected(result, "fashion recommendation not found")
+}
+
+// JSONで保存するカラムのエンコード結果
+type recommendationJSONColumns struct {
+	items, outfits, weather, dayPlan, advisories, feedback string
+}
+
+// JSONで保存するカラムをエンコード
+func encodeRecommendationColumns(recommendation *entities.FashionRecommendation) (columns recommendationJSONColumns, err error) {
+	if columns.items, err = toJSONColumn(recommendation.Items); err != nil {
+		return columns, err
+	}
+	if columns.outfits, err = toJSONColumn(recommendation.Outfits); err != nil {
+		return columns, err
+	}
+	if columns.weather, err = toJSONColumn(recommendation.Weather); err != nil {
+		return columns, err
+	}
+	if columns.dayPlan, err = toJSONColumn(recommendation.DayPlan); err != nil {
+		return columns, err
+	}
+	if columns.advisories, err = toJSONColumn(recommendation.Advisories); err != nil {
+		return columns, err
+	}
+	if columns.feedback, err = toJSONColumn(recommendation.Feedback); err != nil {
+		return columns, err
+	}
+	return columns, nil
+}
+
+// 1行分のファッション推奨データをエンティティに変換
+func scanRecommendation(row rowScanner) (*entities.FashionRecommendation, error) {
+	var recommendation entities.FashionRecommendation
+	var items, outfits, weather, dayPlan, advisories, feedback string
+
+	err := row.Scan(&recommendation.ID, &recommendation.UserID, &recommendation.Style, &recommendation.Strategy, &items, &outfits, &weather, &recommendation.Reason, &recommendation.Location, &dayPlan, &advisories, &feedback, &recommendation.CreatedAt, &recommendation.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("fashion recommendation not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ファッション推奨の読み込みに失敗しました: %w", err)
+	}
+
+	if err := fromJSONColumn(items, &recommendation.Items); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(outfits, &recommendation.Outfits); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(weather, &recommendation.Weather); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(dayPlan, &recommendation.DayPlan); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(advisories, &recommendation.Advisories); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(feedback, &recommendation.Feedback); err != nil {
+		return nil, err
+	}
+	return &recommendation, nil
+}
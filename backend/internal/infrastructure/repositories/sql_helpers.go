@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// 行スキャン用の共通インターフェース（*sql.Row と *sql.Rows の両方を受け付ける）
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// 構造体やスライスをJSONカラム用の文字列に変換
+func toJSONColumn(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("JSONカラムのエンコードに失敗しました: %w", err)
+	}
+	return string(data), nil
+}
+
+// JSONカラムの文字列を構造体やスライスに復元
+func fromJSONColumn(data string, v interface{}) error {
+	if data == "" || data == "null" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return fmt.Errorf("JSONカラムのデコードに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// 未設定（ゼロ値）の日時を NULL として保存するための変換
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// 更新・削除対象の行が存在したかを確認し、存在しない場合は notFound をエラーとして返す
+func requireAffected(result sql.Result, notFound string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("更新件数の取得に失敗しました: %w", err)
+	}
+	if affected == 0 {
+		return errors.New(notFound)
+	}
+	return nil
+}
+
+// バージョン条件付き UPDATE の結果を確認
+// 対象行が更新されなかった場合、行が存在しなければ notFound、存在すればバージョン競合としてエラーを返す
+func requireVersionedUpdate(db database.Executor, result sql.Result, table, entity, id string, expectedVersion int, notFound string) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("更新件数の取得に失敗しました: %w", err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var currentVersion int
+	err = db.QueryRow(db.Rebind(`SELECT version FROM `+table+` WHERE id = ?`), id).Scan(&currentVersion)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New(notFound)
+	}
+	if err != nil {
+		return fmt.Errorf("バージョンの取得に失敗しました: %w", err)
+	}
+
+	return &repositories.ConflictError{Entity: entity, ID: id, ExpectedVersion: expectedVersion, CurrentVersion: currentVersion}
+}
+
+// AND で結合する WHERE 句の条件とプレースホルダーの値
+type sqlConditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+// 条件を追加
+func (c *sqlConditions) add(clause string, args ...interface{}) {
+	c.clauses = append(c.clauses, clause)
+	c.args = append(c.args, args...)
+}
+
+// "expr IN (?, ?, ...)" の条件を追加（exprArgs は expr 内のプレースホルダーの値）
+func (c *sqlConditions) addIn(expr string, values []interface{}, exprArgs ...interface{}) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+	c.add(expr+` IN (`+placeholders+`)`, append(append([]interface{}{}, exprArgs...), values...)...)
+}
+
+// 文字列のスライスをプレースホルダーの値に変換
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// LIKE のパターンで特別な意味を持つ文字をエスケープ（ESCAPE '\' と併用する）
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
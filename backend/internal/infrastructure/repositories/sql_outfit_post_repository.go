@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// outfit投稿リポジトリのSQL実装
+type SQLOutfitPostRepository struct {
+	db database.Executor
+}
+
+func NewSQLOutfitPostRepository(db database.Executor) *SQLOutfitPostRepository {
+	return &SQLOutfitPostRepository{db: db}
+}
+
+const outfitPostColumns = `id, user_id, user_name, items, description, tags, weather, temperature, location, image_url, thumbnail_url, likes, created_at, version`
+
+func (r *SQLOutfitPostRepository) Create(post *entities.OutfitPost) error {
+	if post.ID == "" {
+		post.ID = entities.NewID()
+	}
+
+	items, tags, weather, err := encodeOutfitPostColumns(post)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(r.db.Rebind(`INSERT INTO outfit_posts (`+outfitPostColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		post.ID, post.UserID, post.UserName, items, post.Description, tags, weather, post.Temperature, post.Location, post.ImageURL, post.ThumbnailURL, post.Likes, post.CreatedAt, 1,
+	)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return fmt.Errorf("outfit投稿の保存に失敗しました: %w", repositories.ErrDuplicateKey)
+		}
+		return fmt.Errorf("outfit投稿の保存に失敗しました: %w", err)
+	}
+	post.Version = 1
+	return nil
+}
+
+func (r *SQLOutfitPostRepository) GetByID(id string) (*entities.OutfitPost, error) {
+	row := r.db.QueryRow(r.db.Rebind(`SELECT `+outfitPostColumns+` FROM outfit_posts WHERE id = ?`), id)
+	return scanOutfitPost(row)
+}
+
+func (r *SQLOutfitPostRepository) GetByUserID(userID string) ([]*entities.OutfitPost, error) {
+	return r.queryOutfitPosts(`SELECT `+outfitPostColumns+` FROM outfit_posts WHERE user_id = ? ORDER BY created_at DESC`, userID)
+}
+
+func (r *SQLOutfitPostRepository) GetAll() ([]*entities.OutfitPost, error) {
+	return r.queryOutfitPosts(`SELECT ` + outfitPostColumns + ` FROM outfit_posts ORDER BY created_at DESC`)
+}
+
+func (r *SQLOutfitPostRepository) Update(post *entities.OutfitPost) error {
+	items, tags, weather, err := encodeOutfitPostColumns(post)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(r.db.Rebind(`UPDATE outfit_posts SET user_id = ?, user_name = ?, items = ?, description = ?, tags = ?, weather = ?, temperature = ?, location = ?, image_url = ?, thumbnail_url = ?, likes = ?, version = version + 1 WHERE id = ? AND version = ?`),
+		post.UserID, post.UserName, items, post.Description, tags, weather, post.Temperature, post.Location, post.ImageURL, post.ThumbnailURL, post.Likes, post.ID, post.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("outfit投稿の更新に失敗しました: %w", err)
+	}
+	if err := requireVersionedUpdate(r.db, result, "outfit_posts", "outfit post", post.ID, post.Version, "outfit post not found"); err != nil {
+		return err
+	}
+
+	post.Version++
+	return nil
+}
+
+func (r *SQLOutfitPostRepository) Delete(id string) error {
+	result, err := r.db.Exec(r.db.Rebind(`DELETE FROM outfit_posts WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("outfit投稿の削除に失敗しました: %w", err)
+	}
+	return requireAffected(result, "outfit post not found")
+}
+
+// 複数行のoutfit投稿を取得
+func (r *SQLOutfitPostRepository) queryOutfitPosts(query string, args ...interface{}) ([]*entities.OutfitPost, error) {
+	rows, err := r.db.Query(r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("outfit投稿の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*entities.OutfitPost
+	for rows.Next() {
+		post, err := scanOutfitPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// JSONで保存するカラムをエンコード
+func encodeOutfitPostColumns(post *entities.OutfitPost) (items, tags, weather string, err error) {
+	if items, err = toJSONColumn(post.Items); err != nil {
+		return "", "", "", err
+	}
+	if tags, err = toJSONColumn(post.Tags); err != nil {
+		return "", "", "", err
+	}
+	if weather, err = toJSONColumn(post.Weather); err != nil {
+		return "", "", "", err
+	}
+	return items, tags, weather, nil
+}
+
+// 1行分のoutfit投稿データをエンティティに変換
+func scanOutfitPost(row rowScanner) (*entities.OutfitPost, error) {
+	var post entities.OutfitPost
+	var items, tags, weather string
+
+	err := row.Scan(&post.ID, &post.UserID, &post.UserName, &items, &post.Description, &tags, &weather, &post.Temperature, &post.Location, &post.ImageURL, &post.ThumbnailURL, &post.Likes, &post.CreatedAt, &post.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("outfit post not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("outfit投稿の読み込みに失敗しました: %w", err)
+	}
+
+	if err := fromJSONColumn(items, &post.Items); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(tags, &post.Tags); err != nil {
+		return nil, err
+	}
+	if err := fromJSONColumn(weather, &post.Weather); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+func TestSQLUserRepository(t *testing.T) {
+	repo := NewSQLUserRepository(openTestDB(t))
+	now := time.Now().UTC().Truncate(time.Second)
+	user := &entities.User{
+		Name:          "山田",
+		Email:         "yamada@example.com",
+		Password:      "hashed",
+		Preferences:   &entities.UserPreferences{Colors: []string{"navy"}, Style: "casual"},
+		ComfortOffset: 1.5,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if user.ID == "" || user.Version != 1 {
+		t.Fatalf("created user = id %q version %d, want an ID and version 1", user.ID, user.Version)
+	}
+
+	got, err := repo.GetByEmail("yamada@example.com")
+	if err != nil {
+		t.Fatalf("GetByEmail: %v", err)
+	}
+	if got.ID != user.ID || got.ComfortOffset != 1.5 || got.Preferences == nil || got.Preferences.Style != "casual" || len(got.Preferences.Colors) != 1 {
+		t.Errorf("GetByEmail = %+v (preferences %+v), want the stored user", got, got.Preferences)
+	}
+
+	// メールアドレスの重複は ErrDuplicateKey
+	duplicate := &entities.User{Name: "別人", Email: "yamada@example.com", CreatedAt: now, UpdatedAt: now}
+	if err := repo.Create(duplicate); !errors.Is(err, repositories.ErrDuplicateKey) {
+		t.Errorf("Create with a duplicate email error = %v, want ErrDuplicateKey", err)
+	}
+
+	got.Name = "山田太郎"
+	if err := repo.Update(got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, _ := repo.GetByID(user.ID); got.Name != "山田太郎" || got.Version != 2 {
+		t.Errorf("updated user = name %q version %d, want 山田太郎 version 2", got.Name, got.Version)
+	}
+
+	if err := repo.Delete(user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.GetByID(user.ID); err == nil {
+		t.Error("GetByID after Delete succeeded, want an error")
+	}
+	if err := repo.Delete(user.ID); err == nil {
+		t.Error("second Delete succeeded, want an error")
+	}
+}
+
+func TestSQLOutfitPostRepository(t *testing.T) {
+	repo := NewSQLOutfitPostRepository(openTestDB(t))
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, userID := range []string{"user-1", "user-1", "user-2"} {
+		post := &entities.OutfitPost{
+			UserID:    userID,
+			Items:     []string{"シャツ", "デニム"},
+			Tags:      []string{"casual"},
+			Weather:   entities.WeatherCondition{Temperature: 21, Condition: entities.ConditionClear},
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+		}
+		if err := repo.Create(post); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	posts, err := repo.GetByUserID("user-1")
+	if err != nil {
+		t.Fatalf("GetByUserID: %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("posts = %d, want 2", len(posts))
+	}
+	post := posts[0]
+	if len(post.Items) != 2 || post.Tags[0] != "casual" || post.Weather.Temperature != 21 || post.Weather.Condition != entities.ConditionClear {
+		t.Errorf("post = %+v, want the stored items, tags and weather", post)
+	}
+
+	all, err := repo.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("GetAll = %d posts, want 3", len(all))
+	}
+
+	if err := repo.Delete(post.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if posts, _ := repo.GetByUserID("user-1"); len(posts) != 1 {
+		t.Errorf("posts after Delete = %d, want 1", len(posts))
+	}
+}
+
+func TestSQLFashionRecommendationRepository(t *testing.T) {
+	repo := NewSQLFashionRecommendationRepository(openTestDB(t))
+	departure := time.Date(2024, 11, 5, 8, 0, 0, 0, time.UTC)
+	recommendation := &entities.FashionRecommendation{
+		UserID:   "user-1",
+		Strategy: "rules",
+		Outfits: []entities.Outfit{{Rank: 1, Score: 0.8, Items: []entities.RecommendedItem{
+			{ClothingID: "c1", Slot: entities.SlotBaseLayer, Name: "シャツ"},
+		}}},
+		Weather:    entities.WeatherCondition{Temperature: 8, Condition: entities.ConditionRain},
+		DayPlan:    &entities.DayPlan{DepartureAt: departure, ReturnAt: departure.Add(10 * time.Hour), MinFeelsLike: 5},
+		Advisories: []entities.WeatherAdvisory{{Kind: entities.AdvisoryColdStress, Severity: entities.SeverityCaution}},
+		CreatedAt:  departure,
+	}
+	if err := repo.Create(recommendation); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := repo.GetByID(recommendation.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if len(got.Outfits) != 1 || got.Outfits[0].Items[0].Slot != entities.SlotBaseLayer || got.Weather.Condition != entities.ConditionRain {
+		t.Errorf("outfits %+v weather %+v, want the stored outfit and weather", got.Outfits, got.Weather)
+	}
+	if got.DayPlan == nil || !got.DayPlan.DepartureAt.Equal(departure) || got.DayPlan.MinFeelsLike != 5 {
+		t.Errorf("day plan = %+v, want the stored plan", got.DayPlan)
+	}
+	if len(got.Advisories) != 1 || got.Advisories[0].Kind != entities.AdvisoryColdStress {
+		t.Errorf("advisories = %+v, want the stored advisory", got.Advisories)
+	}
+	if got.Feedback != nil {
+		t.Errorf("feedback = %+v, want nil before any feedback", got.Feedback)
+	}
+
+	got.Feedback = &entities.RecommendationFeedback{Comfort: entities.ComfortTooCold}
+	if err := repo.Update(got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	history, err := repo.GetByUserID("user-1")
+	if err != nil {
+		t.Fatalf("GetByUserID: %v", err)
+	}
+	if len(history) != 1 || history[0].Feedback == nil || history[0].Feedback.Comfort != entities.ComfortTooCold {
+		t.Errorf("history = %+v, want the recommendation with feedback", history)
+	}
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"fmt"
+
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// データベーストランザクションを利用した UnitOfWork の実装
+type SQLUnitOfWork struct {
+	db *database.DB
+}
+
+// NewSQLUnitOfWork SQL UnitOfWork の新しいインスタンスを作成します
+func NewSQLUnitOfWork(db *database.DB) *SQLUnitOfWork {
+	return &SQLUnitOfWork{db: db}
+}
+
+// Do fn をトランザクション内で実行し、成功時はコミット、失敗時はロールバックします
+func (u *SQLUnitOfWork) Do(fn func(tx repositories.Transaction) error) (err error) {
+	tx, err := u.db.BeginTx()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(&sqlTransaction{tx: tx}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// トランザクションに紐づいたリポジトリ群
+type sqlTransaction struct {
+	tx *database.Tx
+}
+
+func (t *sqlTransaction) Users() repositories.UserRepository {
+	return NewSQLUserRepository(t.tx)
+}
+
+func (t *sqlTransaction) Clothing() repositories.ClothingRepository {
+	return NewSQLClothingRepository(t.tx)
+}
+
+func (t *sqlTransaction) FashionRecommendations() repositories.FashionRecommendationRepository {
+	return NewSQLFashionRecommendationRepository(t.tx)
+}
+
+func (t *sqlTransaction) OutfitPosts() repositories.OutfitPostRepository {
+	return NewSQLOutfitPostRepository(t.tx)
+}
+
+func (t *sqlTransaction) WearLogs() repositories.WearLogRepository {
+	return NewSQLWearLogRepository(t.tx)
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// ユーザーリポジトリのSQL実装
+type SQLUserRepository struct {
+	db database.Executor
+}
+
+// NewSQLUserRepository SQLユーザーリポジトリの新しいインスタンスを作成します
+func NewSQLUserRepository(db database.Executor) *SQLUserRepository {
+	return &SQLUserRepository{db: db}
+}
+
+const userColumns = `id, name, email, password, gender, age, preferences, comfort_offset, created_at, updated_at, version`
+
+// 新しいユーザーをリポジトリに追加
+func (r *SQLUserRepository) Create(user *entities.User) error {
+	if user.ID == "" {
+		user.ID = entities.NewID()
+	}
+
+	preferences, err := toJSONColumn(user.Preferences)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(r.db.Rebind(`INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		user.ID, user.Name, user.Email, user.Password, user.Gender, user.Age, preferences, user.ComfortOffset, user.CreatedAt, user.UpdatedAt, 1,
+	)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return fmt.Errorf("ユーザーの保存に失敗しました: %w", repositories.ErrDuplicateKey)
+		}
+		return fmt.Errorf("ユーザーの保存に失敗しました: %w", err)
+	}
+	user.Version = 1
+	return nil
+}
+
+// ユーザーIDでユーザー情報を取得
+func (r *SQLUserRepository) GetByID(id string) (*entities.User, error) {
+	row := r.db.QueryRow(r.db.Rebind(`SELECT `+userColumns+` FROM users WHERE id = ?`), id)
+	return scanUser(row)
+}
+
+// メールアドレスでユーザー情報を取得
+func (r *SQLUserRepository) GetByEmail(email string) (*entities.User, error) {
+	row := r.db.QueryRow(r.db.Rebind(`SELECT `+userColumns+` FROM users WHERE email = ?`), email)
+	return scanUser(row)
+}
+
+// 既存のユーザー情報を更新
+func (r *SQLUserRepository) Update(user *entities.User) error {
+	preferences, err := toJSONColumn(user.Preferences)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(r.db.Rebind(`UPDATE users SET name = ?, email = ?, password = ?, gender = ?, age = ?, preferences = ?, comfort_offset = ?, updated_at = ?, version = version + 1 WHERE id = ? AND version = ?`),
+		user.Name, user.Email, user.Password, user.Gender, user.Age, preferences, user.ComfortOffset, user.UpdatedAt, user.ID, user.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("ユーザーの更新に失敗しました: %w", err)
+	}
+	if err := requireVersionedUpdate(r.db, result, "users", "user", user.ID, user.Version, "ユーザーが見つかりません"); err != nil {
+		return err
+	}
+
+	user.Version++
+	return nil
+}
+
+// ユーザー削除
+func (r *SQLUserRepository) Delete(id string) error {
+	result, err := r.db.Exec(r.db.Rebind(`DELETE FROM users WHERE id = ?`), id)
+	if err != nil {
+		return fmt.Errorf("ユーザーの削除に失敗しました: %w", err)
+	}
+	return requireAffected(result, "ユーザーが見つかりません")
+}
+
+// 1行分のユーザーデータをエンティティに変換
+func scanUser(row rowScanner) (*entities.User, error) {
+	var user entities.User
+	var preferences sql.NullString
+
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Gender, &user.Age, &preferences, &user.ComfortOffset, &user.CreatedAt, &user.UpdatedAt, &user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("ユーザーが見つかりません")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ユーザーの読み込みに失敗しました: %w", err)
+	}
+
+	if err := fromJSONColumn(preferences.String, &user.Preferences); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
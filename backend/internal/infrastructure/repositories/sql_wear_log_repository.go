@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/infrastructure/database"
+)
+
+// 着用記録リポジトリのSQL実装
+type SQLWearLogRepository struct {
+	db database.Executor
+}
+
+func NewSQLWearLogRepository(db database.Executor) *SQLWearLogRepository {
+	return &SQLWearLogRepository{db: db}
+}
+
+const wearLogColumns = `id, user_id, clothing_id, worn_on, source, source_id, created_at`
+
+func (r *SQLWearLogRepository) Create(entry *entities.WearLogEntry) error {
+	if entry.ID == "" {
+		entry.ID = entities.NewID()
+	}
+
+	// 一意制約違反はトランザクションを中断させるため、重複は ON CONFLICT で読み飛ばして件数で判定する
+	result, err := r.db.Exec(r.db.Rebind(`INSERT INTO wear_logs (`+wearLogColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT (clothing_id, worn_on) DO NOTHING`),
+		entry.ID, entry.UserID, entry.ClothingID, entry.WornOn, string(entry.Source), entry.SourceID, entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("着用記録の保存に失敗しました: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("更新件数の取得に失敗しました: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("wear log %s on %s: %w", entry.ClothingID, entry.WornOn.Format("2006-01-02"), repositories.ErrDuplicateKey)
+	}
+	return nil
+}
+
+func (r *SQLWearLogRepository) GetByClothingID(clothingID string) ([]*entities.WearLogEntry, error) {
+	rows, err := r.db.Query(r.db.Rebind(`SELECT `+wearLogColumns+` FROM wear_logs WHERE clothing_id = ? ORDER BY worn_on DESC`), clothingID)
+	if err != nil {
+		return nil, fmt.Errorf("着用記録の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.WearLogEntry
+	for rows.Next() {
+		var entry entities.WearLogEntry
+		var source string
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.ClothingID, &entry.WornOn, &source, &entry.SourceID, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("着用記録の読み込みに失敗しました: %w", err)
+		}
+		entry.Source = entities.WearSource(source)
+		entry.WornOn = entry.WornOn.UTC()
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *SQLWearLogRepository) GetLastWornByUserID(userID string) (map[string]time.Time, error) {
+	// 集計関数の結果は SQLite で日時型として読み込めないため、アイテムごとの最新日はアプリケーション側で求める
+	rows, err := r.db.Query(r.db.Rebind(`SELECT clothing_id, worn_on FROM wear_logs WHERE user_id = ? ORDER BY worn_on DESC`), userID)
+	if err != nil {
+		return nil, fmt.Errorf("着用記録の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	lastWorn := make(map[string]time.Time)
+	for rows.Next() {
+		var clothingID string
+		var wornOn time.Time
+		if err := rows.Scan(&clothingID, &wornOn); err != nil {
+			return nil, fmt.Errorf("着用記録の読み込みに失敗しました: %w", err)
+		}
+		if _, exists := lastWorn[clothingID]; !exists {
+			lastWorn[clothingID] = wornOn.UTC()
+		}
+	}
+	return lastWorn, rows.Err()
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"sync"
+
+	"forecast-app/internal/domain/repositories"
+)
+
+// インメモリリポジトリ用の UnitOfWork 実装
+// 処理の直列化のみを行い、ロールバックには対応しないためテスト・開発用途に限定すること
+type InMemoryUnitOfWork struct {
+	users           *InMemoryUserRepository
+	clothing        *InMemoryClothingRepository
+	recommendations *InMemoryFashionRecommendationRepository
+	outfitPosts     *InMemoryOutfitPostRepository
+	wearLogs        *InMemoryWearLogRepository
+
+	// mutex UnitOfWork 同士の同時実行を防止
+	mutex sync.Mutex
+}
+
+// NewInMemoryUnitOfWork インメモリ UnitOfWork の新しいインスタンスを作成します
+func NewInMemoryUnitOfWork(
+	users *InMemoryUserRepository,
+	clothing *InMemoryClothingRepository,
+	recommendations *InMemoryFashionRecommendationRepository,
+	outfitPosts *InMemoryOutfitPostRepository,
+	wearLogs *InMemoryWearLogRepository,
+) *InMemoryUnitOfWork {
+	return &InMemoryUnitOfWork{
+		users:           users,
+		clothing:        clothing,
+		recommendations: recommendations,
+		outfitPosts:     outfitPosts,
+		wearLogs:        wearLogs,
+	}
+}
+
+// Do fn を他の UnitOfWork と排他的に実行します
+func (u *InMemoryUnitOfWork) Do(fn func(tx repositories.Transaction) error) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return fn(u)
+}
+
+func (u *InMemoryUnitOfWork) Users() repositories.UserRepository {
+	return u.users
+}
+
+func (u *InMemoryUnitOfWork) Clothing() repositories.ClothingRepository {
+	return u.clothing
+}
+
+func (u *InMemoryUnitOfWork) FashionRecommendations() repositories.FashionRecommendationRepository {
+	return u.recommendations
+}
+
+func (u *InMemoryUnitOfWork) OutfitPosts() repositories.OutfitPostRepository {
+	return u.outfitPosts
+}
+
+func (u *InMemoryUnitOfWork) WearLogs() repositories.WearLogRepository {
+	return u.wearLogs
+}
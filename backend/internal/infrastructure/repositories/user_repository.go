@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
 )
 
 // ユーザーリポジトリのインメモリ実装
@@ -32,12 +33,23 @@ func (r *InMemoryUserRepository) Create(user *entities.User) error {
 	defer r.mutex.Unlock()
 
 	// ユーザーIDの自動生成
-	// 実際のDBでは UUID やオートインクリメントを使用
 	if user.ID == "" {
-		user.ID = fmt.Sprintf("user_%d", len(r.users)+1)
+		user.ID = entities.NewID()
 	}
 
-	r.users[user.ID] = user
+	// ID とメールアドレスの一意性を確認（既存ユーザーの上書きを防止）
+	if _, exists := r.users[user.ID]; exists {
+		return fmt.Errorf("ユーザー %s: %w", user.ID, repositories.ErrDuplicateKey)
+	}
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return fmt.Errorf("メールアドレス %s: %w", user.Email, repositories.ErrDuplicateKey)
+		}
+	}
+
+	user.Version = 1
+	stored := *user
+	r.users[user.ID] = &stored
 	return nil
 }
 
@@ -76,11 +88,19 @@ func (r *InMemoryUserRepository) Update(user *entities.User) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.users[user.ID]; !exists {
+	current, exists := r.users[user.ID]
+	if !exists {
 		return errors.New("ユーザーが見つかりません")
 	}
 
-	r.users[user.ID] = user
+	// 楽観的排他制御: 読み込み時点から他の更新が入っていれば拒否
+	if current.Version != user.Version {
+		return &repositories.ConflictError{Entity: "user", ID: user.ID, ExpectedVersion: user.Version, CurrentVersion: current.Version}
+	}
+
+	user.Version++
+	stored := *user
+	r.users[user.ID] = &stored
 	return nil
 }
 
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+func TestClothingUpdateRejectsStaleVersion(t *testing.T) {
+	for name, repo := range map[string]repositories.ClothingRepository{
+		"memory": NewInMemoryClothingRepository(NewInMemoryWearLogRepository()),
+		"sql":    NewSQLClothingRepository(openTestDB(t)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			item, err := entities.NewClothingItem("user-1", "shirt", "シャツ", "navy", string(entities.CategoryTops))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := repo.Create(item); err != nil {
+				t.Fatal(err)
+			}
+
+			// 同じバージョンを読み込んだ2つの更新のうち、後の更新は競合する
+			first, _ := repo.GetByID(item.ID)
+			second, _ := repo.GetByID(item.ID)
+			first.Name = "first"
+			if err := repo.Update(first); err != nil {
+				t.Fatalf("first Update: %v", err)
+			}
+			if first.Version != 2 {
+				t.Errorf("version after Update = %d, want 2", first.Version)
+			}
+
+			second.Name = "second"
+			err = repo.Update(second)
+			var conflict *repositories.ConflictError
+			if !errors.Is(err, repositories.ErrConflict) || !errors.As(err, &conflict) {
+				t.Fatalf("stale Update error = %v, want a ConflictError", err)
+			}
+			if conflict.ExpectedVersion != 1 || conflict.CurrentVersion != 2 {
+				t.Errorf("conflict = %+v, want expected 1 current 2", conflict)
+			}
+			if stored, _ := repo.GetByID(item.ID); stored.Name != "first" || stored.Version != 2 {
+				t.Errorf("stored = name %q version %d, want the first update", stored.Name, stored.Version)
+			}
+		})
+	}
+}
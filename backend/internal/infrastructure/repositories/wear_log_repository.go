@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+type InMemoryWearLogRepository struct {
+	entries []*entities.WearLogEntry
+	mutex   sync.RWMutex
+}
+
+// NewInMemoryWearLogRepository インメモリの着用記録リポジトリを初期化します
+func NewInMemoryWearLogRepository() *InMemoryWearLogRepository {
+	return &InMemoryWearLogRepository{}
+}
+
+// Create 着用記録を追加します（同じアイテム・同じ着用日の記録がある場合は ErrDuplicateKey）
+func (r *InMemoryWearLogRepository) Create(entry *entities.WearLogEntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.entries {
+		if existing.ClothingID == entry.ClothingID && existing.WornOn.Equal(entry.WornOn) {
+			return fmt.Errorf("wear log %s on %s: %w", entry.ClothingID, entry.WornOn.Format("2006-01-02"), repositories.ErrDuplicateKey)
+		}
+	}
+
+	if entry.ID == "" {
+		entry.ID = entities.NewID()
+	}
+	stored := *entry
+	r.entries = append(r.entries, &stored)
+	return nil
+}
+
+// GetByClothingID 指定した衣服アイテムの着用記録を着用日の新しい順に取得します
+func (r *InMemoryWearLogRepository) GetByClothingID(clothingID string) ([]*entities.WearLogEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var entries []*entities.WearLogEntry
+	for _, entry := range r.entries {
+		if entry.ClothingID == clothingID {
+			entryCopy := *entry
+			entries = append(entries, &entryCopy)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].WornOn.After(entries[j].WornOn)
+	})
+	return entries, nil
+}
+
+// GetLastWornByUserID 指定したユーザーのアイテムIDごとの最終着用日を取得します
+func (r *InMemoryWearLogRepository) GetLastWornByUserID(userID string) (map[string]time.Time, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	lastWorn := make(map[string]time.Time)
+	for _, entry := range r.entries {
+		if entry.UserID != userID {
+			continue
+		}
+		if worn, exists := lastWorn[entry.ClothingID]; !exists || entry.WornOn.After(worn) {
+			lastWorn[entry.ClothingID] = entry.WornOn
+		}
+	}
+	return lastWorn, nil
+}
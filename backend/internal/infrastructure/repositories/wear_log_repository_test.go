@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+func testWearLogRepository(t *testing.T, repo repositories.WearLogRepository) {
+	t.Helper()
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	entries := []struct {
+		userID, clothingID string
+		wornOn             time.Time
+	}{
+		{"user-1", "shirt", day(1)},
+		{"user-1", "shirt", day(3)},
+		{"user-1", "pants", day(2)},
+		{"user-2", "coat", day(4)},
+	}
+	for _, e := range entries {
+		entry := &entities.WearLogEntry{UserID: e.userID, ClothingID: e.clothingID, WornOn: e.wornOn, Source: entities.WearSourceRecommendation, CreatedAt: time.Now()}
+		if err := repo.Create(entry); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if entry.ID == "" {
+			t.Error("Create did not assign an ID")
+		}
+	}
+
+	// 同じアイテムの同じ日の記録は1件のみ
+	duplicate := &entities.WearLogEntry{UserID: "user-1", ClothingID: "shirt", WornOn: day(3), Source: entities.WearSourceOutfitPost, CreatedAt: time.Now()}
+	if err := repo.Create(duplicate); !errors.Is(err, repositories.ErrDuplicateKey) {
+		t.Errorf("Create on the same day error = %v, want ErrDuplicateKey", err)
+	}
+
+	history, err := repo.GetByClothingID("shirt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || !history[0].WornOn.Equal(day(3)) || !history[1].WornOn.Equal(day(1)) {
+		t.Errorf("history = %+v, want 2 entries newest first", history)
+	}
+
+	lastWorn, err := repo.GetLastWornByUserID("user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lastWorn) != 2 || !lastWorn["shirt"].Equal(day(3)) || !lastWorn["pants"].Equal(day(2)) {
+		t.Errorf("last worn = %v, want shirt on the 3rd and pants on the 2nd", lastWorn)
+	}
+}
+
+func TestInMemoryWearLogRepository(t *testing.T) {
+	testWearLogRepository(t, NewInMemoryWearLogRepository())
+}
+
+func TestSQLWearLogRepository(t *testing.T) {
+	testWearLogRepository(t, NewSQLWearLogRepository(openTestDB(t)))
+}
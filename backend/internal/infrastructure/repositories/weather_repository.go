@@ -1,20 +1,27 @@
 package repositories
 
 import (
+	"context"
+
 	"forecast-app/internal/domain/entities"
 	"forecast-app/internal/infrastructure/weather"
 )
 
+// 天気プロバイダーに処理を委譲する天気リポジトリ
 type WeatherRepository struct {
-	weatherAPI *weather.OpenWeatherMapAPI
+	provider weather.WeatherProvider
 }
 
-func NewWeatherRepository(apiKey string) *WeatherRepository {
+func NewWeatherRepository(provider weather.WeatherProvider) *WeatherRepository {
 	return &WeatherRepository{
-		weatherAPI: weather.NewOpenWeatherMapAPI(apiKey),
+		provider: provider,
 	}
 }
 
-func (r *WeatherRepository) GetByLocation(latitude, longitude float64) (*entities.WeatherCondition, error) {
-	return r.weatherAPI.GetByLocation(latitude, longitude)
+func (r *WeatherRepository) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	return r.provider.GetByLocation(ctx, latitude, longitude)
+}
+
+func (r *WeatherRepository) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	return r.provider.GetForecast(ctx, latitude, longitude)
 }
@@ -0,0 +1,305 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"forecast-app/internal/domain/repositories"
+)
+
+// ErrCircuitOpen 連続した失敗によりサーキットブレーカーが開いている場合のエラー
+// repositories.ErrWeatherUnavailable としても判定できる
+var ErrCircuitOpen = fmt.Errorf("天気APIのサーキットブレーカーが開いています: %w", repositories.ErrWeatherUnavailable)
+
+// 天気APIクライアントの耐障害性に関する設定
+// ゼロ値の項目はデフォルト値を使用
+type ClientOptions struct {
+	// BaseURL 天気APIのベースURL（空の場合は各プロバイダーの公開エンドポイント、テストやミラーの利用時に指定）
+	BaseURL string
+
+	// Timeout 1回のリクエストのタイムアウト
+	Timeout time.Duration
+
+	// MaxRetries 5xx・429・通信エラー時の最大リトライ回数（負の値でリトライなし）
+	MaxRetries int
+
+	// BaseBackoff リトライ間隔の初期値（試行ごとに2倍）
+	BaseBackoff time.Duration
+
+	// MaxBackoff リトライ間隔の上限
+	MaxBackoff time.Duration
+
+	// BreakerThreshold サーキットブレーカーを開くまでの連続失敗回数
+	BreakerThreshold int
+
+	// BreakerCooldown サーキットブレーカーを開いてから試行を再開するまでの時間
+	BreakerCooldown time.Duration
+}
+
+// デフォルト値を補完した設定を返す
+func (o ClientOptions) withDefaults() ClientOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = 5 * time.Second
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = 2
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 200 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 2 * time.Second
+	}
+	if o.BreakerThreshold <= 0 {
+		o.BreakerThreshold = 5
+	}
+	if o.BreakerCooldown <= 0 {
+		o.BreakerCooldown = 30 * time.Second
+	}
+	return o
+}
+
+// タイムアウト・リトライ・サーキットブレーカーを備えた JSON 取得クライアント
+type resilientClient struct {
+	client  *http.Client
+	options ClientOptions
+	breaker *circuitBreaker
+
+	// sleep リトライ待機（テストで差し替えるため）
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// 耐障害性付きクライアントを作成
+func newResilientClient(options ClientOptions) *resilientClient {
+	options = options.withDefaults()
+	return &resilientClient{
+		client:  &http.Client{},
+		options: options,
+		breaker: newCircuitBreaker(options.BreakerThreshold, options.BreakerCooldown),
+		sleep:   sleepContext,
+	}
+}
+
+// リトライ対象のエラー
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// URL から JSON を取得し out にデコード
+// 一時的な失敗は指数バックオフ＋ジッターでリトライし、連続失敗時はサーキットブレーカーで呼び出しを遮断する
+func (c *resilientClient) getJSON(ctx context.Context, rawURL string, out interface{}) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := c.sleep(ctx, c.backoff(attempt, lastErr)); err != nil {
+				return err
+			}
+		}
+
+		if err := c.breaker.allow(); err != nil {
+			return err
+		}
+
+		err := c.do(ctx, rawURL, out)
+		var retryable *retryableError
+		switch {
+		case err == nil:
+			c.breaker.record(true)
+			return nil
+		case ctx.Err() != nil:
+			// 呼び出し元のキャンセル・期限切れは上流の障害ではないため記録せず、リトライもしない
+			// 試行中の呼び出しだった場合は次の呼び出しが試行できるよう枠を返す
+			c.breaker.release()
+			return fmt.Errorf("%w: %v", repositories.ErrWeatherUnavailable, ctx.Err())
+		case errors.As(err, &retryable):
+			c.breaker.record(false)
+			lastErr = err
+		default:
+			// 4xx やデコード失敗は上流が応答しているため障害として数えない
+			c.breaker.record(true)
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: %v", repositories.ErrWeatherUnavailable, lastErr)
+}
+
+// 1回分のリクエストを実行
+func (c *resilientClient) do(ctx context.Context, rawURL string, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.options.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("天気APIリクエストの作成に失敗しました: %w", redactURL(err))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return &retryableError{err: fmt.Errorf("天気データの取得に失敗しました: %w", redactURL(err))}
+	}
+	defer resp.Body.Close()
+
+	// HTTP ステータスコードの確認
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return &retryableError{
+			err:        fmt.Errorf("天気API がステータス %d を返しました", resp.StatusCode),
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("天気API がステータス %d を返しました", resp.StatusCode)
+	}
+
+	// JSON レスポンスのパース
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("天気レスポンスのデコードに失敗しました: %w", err)
+	}
+
+	return nil
+}
+
+// *url.Error からリクエストURLを取り除く
+// URL のクエリには API キー（OpenWeatherMap の appid など）が含まれるため、操作名と原因のエラーのみを残す
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+// 試行回数に応じた待機時間（指数バックオフ＋ジッター）
+// Retry-After が指定されていればそれを優先する（上限は MaxBackoff）
+func (c *resilientClient) backoff(attempt int, lastErr error) time.Duration {
+	var retryable *retryableError
+	if errors.As(lastErr, &retryable) && retryable.retryAfter > 0 {
+		if retryable.retryAfter > c.options.MaxBackoff {
+			return c.options.MaxBackoff
+		}
+		return retryable.retryAfter
+	}
+
+	d := c.options.BaseBackoff << (attempt - 1)
+	if d <= 0 || d > c.options.MaxBackoff {
+		d = c.options.MaxBackoff
+	}
+	// 半分を固定、残り半分をランダムにして同時リトライの集中を避ける
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Retry-After ヘッダー（秒数形式）を解析
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// コンテキストのキャンセルを考慮して待機
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %v", repositories.ErrWeatherUnavailable, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// サーキットブレーカーの状態
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // 通常状態
+	breakerOpen                         // 遮断中
+	breakerHalfOpen                     // 試行を1件だけ許可している状態
+)
+
+// 連続失敗で上流への呼び出しを一定時間遮断するサーキットブレーカー
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mutex    sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// 呼び出しを許可するかを判定
+func (b *circuitBreaker) allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		// クールダウン経過後は1件だけ試行を許可
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// 呼び出し結果を記録し、状態を遷移
+func (b *circuitBreaker) record(success bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if success {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// 結果を記録せずに呼び出しを終えたことを通知
+// 試行中（半開状態）の場合は遮断状態に戻し、クールダウン経過済みのため次の呼び出しで再び試行を許可する
+func (b *circuitBreaker) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
@@ -0,0 +1,205 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/repositories"
+)
+
+func TestClientErrorsDoNotLeakAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseURL := server.URL
+	server.Close() // 接続できないエンドポイント
+
+	options := testClientOptions(baseURL)
+	options.MaxRetries = -1
+	api := NewOpenWeatherMapAPI("super-secret-key", options)
+
+	_, err := api.GetByLocation(context.Background(), 35.6895, 139.6917)
+	if !errors.Is(err, repositories.ErrWeatherUnavailable) {
+		t.Fatalf("GetByLocation error = %v, want ErrWeatherUnavailable", err)
+	}
+	for _, secret := range []string{"super-secret-key", "appid", baseURL} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("error %q contains %q", err.Error(), secret)
+		}
+	}
+}
+
+func TestClientRetriesAndOpensBreaker(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	options := testClientOptions(server.URL)
+	options.MaxRetries = 1
+	options.BreakerThreshold = 3
+	options.BreakerCooldown = time.Hour
+	client := newResilientClient(options)
+
+	var out struct{}
+	for i := 0; i < 2; i++ {
+		if err := client.getJSON(context.Background(), server.URL, &out); !errors.Is(err, repositories.ErrWeatherUnavailable) {
+			t.Fatalf("call %d error = %v, want ErrWeatherUnavailable", i, err)
+		}
+	}
+	// 2回目の呼び出しの途中（3回目の失敗）でブレーカーが開く
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+	if err := client.getJSON(context.Background(), server.URL, &out); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("call with an open breaker error = %v, want ErrCircuitOpen", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("requests with an open breaker = %d, want 3", got)
+	}
+}
+
+func TestClientCallerCancellationDoesNotTripBreaker(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	options := testClientOptions(server.URL)
+	options.BreakerThreshold = 1
+	options.BreakerCooldown = time.Hour
+	client := newResilientClient(options)
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		var out struct{}
+		err := client.getJSON(ctx, server.URL, &out)
+		cancel()
+		if !errors.Is(err, repositories.ErrWeatherUnavailable) {
+			t.Fatalf("call %d error = %v, want ErrWeatherUnavailable", i, err)
+		}
+		if errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: breaker opened after caller cancellation", i)
+		}
+	}
+	if err := client.breaker.allow(); err != nil {
+		t.Errorf("breaker after cancelled calls = %v, want closed", err)
+	}
+}
+
+func TestClientCancelledTrialCallReleasesBreaker(t *testing.T) {
+	var hang atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hang.Load() {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	options := testClientOptions(server.URL)
+	options.MaxRetries = -1
+	options.BreakerThreshold = 1
+	options.BreakerCooldown = time.Minute
+	client := newResilientClient(options)
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	// ブレーカーを開き、クールダウン経過後の試行を呼び出し元がキャンセルする
+	client.breaker.record(false)
+	now = now.Add(2 * time.Minute)
+	hang.Store(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	var out struct{}
+	err := client.getJSON(ctx, server.URL, &out)
+	cancel()
+	if !errors.Is(err, repositories.ErrWeatherUnavailable) || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("cancelled trial call error = %v, want ErrWeatherUnavailable from the cancellation", err)
+	}
+	if client.breaker.state == breakerHalfOpen {
+		t.Fatal("breaker stuck half-open after the trial call was cancelled")
+	}
+
+	// 次の呼び出しが改めて試行され、成功すればブレーカーが閉じる
+	hang.Store(false)
+	if err := client.getJSON(context.Background(), server.URL, &out); err != nil {
+		t.Fatalf("call after the cancelled trial error = %v, want success", err)
+	}
+	if client.breaker.state != breakerClosed {
+		t.Errorf("breaker state = %d, want closed", client.breaker.state)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.record(false)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("allow after 1 failure = %v, want nil", err)
+	}
+	breaker.record(false)
+	if err := breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow after 2 failures = %v, want ErrCircuitOpen", err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("allow after cooldown = %v, want nil (half-open trial)", err)
+	}
+	if err := breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("second allow while half-open = %v, want ErrCircuitOpen", err)
+	}
+
+	// 試行が失敗すると再び開く
+	breaker.record(false)
+	if err := breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow after failed trial = %v, want ErrCircuitOpen", err)
+	}
+
+	now = now.Add(time.Minute)
+	breaker.allow()
+	breaker.record(true)
+	if err := breaker.allow(); err != nil {
+		t.Errorf("allow after successful trial = %v, want nil", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	client := newResilientClient(ClientOptions{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second})
+
+	if got := client.backoff(1, &retryableError{err: errors.New("503"), retryAfter: 500 * time.Millisecond}); got != 500*time.Millisecond {
+		t.Errorf("backoff with Retry-After = %v, want 500ms", got)
+	}
+	if got := client.backoff(1, &retryableError{err: errors.New("429"), retryAfter: time.Minute}); got != time.Second {
+		t.Errorf("backoff with a long Retry-After = %v, want MaxBackoff", got)
+	}
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		got := client.backoff(attempt, errors.New("failed"))
+		if got < max/2 || got > max {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, got, max/2, max)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{"": 0, "3": 3 * time.Second, "0": 0, "-1": 0, "Wed, 21 Oct 2015 07:28:00 GMT": 0}
+	for value, want := range tests {
+		if got := parseRetryAfter(value); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
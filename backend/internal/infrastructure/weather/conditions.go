@@ -0,0 +1,78 @@
+package weather
+
+import (
+	"forecast-app/internal/domain/entities"
+)
+
+// 各プロバイダー固有の天気コードをドメインの天気状況に変換するマッピング
+
+// OpenWeatherMap の天気ID（weather[0].id）を天気状況に変換
+// https://openweathermap.org/weather-conditions
+func openWeatherMapConditionCode(id int) entities.WeatherConditionCode {
+	switch {
+	case id >= 200 && id < 300:
+		return entities.ConditionThunderstorm
+	case id >= 300 && id < 400:
+		return entities.ConditionDrizzle
+	case id == 511:
+		// 着氷性の雨
+		return entities.ConditionSleet
+	case id >= 500 && id < 600:
+		return entities.ConditionRain
+	case id >= 611 && id <= 616:
+		// みぞれ・雨まじりの雪
+		return entities.ConditionSleet
+	case id >= 600 && id < 700:
+		return entities.ConditionSnow
+	case id == 701:
+		return entities.ConditionMist
+	case id == 741:
+		return entities.ConditionFog
+	case id == 771:
+		return entities.ConditionSquall
+	case id == 781:
+		return entities.ConditionTornado
+	case id >= 700 && id < 800:
+		// 煙・煙霧・砂塵・火山灰
+		return entities.ConditionHaze
+	case id == 800:
+		return entities.ConditionClear
+	case id > 800 && id < 900:
+		return entities.ConditionClouds
+	default:
+		return entities.ConditionUnknown
+	}
+}
+
+// WMO 天気コード（Open-Meteo の weather_code）を天気状況と日本語の説明に変換
+// https://open-meteo.com/en/docs の WMO Weather interpretation codes に準拠
+func wmoCondition(code int) (entities.WeatherConditionCode, string) {
+	switch {
+	case code == 0:
+		return entities.ConditionClear, "快晴"
+	case code == 1:
+		return entities.ConditionClear, "晴れ"
+	case code == 2:
+		return entities.ConditionClouds, "一部曇り"
+	case code == 3:
+		return entities.ConditionClouds, "曇り"
+	case code == 45 || code == 48:
+		return entities.ConditionFog, "霧"
+	case code >= 51 && code <= 57:
+		return entities.ConditionDrizzle, "霧雨"
+	case code == 66 || code == 67:
+		return entities.ConditionSleet, "着氷性の雨"
+	case code >= 61 && code <= 65:
+		return entities.ConditionRain, "雨"
+	case code >= 71 && code <= 77:
+		return entities.ConditionSnow, "雪"
+	case code >= 80 && code <= 82:
+		return entities.ConditionRain, "にわか雨"
+	case code == 85 || code == 86:
+		return entities.ConditionSnow, "にわか雪"
+	case code >= 95 && code <= 99:
+		return entities.ConditionThunderstorm, "雷雨"
+	default:
+		return entities.ConditionUnknown, entities.ConditionUnknown.DisplayName(entities.LanguageJapanese)
+	}
+}
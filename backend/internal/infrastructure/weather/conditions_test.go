@@ -0,0 +1,32 @@
+package weather
+
+import (
+	"testing"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func TestOpenWeatherMapConditionCode(t *testing.T) {
+	tests := map[int]entities.WeatherConditionCode{
+		211: entities.ConditionThunderstorm,
+		301: entities.ConditionDrizzle,
+		500: entities.ConditionRain,
+		511: entities.ConditionSleet,
+		601: entities.ConditionSnow,
+		611: entities.ConditionSleet,
+		701: entities.ConditionMist,
+		711: entities.ConditionHaze,
+		741: entities.ConditionFog,
+		771: entities.ConditionSquall,
+		781: entities.ConditionTornado,
+		800: entities.ConditionClear,
+		804: entities.ConditionClouds,
+		0:   entities.ConditionUnknown,
+		950: entities.ConditionUnknown,
+	}
+	for id, want := range tests {
+		if got := openWeatherMapConditionCode(id); got != want {
+			t.Errorf("openWeatherMapConditionCode(%d) = %q, want %q", id, got, want)
+		}
+	}
+}
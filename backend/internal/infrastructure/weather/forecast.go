@@ -0,0 +1,47 @@
+package weather
+
+import (
+	"math"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 時間ごとの予報を現地日付ごとに集計
+// 代表値には現地時刻12時に最も近い予報を使用し、最高・最低気温と最大降水確率を算出する
+func aggregateDaily(hourly []entities.WeatherCondition, loc *time.Location) []entities.DailyWeather {
+	var daily []entities.DailyWeather
+	index := make(map[string]int)
+	noonDistance := make(map[string]float64)
+
+	for _, condition := range hourly {
+		local := condition.DateTime.In(loc)
+		date := local.Format("2006-01-02")
+		distance := math.Abs(float64(local.Hour()) - 12)
+
+		i, exists := index[date]
+		if !exists {
+			index[date] = len(daily)
+			noonDistance[date] = distance
+			daily = append(daily, entities.DailyWeather{
+				WeatherCondition: condition,
+				Date:             date,
+				MinTemperature:   condition.Temperature,
+				MaxTemperature:   condition.Temperature,
+			})
+			continue
+		}
+
+		day := &daily[i]
+		pop := math.Max(day.PrecipitationProbability, condition.PrecipitationProbability)
+		if distance < noonDistance[date] {
+			noonDistance[date] = distance
+			day.WeatherCondition = condition
+		}
+		day.PrecipitationProbability = pop
+		day.MinTemperature = math.Min(day.MinTemperature, condition.Temperature)
+		day.MaxTemperature = math.Max(day.MaxTemperature, condition.Temperature)
+	}
+
+	return daily
+}
@@ -0,0 +1,79 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+func TestAggregateDaily(t *testing.T) {
+	jst := time.FixedZone("JST", 9*3600)
+	start := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC) // 現地 9時
+	var hourly []entities.WeatherCondition
+	for i := 0; i < 8; i++ {
+		at := start.Add(time.Duration(i) * 3 * time.Hour)
+		hourly = append(hourly, entities.WeatherCondition{
+			Temperature:              float64(10 + i),
+			PrecipitationProbability: float64(i) / 10,
+			DateTime:                 at,
+		})
+	}
+
+	daily := aggregateDaily(hourly, jst)
+	// 現地 9時〜21時が4月1日、0時以降が4月2日
+	if len(daily) != 2 || daily[0].Date != "2024-04-01" || daily[1].Date != "2024-04-02" {
+		t.Fatalf("daily = %+v, want 2024-04-01 and 2024-04-02 in local time", daily)
+	}
+	first := daily[0]
+	if first.MinTemperature != 10 || first.MaxTemperature != 14 {
+		t.Errorf("first day min/max = %v/%v, want 10/14", first.MinTemperature, first.MaxTemperature)
+	}
+	if first.PrecipitationProbability != 0.4 {
+		t.Errorf("first day precipitation probability = %v, want the maximum 0.4", first.PrecipitationProbability)
+	}
+	// 代表値は現地12時の予報
+	if first.Temperature != 11 {
+		t.Errorf("first day representative temperature = %v, want the noon forecast 11", first.Temperature)
+	}
+}
+
+func TestOpenWeatherMapGetForecast(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/forecast" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		start := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).Unix()
+		fmt.Fprintf(w, `{"city":{"name":"Tokyo","timezone":32400},"list":[
+			{"dt":%d,"main":{"temp":12.5,"feels_like":11,"humidity":60},"weather":[{"id":500,"description":"小雨"}],"wind":{"speed":3},"pop":0.7},
+			{"dt":%d,"main":{"temp":15,"feels_like":14,"humidity":55},"weather":[{"id":800,"description":"晴天"}],"wind":{"speed":2},"pop":0}
+		]}`, start, start+3*3600)
+	}))
+	defer server.Close()
+
+	api := NewOpenWeatherMapAPI("test-key", testClientOptions(server.URL))
+	forecast, err := api.GetForecast(context.Background(), 35.6895, 139.6917)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "lat=35.689500&lon=139.691700&appid=test-key&units=metric"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+	if forecast.Location != "Tokyo" || len(forecast.Hourly) != 2 || len(forecast.Daily) != 1 {
+		t.Fatalf("forecast = %+v, want 2 hourly entries for one day in Tokyo", forecast)
+	}
+	first := forecast.Hourly[0]
+	if first.Condition != entities.ConditionRain || first.PrecipitationProbability != 0.7 || first.Location != "Tokyo" {
+		t.Errorf("first entry = %+v, want rain with 70%% precipitation in Tokyo", first)
+	}
+	if day := forecast.Daily[0]; day.Date != "2024-04-01" || day.MinTemperature != 12.5 || day.MaxTemperature != 15 {
+		t.Errorf("daily = %+v, want 2024-04-01 between 12.5 and 15", day)
+	}
+}
@@ -0,0 +1,148 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 予報の件数（3時間間隔 × 40件 = 5日分）
+const mockForecastSteps = 40
+
+// ネットワークを使用しない決定的なモック天気プロバイダー
+// 緯度・季節・時刻から尤もらしい気象条件を算出し、同じ地点・同じ時間帯には常に同じ結果を返す
+type MockWeatherProvider struct {
+	// now 現在時刻の取得関数（テストで固定時刻を注入するため）
+	now func() time.Time
+}
+
+// モック天気プロバイダーの新しいインスタンスを作成（ファクトリ）
+func NewMockWeatherProvider() *MockWeatherProvider {
+	return &MockWeatherProvider{now: time.Now}
+}
+
+// 現在時刻を固定したモック天気プロバイダーを作成
+func NewMockWeatherProviderAt(now func() time.Time) *MockWeatherProvider {
+	return &MockWeatherProvider{now: now}
+}
+
+// Name プロバイダー名を返す
+func (m *MockWeatherProvider) Name() string {
+	return ProviderMock
+}
+
+// 緯度経度から現在の天気情報を生成
+func (m *MockWeatherProvider) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	return m.GetAt(latitude, longitude, m.now()), nil
+}
+
+// 指定日時の天気情報を生成
+func (m *MockWeatherProvider) GetAt(latitude, longitude float64, at time.Time) *entities.WeatherCondition {
+	at = at.UTC().Truncate(time.Hour)
+	rng := rand.New(rand.NewSource(mockSeed(latitude, longitude, at)))
+
+	// 年平均気温は緯度が高いほど低く、季節変動は緯度が高いほど大きい
+	absLat := math.Abs(latitude)
+	annualMean := 30 - 0.4*absLat
+	seasonalAmplitude := 0.25 * absLat
+
+	// 北半球は7月下旬、南半球は1月下旬が最も暑い
+	dayOfYear := float64(at.YearDay())
+	season := math.Cos(2 * math.Pi * (dayOfYear - 205) / 365)
+	if latitude < 0 {
+		season = -season
+	}
+
+	// 経度から現地時刻を近似し、14時を最高気温とする日変化を加える
+	localHour := math.Mod(float64(at.Hour())+longitude/15+24, 24)
+	diurnal := 4 * math.Cos(2*math.Pi*(localHour-14)/24)
+
+	temperature := annualMean + seasonalAmplitude*season + diurnal + rng.Float64()*4 - 2
+	humidity := 45 + rng.Intn(46)
+	windSpeed := math.Round(rng.Float64()*80) / 10
+	cloudCover := rng.Intn(101)
+
+	condition := entities.ConditionClear
+	pop, visibility := 0.0, 10000
+	switch {
+	case cloudCover > 85 && rng.Float64() < 0.6:
+		if temperature <= 1 {
+			condition = entities.ConditionSnow
+		} else {
+			condition = entities.ConditionRain
+		}
+		humidity = 80 + rng.Intn(21)
+		pop = 0.6 + rng.Float64()*0.4
+		visibility = 2000 + rng.Intn(6000)
+	case cloudCover > 40:
+		condition = entities.ConditionClouds
+		pop = rng.Float64() * 0.4
+	}
+
+	return &entities.WeatherCondition{
+		Temperature:              round1(temperature),
+		FeelsLike:                round1(mockFeelsLike(temperature, humidity, windSpeed)),
+		Description:              condition.DisplayName(entities.LanguageJapanese),
+		Condition:                condition,
+		Humidity:                 humidity,
+		WindSpeed:                windSpeed,
+		CloudCover:               cloudCover,
+		PrecipitationProbability: math.Round(pop*100) / 100,
+		Pressure:                 1000 + rng.Intn(26),
+		WindDirection:            rng.Intn(360),
+		Visibility:               visibility,
+		Location:                 fmt.Sprintf("Mock (%.2f, %.2f)", latitude, longitude),
+		DateTime:                 at,
+	}
+}
+
+// 緯度経度から5日間/3時間間隔の天気予報を生成
+func (m *MockWeatherProvider) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	// 次の3時間区切りから40件（5日分）を生成
+	start := m.now().UTC().Truncate(3 * time.Hour).Add(3 * time.Hour)
+	hourly := make([]entities.WeatherCondition, 0, mockForecastSteps)
+	for i := 0; i < mockForecastSteps; i++ {
+		hourly = append(hourly, *m.GetAt(latitude, longitude, start.Add(time.Duration(i)*3*time.Hour)))
+	}
+
+	// 経度から現地の時差を近似
+	offset := int(math.Round(longitude/15)) * 3600
+
+	return &entities.WeatherForecast{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Location:  fmt.Sprintf("Mock (%.2f, %.2f)", latitude, longitude),
+		Hourly:    hourly,
+		Daily:     aggregateDaily(hourly, time.FixedZone("", offset)),
+		UpdatedAt: m.now(),
+	}, nil
+}
+
+// 地点と時刻（1時間単位）から乱数シードを算出
+func mockSeed(latitude, longitude float64, at time.Time) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%.2f:%.2f:%s", latitude, longitude, at.Format("2006-01-02T15"))
+	return int64(h.Sum64())
+}
+
+// 風と湿度を考慮した簡易的な体感温度
+func mockFeelsLike(temperature float64, humidity int, windSpeed float64) float64 {
+	switch {
+	case temperature <= 10:
+		return temperature - windSpeed*0.7
+	case temperature >= 25:
+		return temperature + float64(humidity-50)/10
+	default:
+		return temperature
+	}
+}
+
+// 小数第1位に丸める
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
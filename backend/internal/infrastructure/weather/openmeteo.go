@@ -0,0 +1,215 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 予報を取得する日数（OpenWeatherMap の5日予報に合わせつつ余裕を持たせる）
+const openMeteoForecastDays = 7
+
+// Open-Meteo から取得する気象変数
+var openMeteoVariables = []string{
+	"temperature_2m",
+	"apparent_temperature",
+	"relative_humidity_2m",
+	"weather_code",
+	"cloud_cover",
+	"pressure_msl",
+	"wind_speed_10m",
+	"wind_direction_10m",
+}
+
+// Open-Meteo API（API キー不要）との統合を実装する構造体
+type OpenMeteoAPI struct {
+	baseURL string
+	client  *resilientClient
+}
+
+// Open-Meteo の公開エンドポイント
+const openMeteoBaseURL = "https://api.open-meteo.com/v1"
+
+// Open-Meteo API クライアントの新しいインスタンスを作成（ファクトリ）
+// options.BaseURL が空の場合は公開エンドポイントを使用
+func NewOpenMeteoAPI(options ClientOptions) *OpenMeteoAPI {
+	baseURL := options.BaseURL
+	if baseURL == "" {
+		baseURL = openMeteoBaseURL
+	}
+	return &OpenMeteoAPI{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  newResilientClient(options),
+	}
+}
+
+// OpenMeteoCurrent Open-Meteo の現在の気象データ
+type OpenMeteoCurrent struct {
+	Time                int64   `json:"time"`                 // 計測日時（UNIX時間）
+	Temperature2m       float64 `json:"temperature_2m"`       // 気温（摂氏）
+	ApparentTemperature float64 `json:"apparent_temperature"` // 体感温度（摂氏）
+	RelativeHumidity2m  float64 `json:"relative_humidity_2m"` // 湿度（パーセント）
+	WeatherCode         int     `json:"weather_code"`         // WMO 天気コード
+	CloudCover          float64 `json:"cloud_cover"`          // 雲量（パーセント）
+	PressureMSL         float64 `json:"pressure_msl"`         // 海面気圧（hPa）
+	WindSpeed10m        float64 `json:"wind_speed_10m"`       // 風速（m/s）
+	WindDirection10m    float64 `json:"wind_direction_10m"`   // 風向（度）
+}
+
+// OpenMeteoHourly Open-Meteo の時間ごとの予報（変数ごとの配列）
+type OpenMeteoHourly struct {
+	Time                     []int64   `json:"time"`
+	Temperature2m            []float64 `json:"temperature_2m"`
+	ApparentTemperature      []float64 `json:"apparent_temperature"`
+	RelativeHumidity2m       []float64 `json:"relative_humidity_2m"`
+	WeatherCode              []int     `json:"weather_code"`
+	CloudCover               []float64 `json:"cloud_cover"`
+	PressureMSL              []float64 `json:"pressure_msl"`
+	WindSpeed10m             []float64 `json:"wind_speed_10m"`
+	WindDirection10m         []float64 `json:"wind_direction_10m"`
+	PrecipitationProbability []float64 `json:"precipitation_probability"` // 降水確率（パーセント）
+	Visibility               []float64 `json:"visibility"`                // 視程（メートル）
+}
+
+// OpenMeteoResponse Open-Meteo Forecast API からのレスポンス構造体
+type OpenMeteoResponse struct {
+	Latitude         float64           `json:"latitude"`
+	Longitude        float64           `json:"longitude"`
+	Timezone         string            `json:"timezone"`
+	UTCOffsetSeconds int               `json:"utc_offset_seconds"` // UTC からの時差（秒）
+	Current          *OpenMeteoCurrent `json:"current"`
+	Hourly           *OpenMeteoHourly  `json:"hourly"`
+}
+
+// Name プロバイダー名を返す
+func (o *OpenMeteoAPI) Name() string {
+	return ProviderOpenMeteo
+}
+
+// 緯度経度から現在の天気情報を取得
+func (o *OpenMeteoAPI) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
+	params := o.params(latitude, longitude)
+	params.Set("current", strings.Join(openMeteoVariables, ","))
+
+	var resp OpenMeteoResponse
+	if err := o.client.getJSON(ctx, o.baseURL+"/forecast?"+params.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	if resp.Current == nil {
+		return nil, fmt.Errorf("Open-Meteo のレスポンスに現在の天気が含まれていません")
+	}
+
+	c := resp.Current
+	return newOpenMeteoCondition(openMeteoSample{
+		time:          c.Time,
+		temperature:   c.Temperature2m,
+		feelsLike:     c.ApparentTemperature,
+		humidity:      c.RelativeHumidity2m,
+		weatherCode:   c.WeatherCode,
+		cloudCover:    c.CloudCover,
+		pressure:      c.PressureMSL,
+		windSpeed:     c.WindSpeed10m,
+		windDirection: c.WindDirection10m,
+	}), nil
+}
+
+// 緯度経度から1時間間隔の天気予報を取得
+func (o *OpenMeteoAPI) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	params := o.params(latitude, longitude)
+	params.Set("hourly", strings.Join(append(openMeteoVariables, "precipitation_probability", "visibility"), ","))
+	params.Set("forecast_days", fmt.Sprint(openMeteoForecastDays))
+
+	var resp OpenMeteoResponse
+	if err := o.client.getJSON(ctx, o.baseURL+"/forecast?"+params.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	if resp.Hourly == nil {
+		return nil, fmt.Errorf("Open-Meteo のレスポンスに時間ごとの予報が含まれていません")
+	}
+
+	h := resp.Hourly
+	hourly := make([]entities.WeatherCondition, 0, len(h.Time))
+	for i, t := range h.Time {
+		hourly = append(hourly, *newOpenMeteoCondition(openMeteoSample{
+			time:          t,
+			temperature:   valueAt(h.Temperature2m, i),
+			feelsLike:     valueAt(h.ApparentTemperature, i),
+			humidity:      valueAt(h.RelativeHumidity2m, i),
+			weatherCode:   valueAt(h.WeatherCode, i),
+			cloudCover:    valueAt(h.CloudCover, i),
+			pressure:      valueAt(h.PressureMSL, i),
+			windSpeed:     valueAt(h.WindSpeed10m, i),
+			windDirection: valueAt(h.WindDirection10m, i),
+			pop:           valueAt(h.PrecipitationProbability, i) / 100,
+			visibility:    valueAt(h.Visibility, i),
+		}))
+	}
+
+	return &entities.WeatherForecast{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Hourly:    hourly,
+		Daily:     aggregateDaily(hourly, time.FixedZone(resp.Timezone, resp.UTCOffsetSeconds)),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// 共通のクエリパラメータを生成
+// 単位はドメインに合わせて摂氏・m/s、時刻は UNIX 時間で受け取る
+func (o *OpenMeteoAPI) params(latitude, longitude float64) url.Values {
+	params := url.Values{}
+	params.Set("latitude", fmt.Sprintf("%f", latitude))
+	params.Set("longitude", fmt.Sprintf("%f", longitude))
+	params.Set("wind_speed_unit", "ms")
+	params.Set("timeformat", "unixtime")
+	params.Set("timezone", "auto")
+	return params
+}
+
+// 現在値・予報値に共通する1時点分の気象データ
+type openMeteoSample struct {
+	time          int64
+	temperature   float64
+	feelsLike     float64
+	humidity      float64
+	weatherCode   int
+	cloudCover    float64
+	pressure      float64
+	windSpeed     float64
+	windDirection float64
+	pop           float64
+	visibility    float64
+}
+
+// 外部APIデータを内部ドメインエンティティに変換
+func newOpenMeteoCondition(s openMeteoSample) *entities.WeatherCondition {
+	condition, description := wmoCondition(s.weatherCode)
+	return &entities.WeatherCondition{
+		Temperature:              s.temperature,
+		FeelsLike:                s.feelsLike,
+		Description:              description,
+		Condition:                condition,
+		Humidity:                 int(math.Round(s.humidity)),
+		WindSpeed:                s.windSpeed,
+		CloudCover:               int(math.Round(s.cloudCover)),
+		PrecipitationProbability: s.pop,
+		Pressure:                 int(math.Round(s.pressure)),
+		WindDirection:            int(math.Round(s.windDirection)),
+		Visibility:               int(math.Round(s.visibility)),
+		DateTime:                 time.Unix(s.time, 0).UTC(),
+	}
+}
+
+// 配列の要素を取得（欠損している場合はゼロ値）
+func valueAt[T any](values []T, i int) T {
+	var zero T
+	if i >= len(values) {
+		return zero
+	}
+	return values[i]
+}
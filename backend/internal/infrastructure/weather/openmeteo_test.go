@@ -0,0 +1,239 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+// 記録済みのレスポンス（testdata/ 以下のファイル）を返す Open-Meteo のテストサーバー
+// 受け取ったクエリパラメータを queries に記録する
+func newOpenMeteoFixtureServer(t *testing.T, fixture string, queries *[]url.Values) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile("testdata/" + fixture)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/forecast" {
+			http.NotFound(w, r)
+			return
+		}
+		if queries != nil {
+			*queries = append(*queries, r.URL.Query())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// テスト用のクライアント設定（リトライ間隔を短くする）
+func testClientOptions(baseURL string) ClientOptions {
+	return ClientOptions{
+		BaseURL:     baseURL,
+		Timeout:     2 * time.Second,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+}
+
+func TestNewOpenMeteoAPIDefaultBaseURL(t *testing.T) {
+	if got := NewOpenMeteoAPI(ClientOptions{}).baseURL; got != openMeteoBaseURL {
+		t.Errorf("baseURL = %q, want %q", got, openMeteoBaseURL)
+	}
+	if got := NewOpenMeteoAPI(ClientOptions{BaseURL: "http://mirror/v1/"}).baseURL; got != "http://mirror/v1" {
+		t.Errorf("baseURL = %q, want http://mirror/v1", got)
+	}
+}
+
+func TestOpenMeteoGetByLocation(t *testing.T) {
+	var queries []url.Values
+	server := newOpenMeteoFixtureServer(t, "openmeteo_current.json", &queries)
+	api := NewOpenMeteoAPI(testClientOptions(server.URL + "/v1"))
+
+	condition, err := api.GetByLocation(context.Background(), 35.6895, 139.6917)
+	if err != nil {
+		t.Fatalf("GetByLocation: %v", err)
+	}
+
+	if len(queries) != 1 {
+		t.Fatalf("requests = %d, want 1", len(queries))
+	}
+	query := queries[0]
+	for name, want := range map[string]string{"latitude": "35.689500", "longitude": "139.691700", "wind_speed_unit": "ms", "timeformat": "unixtime", "timezone": "auto"} {
+		if got := query.Get(name); got != want {
+			t.Errorf("query %s = %q, want %q", name, got, want)
+		}
+	}
+	if !strings.Contains(query.Get("current"), "weather_code") {
+		t.Errorf("query current = %q, want weather_code", query.Get("current"))
+	}
+
+	if condition.Temperature != 27.4 || condition.FeelsLike != 30.1 {
+		t.Errorf("temperature = %v / %v, want 27.4 / 30.1", condition.Temperature, condition.FeelsLike)
+	}
+	if condition.Condition != entities.ConditionRain || condition.Description != "にわか雨" {
+		t.Errorf("condition = %s %q, want rain にわか雨", condition.Condition, condition.Description)
+	}
+	if condition.Humidity != 78 || condition.CloudCover != 92 || condition.Pressure != 1008 || condition.WindDirection != 203 {
+		t.Errorf("humidity/cloud/pressure/direction = %d/%d/%d/%d, want 78/92/1008/203", condition.Humidity, condition.CloudCover, condition.Pressure, condition.WindDirection)
+	}
+	if condition.WindSpeed != 4.6 {
+		t.Errorf("wind speed = %v, want 4.6", condition.WindSpeed)
+	}
+	if want := time.Unix(1719800100, 0).UTC(); !condition.DateTime.Equal(want) {
+		t.Errorf("time = %v, want %v", condition.DateTime, want)
+	}
+}
+
+func TestOpenMeteoGetForecast(t *testing.T) {
+	var queries []url.Values
+	server := newOpenMeteoFixtureServer(t, "openmeteo_forecast.json", &queries)
+	api := NewOpenMeteoAPI(testClientOptions(server.URL + "/v1"))
+
+	forecast, err := api.GetForecast(context.Background(), 35.6895, 139.6917)
+	if err != nil {
+		t.Fatalf("GetForecast: %v", err)
+	}
+
+	query := queries[0]
+	if got := query.Get("forecast_days"); got != "7" {
+		t.Errorf("query forecast_days = %q, want 7", got)
+	}
+	for _, variable := range []string{"temperature_2m", "precipitation_probability", "visibility"} {
+		if !strings.Contains(query.Get("hourly"), variable) {
+			t.Errorf("query hourly = %q, want %s", query.Get("hourly"), variable)
+		}
+	}
+
+	if len(forecast.Hourly) != 30 {
+		t.Fatalf("hourly entries = %d, want 30", len(forecast.Hourly))
+	}
+	first := forecast.Hourly[0]
+	if want := time.Date(2024, 6, 30, 15, 0, 0, 0, time.UTC); !first.DateTime.Equal(want) {
+		t.Errorf("first hour = %v, want %v", first.DateTime, want)
+	}
+	if first.Temperature != 20 || first.FeelsLike != 19 {
+		t.Errorf("first temperature = %v / %v, want 20 / 19", first.Temperature, first.FeelsLike)
+	}
+	if first.Condition != entities.ConditionClouds || first.PrecipitationProbability != 0.1 {
+		t.Errorf("first condition = %s pop %v, want clouds pop 0.1", first.Condition, first.PrecipitationProbability)
+	}
+	if first.Pressure != 1013 || first.Visibility != 24140 {
+		t.Errorf("first pressure/visibility = %d/%d, want 1013/24140", first.Pressure, first.Visibility)
+	}
+	// 配列が短い変数は欠損としてゼロ値になる
+	if last := forecast.Hourly[29]; last.Visibility != 0 || last.Condition != entities.ConditionThunderstorm {
+		t.Errorf("last hour visibility/condition = %d/%s, want 0/thunderstorm", last.Visibility, last.Condition)
+	}
+
+	// 日ごとの集計は現地時刻（Asia/Tokyo）の日付で区切る
+	if len(forecast.Daily) != 2 {
+		t.Fatalf("daily entries = %d, want 2", len(forecast.Daily))
+	}
+	day1, day2 := forecast.Daily[0], forecast.Daily[1]
+	if day1.Date != "2024-07-01" || day1.MinTemperature != 20 || day1.MaxTemperature != 31.5 {
+		t.Errorf("day 1 = %s %v..%v, want 2024-07-01 20..31.5", day1.Date, day1.MinTemperature, day1.MaxTemperature)
+	}
+	if day1.Condition != entities.ConditionRain || day1.PrecipitationProbability != 0.8 {
+		t.Errorf("day 1 condition = %s pop %v, want rain (noon) pop 0.8", day1.Condition, day1.PrecipitationProbability)
+	}
+	if day2.Date != "2024-07-02" || day2.MinTemperature != 15 || day2.MaxTemperature != 17.5 {
+		t.Errorf("day 2 = %s %v..%v, want 2024-07-02 15..17.5", day2.Date, day2.MinTemperature, day2.MaxTemperature)
+	}
+	if day2.Condition != entities.ConditionThunderstorm {
+		t.Errorf("day 2 condition = %s, want thunderstorm (closest to noon)", day2.Condition)
+	}
+}
+
+func TestOpenMeteoErrorStatuses(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantUnavailable bool
+		wantRequests    int32
+	}{
+		{"bad request is not retried", http.StatusBadRequest, `{"error":true,"reason":"Latitude must be in range of -90 to 90°."}`, false, 1},
+		{"not found is not retried", http.StatusNotFound, "", false, 1},
+		{"server error is retried", http.StatusInternalServerError, "", true, 3},
+		{"rate limit is retried", http.StatusTooManyRequests, "", true, 3},
+		{"malformed body", http.StatusOK, `{"hourly":`, false, 1},
+		{"missing hourly data", http.StatusOK, `{"latitude":35.7}`, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			api := NewOpenMeteoAPI(testClientOptions(server.URL))
+			_, err := api.GetForecast(context.Background(), 35.6895, 139.6917)
+			if err == nil {
+				t.Fatal("GetForecast succeeded, want error")
+			}
+			if got := errors.Is(err, repositories.ErrWeatherUnavailable); got != tt.wantUnavailable {
+				t.Errorf("errors.Is(err, ErrWeatherUnavailable) = %v, want %v (err = %v)", got, tt.wantUnavailable, err)
+			}
+			if got := atomic.LoadInt32(&requests); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestWMOCondition(t *testing.T) {
+	tests := []struct {
+		code        int
+		condition   entities.WeatherConditionCode
+		description string
+	}{
+		{0, entities.ConditionClear, "快晴"},
+		{1, entities.ConditionClear, "晴れ"},
+		{2, entities.ConditionClouds, "一部曇り"},
+		{3, entities.ConditionClouds, "曇り"},
+		{45, entities.ConditionFog, "霧"},
+		{48, entities.ConditionFog, "霧"},
+		{51, entities.ConditionDrizzle, "霧雨"},
+		{57, entities.ConditionDrizzle, "霧雨"},
+		{61, entities.ConditionRain, "雨"},
+		{65, entities.ConditionRain, "雨"},
+		{66, entities.ConditionSleet, "着氷性の雨"},
+		{67, entities.ConditionSleet, "着氷性の雨"},
+		{71, entities.ConditionSnow, "雪"},
+		{77, entities.ConditionSnow, "雪"},
+		{80, entities.ConditionRain, "にわか雨"},
+		{82, entities.ConditionRain, "にわか雨"},
+		{85, entities.ConditionSnow, "にわか雪"},
+		{86, entities.ConditionSnow, "にわか雪"},
+		{95, entities.ConditionThunderstorm, "雷雨"},
+		{99, entities.ConditionThunderstorm, "雷雨"},
+		{4, entities.ConditionUnknown, ""},
+		{100, entities.ConditionUnknown, ""},
+	}
+	for _, tt := range tests {
+		condition, description := wmoCondition(tt.code)
+		if condition != tt.condition {
+			t.Errorf("wmoCondition(%d) = %s, want %s", tt.code, condition, tt.condition)
+		}
+		if tt.description != "" && description != tt.description {
+			t.Errorf("wmoCondition(%d) description = %q, want %q", tt.code, description, tt.description)
+		}
+	}
+}
@@ -1,97 +1,166 @@
 package weather
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"strings"
+	"time"
 
 	"forecast-app/internal/domain/entities"
 )
 
 // OpenWeatherMap API との統合を実装する構造体
 type OpenWeatherMapAPI struct {
-	apiKey string
-	client *http.Client
+	apiKey  string
+	baseURL string
+	client  *resilientClient
 }
 
+// OpenWeatherMap の公開エンドポイント
+const openWeatherMapBaseURL = "https://api.openweathermap.org/data/2.5"
+
 // OpenWeatherMap API クライアントの新しいインスタンスを作成（ファクトリ）
-func NewOpenWeatherMapAPI(apiKey string) *OpenWeatherMapAPI {
+// options.BaseURL が空の場合は公開エンドポイントを使用
+func NewOpenWeatherMapAPI(apiKey string, options ClientOptions) *OpenWeatherMapAPI {
+	baseURL := options.BaseURL
+	if baseURL == "" {
+		baseURL = openWeatherMapBaseURL
+	}
 	return &OpenWeatherMapAPI{
-		apiKey: apiKey,
-		client: &http.Client{},
+		apiKey:  apiKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  newResilientClient(options),
 	}
 }
 
 // OpenWeatherMapResponse OpenWeatherMap API からのレスポンス構造体
 type OpenWeatherMapResponse struct {
+	// Dt データ計測日時（UNIX時間）
+	Dt int64 `json:"dt"`
+
 	// Main 主要な気象データ（温度、湿度など）
 	Main struct {
 		Temp      float64 `json:"temp"`       // 気温（摂氏）
 		FeelsLike float64 `json:"feels_like"` // 体感温度（摂氏）
 		Humidity  int     `json:"humidity"`   // 湿度（パーセント）
+		Pressure  int     `json:"pressure"`   // 気圧（hPa）
 	} `json:"main"`
-	
+
 	// Weather 天気状況の配列（通常は1つの要素）
 	Weather []struct {
+		ID          int    `json:"id"`          // 天気ID（2xx: 雷雨, 5xx: 雨, 800: 晴れ など）
 		Main        string `json:"main"`        // 主要な天気状況（Rain, Snow, Clear など）
 		Description string `json:"description"` // 詳細な天気説明
 	} `json:"weather"`
-	
+
 	// Wind 風に関する情報
 	Wind struct {
 		Speed float64 `json:"speed"` // 風速（m/s）
+		Deg   int     `json:"deg"`   // 風向（度）
 	} `json:"wind"`
-	
+
 	// Clouds 雲量情報
 	Clouds struct {
 		All int `json:"all"` // 雲量（パーセント）
 	} `json:"clouds"`
-	
+
+	// Visibility 視程（メートル）
+	Visibility int `json:"visibility"`
+
+	// Pop 降水確率（0.0〜1.0、予報レスポンスのみ）
+	Pop float64 `json:"pop"`
+
 	// Name 地域名
 	Name string `json:"name"`
 }
 
-//  緯度経度から現在の天気情報を取得
-func (w *OpenWeatherMapAPI) GetByLocation(latitude, longitude float64) (*entities.WeatherCondition, error) {
-	// OpenWeatherMap API URL の構築
-	url := fmt.Sprintf(
-		"https://api.openweathermap.org/data/2.5/weather?lat=%f&lon=%f&appid=%s&units=metric",
-		latitude, longitude, w.apiKey,
-	)
+// OpenWeatherMapForecastResponse 5日間/3時間予報 API からのレスポンス構造体
+type OpenWeatherMapForecastResponse struct {
+	// List 3時間ごとの予報（最大40件）
+	List []OpenWeatherMapResponse `json:"list"`
 
-	// HTTP GET リクエストの実行
-	resp, err := w.client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("天気データの取得に失敗しました: %w", err)
-	}
-	defer resp.Body.Close()
+	// City 予報地点の情報
+	City struct {
+		Name     string `json:"name"`
+		Timezone int    `json:"timezone"` // UTC からの時差（秒）
+	} `json:"city"`
+}
 
-	// HTTP ステータスコードの確認
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("天気API がステータス %d を返しました", resp.StatusCode)
-	}
+// Name プロバイダー名を返す
+func (w *OpenWeatherMapAPI) Name() string {
+	return ProviderOpenWeatherMap
+}
 
-	// JSON レスポンスのパース
+//  緯度経度から現在の天気情報を取得
+func (w *OpenWeatherMapAPI) GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error) {
 	var weatherResp OpenWeatherMapResponse
-	if err := json.NewDecoder(resp.Body).Decode(&weatherResp); err != nil {
-		return nil, fmt.Errorf("天気レスポンスのデコードに失敗しました: %w", err)
+	if err := w.get(ctx, "weather", latitude, longitude, &weatherResp); err != nil {
+		return nil, err
 	}
 
 	// 外部APIデータを内部ドメインエンティティに変換
+	weatherCondition := weatherResp.toWeatherCondition()
+	weatherCondition.Location = weatherResp.Name // 地域名
+
+	return weatherCondition, nil
+}
+
+// 緯度経度から5日間/3時間間隔の天気予報を取得
+func (w *OpenWeatherMapAPI) GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error) {
+	var forecastResp OpenWeatherMapForecastResponse
+	if err := w.get(ctx, "forecast", latitude, longitude, &forecastResp); err != nil {
+		return nil, err
+	}
+
+	hourly := make([]entities.WeatherCondition, 0, len(forecastResp.List))
+	for _, item := range forecastResp.List {
+		condition := item.toWeatherCondition()
+		condition.Location = forecastResp.City.Name
+		hourly = append(hourly, *condition)
+	}
+
+	return &entities.WeatherForecast{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Location:  forecastResp.City.Name,
+		Hourly:    hourly,
+		Daily:     aggregateDaily(hourly, time.FixedZone("", forecastResp.City.Timezone)),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// OpenWeatherMap API を呼び出し、JSON レスポンスを out にデコード
+func (w *OpenWeatherMapAPI) get(ctx context.Context, endpoint string, latitude, longitude float64, out interface{}) error {
+	// OpenWeatherMap API URL の構築
+	url := fmt.Sprintf(
+		"%s/%s?lat=%f&lon=%f&appid=%s&units=metric",
+		w.baseURL, endpoint, latitude, longitude, w.apiKey,
+	)
+
+	// タイムアウト・リトライ・サーキットブレーカー付きで取得
+	return w.client.getJSON(ctx, url, out)
+}
+
+// 外部APIデータを内部ドメインエンティティに変換
+func (r *OpenWeatherMapResponse) toWeatherCondition() *entities.WeatherCondition {
 	weatherCondition := &entities.WeatherCondition{
-		Temperature: weatherResp.Main.Temp,       // 気温
-		FeelsLike:   weatherResp.Main.FeelsLike,  // 体感温度
-		Humidity:    weatherResp.Main.Humidity,   // 湿度
-		WindSpeed:   weatherResp.Wind.Speed,      // 風速
-		CloudCover:  weatherResp.Clouds.All,      // 雲量
-		Location:    weatherResp.Name,            // 地域名
+		Temperature:              r.Main.Temp,      // 気温
+		FeelsLike:                r.Main.FeelsLike, // 体感温度
+		Humidity:                 r.Main.Humidity,  // 湿度
+		Pressure:                 r.Main.Pressure,  // 気圧
+		WindSpeed:                r.Wind.Speed,     // 風速
+		WindDirection:            r.Wind.Deg,       // 風向
+		CloudCover:               r.Clouds.All,     // 雲量
+		Visibility:               r.Visibility,     // 視程
+		PrecipitationProbability: r.Pop,            // 降水確率
+		DateTime:                 time.Unix(r.Dt, 0).UTC(),
 	}
 
 	// 天気状況情報の設定（配列の最初の要素を使用）
-	if len(weatherResp.Weather) > 0 {
-		weatherCondition.Condition = weatherResp.Weather[0].Main           // 主要天気状況
-		weatherCondition.Description = weatherResp.Weather[0].Description  // 詳細説明
+	if len(r.Weather) > 0 {
+		weatherCondition.Condition = openWeatherMapConditionCode(r.Weather[0].ID) // 天気状況
+		weatherCondition.Description = r.Weather[0].Description // 詳細説明
 	}
 
-	return weatherCondition, nil
+	return weatherCondition
 }
@@ -0,0 +1,54 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+
+	"forecast-app/internal/domain/entities"
+)
+
+// 天気データの取得元を抽象化するインターフェース
+// repositories.WeatherRepository はこのインターフェース経由で天気データを取得する
+type WeatherProvider interface {
+	// Name プロバイダー名（ログ出力用）
+	Name() string
+
+	// GetByLocation 緯度経度から現在の天気情報を取得します
+	GetByLocation(ctx context.Context, latitude, longitude float64) (*entities.WeatherCondition, error)
+
+	// GetForecast 緯度経度から今後5日間以上の天気予報を取得します
+	GetForecast(ctx context.Context, latitude, longitude float64) (*entities.WeatherForecast, error)
+}
+
+// プロバイダー名の定義（WEATHER_PROVIDER 環境変数で指定）
+const (
+	ProviderOpenWeatherMap = "openweathermap"
+	ProviderOpenMeteo      = "open-meteo"
+	ProviderMock           = "mock"
+)
+
+// 名前を指定して天気プロバイダーを生成（ファクトリ）
+// name が空の場合、API キーがあれば OpenWeatherMap、なければモックを使用する
+func NewProvider(name, apiKey string, options ClientOptions) (WeatherProvider, error) {
+	if name == "" {
+		name = ProviderMock
+		if apiKey != "" {
+			name = ProviderOpenWeatherMap
+		}
+	}
+
+	switch name {
+	case ProviderOpenWeatherMap:
+		if apiKey == "" {
+			return nil, fmt.Errorf("%s プロバイダーには WEATHER_API_KEY の指定が必要です", name)
+		}
+		return NewOpenWeatherMapAPI(apiKey, options), nil
+	case ProviderOpenMeteo:
+		// Open-Meteo は API キー不要
+		return NewOpenMeteoAPI(options), nil
+	case ProviderMock:
+		return NewMockWeatherProvider(), nil
+	default:
+		return nil, fmt.Errorf("未対応の天気プロバイダーです: %s", name)
+	}
+}
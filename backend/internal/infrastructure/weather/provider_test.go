@@ -0,0 +1,107 @@
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKey  string
+		want    string
+		wantErr bool
+	}{
+		{"", "", ProviderMock, false},
+		{"", "key", ProviderOpenWeatherMap, false},
+		{ProviderOpenMeteo, "", ProviderOpenMeteo, false},
+		{ProviderMock, "key", ProviderMock, false},
+		{ProviderOpenWeatherMap, "", "", true},
+		{"weathernews", "", "", true},
+	}
+	for _, tt := range tests {
+		provider, err := NewProvider(tt.name, tt.apiKey, ClientOptions{})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NewProvider(%q, %q) = %s, want an error", tt.name, tt.apiKey, provider.Name())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewProvider(%q, %q): %v", tt.name, tt.apiKey, err)
+			continue
+		}
+		if provider.Name() != tt.want {
+			t.Errorf("NewProvider(%q, %q) = %s, want %s", tt.name, tt.apiKey, provider.Name(), tt.want)
+		}
+	}
+}
+
+func TestMockWeatherProviderIsDeterministic(t *testing.T) {
+	now := time.Date(2024, 7, 20, 5, 30, 0, 0, time.UTC)
+	provider := NewMockWeatherProviderAt(func() time.Time { return now })
+
+	first, _ := provider.GetByLocation(context.Background(), 35.6895, 139.6917)
+	again, _ := NewMockWeatherProviderAt(func() time.Time { return now.Add(20 * time.Minute) }).GetByLocation(context.Background(), 35.6895, 139.6917)
+	if *first != *again {
+		t.Errorf("same hour returned %+v and %+v, want identical conditions", first, again)
+	}
+	if first.Condition == "" || first.Humidity < 45 || first.Humidity > 100 || first.CloudCover > 100 {
+		t.Errorf("condition = %+v, want a plausible observation", first)
+	}
+}
+
+func TestMockWeatherProviderSeasons(t *testing.T) {
+	provider := NewMockWeatherProvider()
+	meanTemperature := func(latitude float64, month time.Month) float64 {
+		sum := 0.0
+		for day := 1; day <= 28; day++ {
+			for hour := 0; hour < 24; hour += 3 {
+				sum += provider.GetAt(latitude, 139.7, time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)).Temperature
+			}
+		}
+		return sum / (28 * 8)
+	}
+
+	// 北半球は夏が暑く、南半球は季節が逆になる
+	if summer, winter := meanTemperature(35.7, time.July), meanTemperature(35.7, time.January); summer-winter < 10 {
+		t.Errorf("northern July %.1f / January %.1f, want a clear seasonal difference", summer, winter)
+	}
+	if summer, winter := meanTemperature(-35.7, time.January), meanTemperature(-35.7, time.July); summer-winter < 10 {
+		t.Errorf("southern January %.1f / July %.1f, want a clear seasonal difference", summer, winter)
+	}
+	// 赤道付近は高緯度より暖かい
+	if tropics, arctic := meanTemperature(1.3, time.January), meanTemperature(64.8, time.January); tropics-arctic < 20 {
+		t.Errorf("January tropics %.1f / arctic %.1f, want the tropics much warmer", tropics, arctic)
+	}
+}
+
+func TestMockWeatherProviderForecast(t *testing.T) {
+	now := time.Date(2024, 3, 10, 4, 15, 0, 0, time.UTC)
+	provider := NewMockWeatherProviderAt(func() time.Time { return now })
+
+	forecast, err := provider.GetForecast(context.Background(), 35.6895, 139.6917)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forecast.Hourly) != mockForecastSteps {
+		t.Fatalf("hourly = %d entries, want %d", len(forecast.Hourly), mockForecastSteps)
+	}
+	if want := time.Date(2024, 3, 10, 6, 0, 0, 0, time.UTC); !forecast.Hourly[0].DateTime.Equal(want) {
+		t.Errorf("first entry at %v, want the next 3-hour boundary %v", forecast.Hourly[0].DateTime, want)
+	}
+	for i := 1; i < len(forecast.Hourly); i++ {
+		if step := forecast.Hourly[i].DateTime.Sub(forecast.Hourly[i-1].DateTime); step != 3*time.Hour {
+			t.Fatalf("entry %d is %v after the previous one, want 3h", i, step)
+		}
+	}
+	if len(forecast.Daily) < 5 {
+		t.Errorf("daily = %d days, want at least 5", len(forecast.Daily))
+	}
+	for _, day := range forecast.Daily {
+		if day.MinTemperature > day.MaxTemperature {
+			t.Errorf("%s: min %.1f above max %.1f", day.Date, day.MinTemperature, day.MaxTemperature)
+		}
+	}
+}
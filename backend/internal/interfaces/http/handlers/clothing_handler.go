@@ -2,19 +2,63 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
 	"net/http"
+	"net/url"
 	"path"
+	"strconv"
+	"strings"
 
 	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
 )
 
 type ClothingHandler struct {
 	clothingUseCase *usecases.ClothingUseCase
+	imageUseCase    *usecases.ImageUseCase
 }
 
-func NewClothingHandler(clothingUseCase *usecases.ClothingUseCase) *ClothingHandler {
+func NewClothingHandler(clothingUseCase *usecases.ClothingUseCase, imageUseCase *usecases.ImageUseCase) *ClothingHandler {
 	return &ClothingHandler{
 		clothingUseCase: clothingUseCase,
+		imageUseCase:    imageUseCase,
+	}
+}
+
+// ServeClothingPath /api/clothing/ 配下のリクエストをパスとメソッドで振り分けます
+func (h *ClothingHandler) ServeClothingPath(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/clothing/"), "/")
+	if id == "" {
+		h.GetUserClothing(w, r)
+		return
+	}
+
+	if id, action, found := strings.Cut(id, "/"); found {
+		switch action {
+		case "wear-history":
+			h.GetWearHistory(w, r, id)
+		case "image":
+			h.UploadClothingImage(w, r, id)
+		default:
+			http.NotFound(w, r)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetClothingItem(w, r)
+	case http.MethodPut:
+		h.UpdateClothingItem(w, r)
+	case http.MethodDelete:
+		h.DeleteClothingItem(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
@@ -49,6 +93,11 @@ func (h *ClothingHandler) CreateClothingItem(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(clothing)
 }
 
+// GetUserClothing クローゼットを検索条件で絞り込み、並び替えて返します
+// 条件はクエリパラメータで指定します（category・color・brand・availability は複数指定またはカンマ区切り）:
+// category, color, brand, min_warmth, max_warmth, season, availability, q, sort, order, limit, cursor
+// limit・cursor を省略した場合は従来どおりクローゼット全体を返します
+// 次のページがある場合は X-Next-Cursor ヘッダーにカーソルを、Link ヘッダーに次のページのURLを設定します
 func (h *ClothingHandler) GetUserClothing(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -61,14 +110,75 @@ func (h *ClothingHandler) GetUserClothing(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	clothing, err := h.clothingUseCase.GetUserClothing(userID)
+	req, err := parseClothingSearchRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.clothingUseCase.SearchClothing(userID, req)
+	if errors.Is(err, usecases.ErrInvalidClothingQuery) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	if result.NextCursor != "" {
+		next := *r.URL
+		query := next.Query()
+		query.Set("cursor", result.NextCursor)
+		next.RawQuery = query.Encode()
+		w.Header().Set("X-Next-Cursor", result.NextCursor)
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(clothing)
+	json.NewEncoder(w).Encode(result.Items)
+}
+
+// クエリパラメータからクローゼットの検索条件を作成
+func parseClothingSearchRequest(values url.Values) (usecases.ClothingSearchRequest, error) {
+	req := usecases.ClothingSearchRequest{
+		Categories: queryList(values, "category"),
+		Colors:     queryList(values, "color"),
+		Brands:     queryList(values, "brand"),
+		Season:     entities.Season(values.Get("season")),
+		Query:      strings.TrimSpace(values.Get("q")),
+		Sort:       repositories.ClothingSort(values.Get("sort")),
+		Order:      values.Get("order"),
+		Cursor:     values.Get("cursor"),
+	}
+	for _, availability := range queryList(values, "availability") {
+		req.Availabilities = append(req.Availabilities, entities.Availability(availability))
+	}
+
+	for name, target := range map[string]*int{"min_warmth": &req.MinWarmth, "max_warmth": &req.MaxWarmth, "limit": &req.Limit} {
+		value := values.Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return req, fmt.Errorf("invalid %s parameter", name)
+		}
+		*target = n
+	}
+	return req, nil
+}
+
+// 複数指定またはカンマ区切りのクエリパラメータを値の一覧に変換（空の値は除く）
+func queryList(values url.Values, name string) []string {
+	var list []string
+	for _, value := range values[name] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				list = append(list, v)
+			}
+		}
+	}
+	return list
 }
 
 func (h *ClothingHandler) GetClothingItem(w http.ResponseWriter, r *http.Request) {
@@ -77,6 +187,12 @@ func (h *ClothingHandler) GetClothingItem(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	id := path.Base(r.URL.Path)
 	if id == "" {
 		http.Error(w, "Clothing item ID is required", http.StatusBadRequest)
@@ -84,15 +200,239 @@ func (h *ClothingHandler) GetClothingItem(w http.ResponseWriter, r *http.Request
 	}
 
 	clothing, err := h.clothingUseCase.GetClothingByID(id)
+	if err != nil || clothing.UserID != userID {
+		http.Error(w, "clothing item not found", http.StatusNotFound)
+		return
+	}
+
+	setETag(w, clothing.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clothing)
+}
+
+// GetWearHistory 衣服アイテムの着用履歴を返します
+func (h *ClothingHandler) GetWearHistory(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	history, err := h.clothingUseCase.GetWearHistory(id, userID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// UploadClothingImage 衣服アイテムの画像をアップロードして差し替えます（multipart/form-data の image フィールド）
+// 画像から抽出した色を提案し、?apply_color=true の場合は登録済みの色を提案した色で上書きします
+func (h *ClothingHandler) UploadClothingImage(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	applyColor := false
+	if value := r.URL.Query().Get("apply_color"); value != "" {
+		if applyColor, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "Invalid apply_color parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	data, contentType, err := readImageUpload(w, r, h.imageUseCase.MaxBytes())
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+
+	result, err := h.imageUseCase.AttachClothingImage(r.Context(), id, userID, data, contentType, expectedVersion, applyColor)
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+
+	setETag(w, result.Clothing.Version)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// BulkUpdateAvailability 複数の衣服アイテムの利用状況をまとめて変更します
+func (h *ClothingHandler) BulkUpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req usecases.BulkAvailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	clothing, err := h.clothingUseCase.BulkUpdateAvailability(userID, req)
+	if err != nil {
+		writeUpdateError(w, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(clothing)
 }
 
+// インポートするファイルのサイズの上限（バイト）
+const maxClothingImportBytes = 5 << 20
+
+// ImportClothing CSV・JSON のファイルから衣服アイテムをまとめて登録します
+// 形式は ?format=csv|json、省略時は Content-Type（text/csv・application/json）で判定します
+// ?dry_run=true の場合は検証のみ行います。いずれかの行にエラーがある場合は何も登録せず、行ごとのエラーを 422 で返します
+func (h *ClothingHandler) ImportClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "Invalid dry_run parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	format := usecases.ClothingFileFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = clothingFormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxClothingImportBytes)
+	result, err := h.clothingUseCase.ImportClothing(userID, format, r.Body, dryRun)
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	case errors.Is(err, usecases.ErrUnsupportedClothingFormat):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	case errors.Is(err, usecases.ErrInvalidClothingImport):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case len(result.Errors) > 0:
+		status = http.StatusUnprocessableEntity
+	case result.DryRun:
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
+
+// ExportClothing クローゼットの全アイテムを ?format=csv|json（省略時は json）のファイルとしてストリーミングで返します
+func (h *ClothingHandler) ExportClothing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	format := usecases.ClothingFileFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = usecases.ClothingFormatJSON
+	}
+	if !format.IsValid() {
+		http.Error(w, "format must be csv or json", http.StatusBadRequest)
+		return
+	}
+
+	contentType := "application/json"
+	if format == usecases.ClothingFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="closet.%s"`, format))
+
+	// 書き出しを始めた後はステータスを変更できないため、途中のエラーはログに記録するのみとする
+	out := &trackingWriter{w: w}
+	if err := h.clothingUseCase.ExportClothing(userID, format, out); err != nil {
+		if !out.written {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.Printf("failed to export clothing items for user %s: %v", userID, err)
+	}
+}
+
+// Content-Type からインポートするファイルの形式を判定（判定できない場合は空）
+func clothingFormatFromContentType(contentType string) usecases.ClothingFileFormat {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return usecases.ClothingFormatJSON
+	case "text/csv", "application/csv":
+		return usecases.ClothingFormatCSV
+	}
+	return usecases.ClothingFileFormat(mediaType)
+}
+
+// 書き込みが行われたかを記録する io.Writer
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.w.Write(p)
+}
+
 func (h *ClothingHandler) UpdateClothingItem(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -117,12 +457,19 @@ func (h *ClothingHandler) UpdateClothingItem(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	clothing, err := h.clothingUseCase.UpdateClothingItem(id, userID, req)
+	expectedVersion, err := parseIfMatch(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	clothing, err := h.clothingUseCase.UpdateClothingItem(id, userID, req, expectedVersion)
+	if err != nil {
+		writeUpdateError(w, err)
+		return
+	}
+
+	setETag(w, clothing.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(clothing)
 }
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/repositories"
+)
+
+// エンティティのバージョンを ETag ヘッダーに設定
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// If-Match ヘッダーからクライアントが参照したバージョンを取得
+// ヘッダー未指定または "*" の場合は 0（バージョン確認なし）を返す
+func parseIfMatch(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version <= 0 {
+		return 0, errors.New("invalid If-Match header")
+	}
+	return version, nil
+}
+
+// 更新系のユースケースエラーを HTTP ステータスに変換して返却
+// If-Match 不一致は 412、更新中の競合は 409、それ以外は 400
+func writeUpdateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrPreconditionFailed):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, repositories.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/repositories"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"*", 0, false},
+		{`"3"`, 3, false},
+		{`W/"4"`, 4, false},
+		{"5", 5, false},
+		{`"0"`, 0, true},
+		{`"abc"`, 0, true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/api/clothing/c1", nil)
+		if tt.header != "" {
+			r.Header.Set("If-Match", tt.header)
+		}
+		version, err := parseIfMatch(r)
+		if version != tt.version || (err != nil) != tt.wantErr {
+			t.Errorf("parseIfMatch(%q) = %d, %v, want %d (error %v)", tt.header, version, err, tt.version, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteUpdateError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"precondition failed", usecases.ErrPreconditionFailed, http.StatusPreconditionFailed},
+		{"conflict", fmt.Errorf("failed to update: %w", &repositories.ConflictError{Entity: "clothing item", ID: "c1", ExpectedVersion: 1, CurrentVersion: 2}), http.StatusConflict},
+		{"validation", fmt.Errorf("name is required"), http.StatusBadRequest},
+		{"unknown strategy", fmt.Errorf("%w: random", usecases.ErrUnknownRecommendationStrategy), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		writeUpdateError(rec, tt.err)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.status)
+		}
+	}
+}
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/repositories"
 )
 
 type FashionHandler struct {
@@ -38,16 +42,103 @@ func (h *FashionHandler) GetRecommendations(w http.ResponseWriter, r *http.Reque
 
 	req.UserID = userID
 
-	recommendation, err := h.fashionUseCase.GetRecommendations(req)
+	recommendation, err := h.fashionUseCase.GetRecommendations(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeRecommendationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recommendation)
+}
+
+// ServeRecommendationPath /api/recommendations/ 配下のリクエストをパスで振り分けます
+func (h *FashionHandler) ServeRecommendationPath(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/recommendations/"), "/")
+	if path == "" {
+		h.GetUserRecommendations(w, r)
+		return
+	}
+
+	id, action, _ := strings.Cut(path, "/")
+	switch action {
+	case "feedback":
+		h.SubmitFeedback(w, r, id)
+	case "accept":
+		h.AcceptRecommendation(w, r, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// SubmitFeedback 推奨結果への体感・アイテム評価のフィードバックを記録します
+func (h *FashionHandler) SubmitFeedback(w http.ResponseWriter, r *http.Request, recommendationID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req usecases.FeedbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	recommendation, err := h.fashionUseCase.SubmitFeedback(userID, recommendationID, req)
+	if err != nil {
+		if errors.Is(err, usecases.ErrRecommendationNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeUpdateError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	setETag(w, recommendation.Version)
 	json.NewEncoder(w).Encode(recommendation)
 }
 
+// AcceptRecommendation 推奨されたコーディネートを採用し、アイテムの着用を記録します
+// リクエストボディは省略可能（最上位のコーディネートを当日に着用したものとして記録）
+func (h *FashionHandler) AcceptRecommendation(w http.ResponseWriter, r *http.Request, recommendationID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req usecases.AcceptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.fashionUseCase.AcceptRecommendation(userID, recommendationID, req)
+	if err != nil {
+		if errors.Is(err, usecases.ErrRecommendationNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeUpdateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
 func (h *FashionHandler) GetUserRecommendations(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -109,12 +200,25 @@ func (h *FashionHandler) GetRecommendationsLegacy(w http.ResponseWriter, r *http
 		Location:  location,
 	}
 
-	recommendation, err := h.fashionUseCase.GetRecommendations(req)
+	recommendation, err := h.fashionUseCase.GetRecommendations(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeRecommendationError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(recommendation)
 }
+
+// 推奨生成のエラーをステータスコードに変換して返す
+// 天気サービスが利用できず代替データもない場合は 503、外出時間帯や地点の指定が不正な場合は 400 を返す
+func writeRecommendationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrWeatherUnavailable):
+		writeWeatherUnavailable(w, err)
+	case errors.Is(err, usecases.ErrInvalidTimeWindow), errors.Is(err, usecases.ErrInvalidCoordinates):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
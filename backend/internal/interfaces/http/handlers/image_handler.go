@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/services"
+)
+
+// アップロードする画像のフォームフィールド名
+const imageFormField = "image"
+
+// マルチパートのヘッダーなど、画像本体以外に許容するリクエストサイズ
+const multipartOverhead = 64 << 10
+
+type ImageHandler struct {
+	imageUseCase *usecases.ImageUseCase
+}
+
+func NewImageHandler(imageUseCase *usecases.ImageUseCase) *ImageHandler {
+	return &ImageHandler{
+		imageUseCase: imageUseCase,
+	}
+}
+
+// UploadImage multipart/form-data の image フィールドで送信された画像を保存し、署名付きURLを返します
+func (h *ImageHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	data, contentType, err := readImageUpload(w, r, h.imageUseCase.MaxBytes())
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+
+	uploaded, err := h.imageUseCase.UploadImage(r.Context(), userID, data, contentType)
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(uploaded)
+}
+
+// ServeImage 署名付きURLで画像を配信します（<img> から参照できるよう認証は署名で代替）
+func (h *ImageHandler) ServeImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, usecases.ImageRoutePrefix)
+	blob, err := h.imageUseCase.OpenImage(r.Context(), path, r.URL.Query().Get("sig"))
+	switch {
+	case errors.Is(err, usecases.ErrInvalidImageSignature):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, usecases.ErrImageNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer blob.Body.Close()
+
+	// 同じキーの画像が書き換えられることはないため、長期間キャッシュさせる
+	w.Header().Set("Content-Type", blob.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	if blob.Size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(blob.Size, 10))
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+	io.Copy(w, blob.Body)
+}
+
+// マルチパートのリクエストから画像フィールドを読み込む（他のフィールドは無視する）
+// 画像の Content-Type はパートのヘッダーで宣言されたものを返す
+func readImageUpload(w http.ResponseWriter, r *http.Request, maxBytes int64) ([]byte, string, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil, "", errors.New("request must be multipart/form-data")
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid multipart request: %w", err)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, "", fmt.Errorf("%s field is required", imageFormField)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid multipart request: %w", err)
+		}
+		if part.FormName() != imageFormField {
+			part.Close()
+			continue
+		}
+
+		// 上限を1バイト超えて読み込み、超過を検出する
+		data, err := io.ReadAll(io.LimitReader(part, maxBytes+1))
+		part.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid multipart request: %w", err)
+		}
+		if int64(len(data)) > maxBytes {
+			return nil, "", services.ErrImageTooLarge
+		}
+		return data, part.Header.Get("Content-Type"), nil
+	}
+}
+
+// 画像アップロードのエラーを HTTP ステータスに変換して返却
+// サイズ超過は 413、非対応の形式は 415、保存先の障害は 500、更新の競合は writeUpdateError に従い、それ以外は 400
+func writeImageError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, usecases.ErrImageStorage):
+		log.Printf("failed to store image: %v", err)
+		http.Error(w, "failed to store image", http.StatusInternalServerError)
+	case errors.Is(err, services.ErrImageTooLarge), errors.As(err, &maxBytesErr):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, services.ErrUnsupportedImageType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	default:
+		writeUpdateError(w, err)
+	}
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/repositories"
+	"forecast-app/internal/domain/services"
+)
+
+func TestWriteImageError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"too large", fmt.Errorf("%w: limit", services.ErrImageTooLarge), http.StatusRequestEntityTooLarge},
+		{"request body too large", &http.MaxBytesError{Limit: 10}, http.StatusRequestEntityTooLarge},
+		{"unsupported type", services.ErrUnsupportedImageType, http.StatusUnsupportedMediaType},
+		{"storage failure", fmt.Errorf("%w: failed to store image: %w", usecases.ErrImageStorage, errors.New("secret bucket path")), http.StatusInternalServerError},
+		{"precondition failed", usecases.ErrPreconditionFailed, http.StatusPreconditionFailed},
+		{"conflict", repositories.ErrConflict, http.StatusConflict},
+		{"invalid image", services.ErrInvalidImage, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeImageError(rec, tt.err)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusInternalServerError && strings.Contains(rec.Body.String(), "secret bucket path") {
+				t.Errorf("body leaks the storage error: %q", rec.Body.String())
+			}
+		})
+	}
+}
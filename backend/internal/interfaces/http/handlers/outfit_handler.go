@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"path"
+	"strings"
 
 	"forecast-app/internal/application/usecases"
 )
@@ -109,7 +110,20 @@ func (h *OutfitHandler) GetOutfitPost(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(outfitPost)
 }
 
-func (h *OutfitHandler) LikeOutfitPost(w http.ResponseWriter, r *http.Request) {
+// /api/outfit-posts/{id}/like へのリクエストを振り分け
+func (h *OutfitHandler) ServeOutfitPostPath(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/outfit-posts/"), "/")
+	id, action, _ := strings.Cut(path, "/")
+	switch action {
+	case "like":
+		h.LikeOutfitPost(w, r, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// LikeOutfitPost POST /api/outfit-posts/{id}/like 投稿にいいねします
+func (h *OutfitHandler) LikeOutfitPost(w http.ResponseWriter, r *http.Request, id string) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -121,7 +135,6 @@ func (h *OutfitHandler) LikeOutfitPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := path.Base(r.URL.Path)
 	if id == "" {
 		http.Error(w, "Outfit post ID is required", http.StatusBadRequest)
 		return
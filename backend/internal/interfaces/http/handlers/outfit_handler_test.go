@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/entities"
+	infrarepo "forecast-app/internal/infrastructure/repositories"
+)
+
+func TestServeOutfitPostPathLike(t *testing.T) {
+	posts := infrarepo.NewInMemoryOutfitPostRepository()
+	handler := NewOutfitHandler(usecases.NewOutfitUseCase(posts, nil))
+	post := &entities.OutfitPost{UserID: "user-2", Items: []string{"シャツ"}}
+	if err := posts.Create(post); err != nil {
+		t.Fatal(err)
+	}
+
+	like := func(method, target string) int {
+		req := httptest.NewRequest(method, target, nil)
+		req = req.WithContext(context.WithValue(req.Context(), "user_id", "user-1"))
+		rec := httptest.NewRecorder()
+		handler.ServeOutfitPostPath(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := like(http.MethodPost, "/api/outfit-posts/"+post.ID+"/like"); code != http.StatusOK {
+			t.Fatalf("like status = %d, want 200", code)
+		}
+	}
+	stored, err := posts.GetByID(post.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Likes != 2 {
+		t.Errorf("likes = %d, want 2", stored.Likes)
+	}
+
+	tests := []struct {
+		method string
+		target string
+		status int
+	}{
+		{http.MethodGet, "/api/outfit-posts/" + post.ID + "/like", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/api/outfit-posts/missing/like", http.StatusBadRequest},
+		{http.MethodPost, "/api/outfit-posts/" + post.ID + "/share", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if code := like(tt.method, tt.target); code != tt.status {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.target, code, tt.status)
+		}
+	}
+}
@@ -102,6 +102,7 @@ func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// プロフィール情報をJSON形式で返却
+	setETag(w, user.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(user)
 }
@@ -130,6 +131,7 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 			PreferredColors []string `json:"preferred_colors"` // 好みの色
 			PreferredBrands []string `json:"preferred_brands"` // 好みのブランド
 			Style           string   `json:"style"`             // 好みのスタイル
+			RecommendationStrategy string `json:"recommendation_strategy"` // 使用する推奨戦略
 		} `json:"preferences"`
 	}
 
@@ -144,16 +146,25 @@ func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		PreferredColors: req.Preferences.PreferredColors,
 		PreferredBrands: req.Preferences.PreferredBrands,
 		Style:           req.Preferences.Style,
+		RecommendationStrategy: req.Preferences.RecommendationStrategy,
+	}
+
+	// If-Match ヘッダーから参照元のバージョンを取得（楽観的排他制御）
+	expectedVersion, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, "無効な If-Match ヘッダーです", http.StatusBadRequest)
+		return
 	}
 
 	// ユースケースでプロフィール更新を実行
-	user, err := h.userUseCase.UpdateProfile(userID, req.Name, preferences)
+	user, err := h.userUseCase.UpdateProfile(userID, req.Name, preferences, expectedVersion)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeUpdateError(w, err)
 		return
 	}
 
 	// 更新されたプロフィール情報を返却
+	setETag(w, user.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(user)
 }
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+)
+
+type WeatherHandler struct {
+	weatherUseCase *usecases.WeatherUseCase
+}
+
+func NewWeatherHandler(weatherUseCase *usecases.WeatherUseCase) *WeatherHandler {
+	return &WeatherHandler{
+		weatherUseCase: weatherUseCase,
+	}
+}
+
+// フロントエンドの WeatherCondition 型に対応するレスポンス
+type weatherConditionResponse struct {
+	Temperature              float64 `json:"temperature"`
+	FeelsLike                float64 `json:"feelsLike"`
+	Description              string  `json:"description"`
+	WindDirection            int     `json:"windDirection"`
+	Visibility               int     `json:"visibility"`
+	UVIndex                  float64 `json:"uvIndex"`
+	Condition                string  `json:"condition"`
+	ConditionName            string  `json:"conditionName"`
+	Humidity                 int     `json:"humidity"`
+	WindSpeed                float64 `json:"windSpeed"`
+	CloudCover               int     `json:"cloudCover"`
+	PrecipitationProbability float64 `json:"precipitationProbability"`
+	Location                 string  `json:"location"`
+	DateTime                 string  `json:"dateTime"`
+	Pressure                 int     `json:"pressure"`
+}
+
+// フロントエンドの WeatherForecastDaily 型に対応するレスポンス
+type dailyWeatherResponse struct {
+	weatherConditionResponse
+	Date           string  `json:"date"`
+	MinTemperature float64 `json:"minTemperature"`
+	MaxTemperature float64 `json:"maxTemperature"`
+}
+
+// フロントエンドの WeatherForecast 型に対応するレスポンス
+type weatherForecastResponse struct {
+	Location struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"location"`
+	Daily       []dailyWeatherResponse     `json:"daily"`
+	Hourly      []weatherConditionResponse `json:"hourly"`
+	LastUpdated string                     `json:"lastUpdated"`
+	Stale       bool                       `json:"stale"` // 天気サービスの障害中にキャッシュの古い予報を返している場合に true
+}
+
+// GetForecast GET /api/weather/forecast?lat=..&lon=.. 天気予報を返します
+func (h *WeatherHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lat, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	if err != nil {
+		http.Error(w, "Invalid latitude", http.StatusBadRequest)
+		return
+	}
+
+	lon, err := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+	if err != nil {
+		http.Error(w, "Invalid longitude", http.StatusBadRequest)
+		return
+	}
+
+	forecast, err := h.weatherUseCase.GetForecast(r.Context(), lat, lon)
+	if errors.Is(err, usecases.ErrInvalidCoordinates) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, repositories.ErrWeatherUnavailable) {
+		writeWeatherUnavailable(w, err)
+		return
+	}
+	if err != nil {
+		log.Printf("failed to get weather forecast: %v", err)
+		http.Error(w, "failed to get weather forecast", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newWeatherForecastResponse(forecast, preferredLanguage(r)))
+}
+
+func newWeatherForecastResponse(forecast *entities.WeatherForecast, language string) weatherForecastResponse {
+	var resp weatherForecastResponse
+	resp.Location.Lat = forecast.Latitude
+	resp.Location.Lon = forecast.Longitude
+	resp.LastUpdated = forecast.UpdatedAt.Format(time.RFC3339)
+	resp.Stale = forecast.Stale
+
+	resp.Hourly = make([]weatherConditionResponse, 0, len(forecast.Hourly))
+	for i := range forecast.Hourly {
+		resp.Hourly = append(resp.Hourly, newWeatherConditionResponse(&forecast.Hourly[i], language))
+	}
+
+	resp.Daily = make([]dailyWeatherResponse, 0, len(forecast.Daily))
+	for i := range forecast.Daily {
+		day := &forecast.Daily[i]
+		resp.Daily = append(resp.Daily, dailyWeatherResponse{
+			weatherConditionResponse: newWeatherConditionResponse(&day.WeatherCondition, language),
+			Date:                     day.Date,
+			MinTemperature:           day.MinTemperature,
+			MaxTemperature:           day.MaxTemperature,
+		})
+	}
+
+	return resp
+}
+
+func newWeatherConditionResponse(condition *entities.WeatherCondition, language string) weatherConditionResponse {
+	return weatherConditionResponse{
+		Temperature:              condition.Temperature,
+		FeelsLike:                condition.FeelsLike,
+		Description:              condition.Description,
+		WindDirection:            condition.WindDirection,
+		Visibility:               condition.Visibility,
+		Condition:                string(condition.Condition),
+		ConditionName:            condition.Condition.DisplayName(language),
+		Humidity:                 condition.Humidity,
+		WindSpeed:                condition.WindSpeed,
+		CloudCover:               condition.CloudCover,
+		PrecipitationProbability: condition.PrecipitationProbability,
+		Location:                 condition.Location,
+		DateTime:                 condition.DateTime.Format(time.RFC3339),
+		Pressure:                 condition.Pressure,
+	}
+}
+
+// 天気サービスが利用できない場合に 503 を返す
+// 上流のエラーには接続先などの内部情報が含まれるため、ログにのみ記録してクライアントには定型のメッセージを返す
+func writeWeatherUnavailable(w http.ResponseWriter, err error) {
+	log.Printf("weather service unavailable: %v", err)
+	w.Header().Set("Retry-After", "30")
+	http.Error(w, "weather service is temporarily unavailable", http.StatusServiceUnavailable)
+}
+
+// Accept-Language ヘッダーから天気状況の表示言語を決定（未指定・未対応は日本語）
+func preferredLanguage(r *http.Request) string {
+	for _, tag := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag = strings.ToLower(strings.TrimSpace(strings.SplitN(tag, ";", 2)[0]))
+		switch {
+		case strings.HasPrefix(tag, entities.LanguageJapanese):
+			return entities.LanguageJapanese
+		case strings.HasPrefix(tag, entities.LanguageEnglish):
+			return entities.LanguageEnglish
+		}
+	}
+	return entities.LanguageJapanese
+}
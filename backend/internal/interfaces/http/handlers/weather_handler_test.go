@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/entities"
+	"forecast-app/internal/domain/repositories"
+	infrarepo "forecast-app/internal/infrastructure/repositories"
+	"forecast-app/internal/infrastructure/weather"
+)
+
+func TestWriteRecommendationError(t *testing.T) {
+	upstream := fmt.Errorf("%w: Get \"https://api.openweathermap.org/data/2.5/weather?appid=secret-key\": dial tcp: timeout", repositories.ErrWeatherUnavailable)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"weather unavailable", upstream, http.StatusServiceUnavailable},
+		{"invalid time window", usecases.ErrInvalidTimeWindow, http.StatusBadRequest},
+		{"invalid coordinates", usecases.ErrInvalidCoordinates, http.StatusBadRequest},
+		{"other", errors.New("unexpected"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeRecommendationError(rec, tt.err)
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestWriteWeatherUnavailableHidesUpstreamError(t *testing.T) {
+	err := fmt.Errorf("%w: Get \"https://api.openweathermap.org/data/2.5/weather?appid=secret-key\": dial tcp: timeout", repositories.ErrWeatherUnavailable)
+
+	rec := httptest.NewRecorder()
+	writeWeatherUnavailable(rec, err)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+	for _, secret := range []string{"secret-key", "appid", "openweathermap"} {
+		if strings.Contains(rec.Body.String(), secret) {
+			t.Errorf("body %q contains %q", rec.Body.String(), secret)
+		}
+	}
+}
+
+func TestPreferredLanguage(t *testing.T) {
+	tests := map[string]string{
+		"":                   "ja",
+		"en-US,en;q=0.9":     "en",
+		"ja-JP,en;q=0.8":     "ja",
+		"fr-FR, en-GB;q=0.7": "en",
+		"de":                 "ja",
+	}
+	for header, want := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/weather/forecast", nil)
+		r.Header.Set("Accept-Language", header)
+		if got := preferredLanguage(r); got != want {
+			t.Errorf("preferredLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestGetForecast(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	handler := NewWeatherHandler(usecases.NewWeatherUseCase(infrarepo.NewWeatherRepository(weather.NewMockWeatherProviderAt(func() time.Time { return now }))))
+
+	get := func(target, language string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept-Language", language)
+		rec := httptest.NewRecorder()
+		handler.GetForecast(rec, req)
+		return rec
+	}
+
+	rec := get("/api/weather/forecast?lat=35.6895&lon=139.6917", "en-US")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var resp weatherForecastResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Location.Lat != 35.6895 || len(resp.Hourly) == 0 || len(resp.Daily) < 5 || resp.LastUpdated != now.Format(time.RFC3339) {
+		t.Errorf("response = location %+v hourly %d daily %d updated %q, want the 5-day forecast", resp.Location, len(resp.Hourly), len(resp.Daily), resp.LastUpdated)
+	}
+	hour := resp.Hourly[0]
+	if hour.Condition == "" || hour.ConditionName != entities.WeatherConditionCode(hour.Condition).DisplayName(entities.LanguageEnglish) {
+		t.Errorf("condition %q name %q, want the English display name", hour.Condition, hour.ConditionName)
+	}
+
+	if resp.Stale {
+		t.Error("fresh forecast response is marked stale")
+	}
+	// 障害中に古い予報を返している場合はクライアントに伝える
+	if stale := newWeatherForecastResponse(&entities.WeatherForecast{Stale: true}, entities.LanguageJapanese); !stale.Stale {
+		t.Error("stale forecast response is not marked stale")
+	}
+
+	for target, status := range map[string]int{
+		"/api/weather/forecast?lat=abc&lon=139.6917": http.StatusBadRequest,
+		"/api/weather/forecast?lat=35.6895":          http.StatusBadRequest,
+		"/api/weather/forecast?lat=95&lon=139.6917":  http.StatusBadRequest,
+	} {
+		if rec := get(target, ""); rec.Code != status {
+			t.Errorf("GET %s status = %d, want %d", target, rec.Code, status)
+		}
+	}
+}
@@ -91,7 +91,8 @@ func (m *AuthMiddleware) CORS(next http.HandlerFunc) http.HandlerFunc {
 		// CORS ヘッダーの設定
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, X-Next-Cursor, Link, Content-Disposition")
 
 		// プリフライトリクエストの処理
 		if r.Method == http.MethodOptions {
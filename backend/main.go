@@ -1,15 +1,28 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"expvar"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"forecast-app/internal/application/usecases"
+	"forecast-app/internal/domain/repositories"
 	"forecast-app/internal/domain/services"
-	"forecast-app/internal/infrastructure/repositories"
+	"forecast-app/internal/infrastructure/blobstore"
+	"forecast-app/internal/infrastructure/database"
+	infrarepo "forecast-app/internal/infrastructure/repositories"
+	"forecast-app/internal/infrastructure/weather"
 	"forecast-app/internal/interfaces/http/handlers"
 	"forecast-app/internal/interfaces/http/middleware"
+
+	"golang.org/x/crypto/hkdf"
 )
 
 func main() {
@@ -19,10 +32,22 @@ func main() {
 		port = "8080"
 	}
 
-	weatherAPIKey := os.Getenv("WEATHER_API_KEY")
-	if weatherAPIKey == "" {
-		log.Println("Warning: WEATHER_API_KEY not set, using mock weather data")
-		weatherAPIKey = "mock-key"
+	// WEATHER_PROVIDER: openweathermap / open-meteo / mock（未指定時は WEATHER_API_KEY の有無で自動選択）
+	// 天気API呼び出しのタイムアウト・リトライ・サーキットブレーカー設定（未指定時はデフォルト値）
+	// WEATHER_BASE_URL: 天気APIのベースURL（未指定時は各プロバイダーの公開エンドポイント）
+	weatherClientOptions := weather.ClientOptions{
+		BaseURL:          os.Getenv("WEATHER_BASE_URL"),
+		Timeout:          envDuration("WEATHER_TIMEOUT", 0),
+		MaxRetries:       envInt("WEATHER_MAX_RETRIES", 0),
+		BreakerThreshold: envInt("WEATHER_BREAKER_THRESHOLD", 0),
+		BreakerCooldown:  envDuration("WEATHER_BREAKER_COOLDOWN", 0),
+	}
+	weatherProvider, err := weather.NewProvider(os.Getenv("WEATHER_PROVIDER"), os.Getenv("WEATHER_API_KEY"), weatherClientOptions)
+	if err != nil {
+		log.Fatalf("天気プロバイダーの初期化に失敗しました: %v", err)
+	}
+	if weatherProvider.Name() == weather.ProviderMock {
+		log.Println("Warning: using mock weather data (set WEATHER_API_KEY or WEATHER_PROVIDER to use a real provider)")
 	}
 
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -32,36 +57,212 @@ func main() {
 	}
 
 	// リポジトリインスタンス生成
-	userRepo := repositories.NewInMemoryUserRepository()
-	clothingRepo := repositories.NewInMemoryClothingRepository()
-	fashionRepo := repositories.NewInMemoryFashionRecommendationRepository()
-	outfitRepo := repositories.NewInMemoryOutfitPostRepository()
-	weatherRepo := repositories.NewWeatherRepository(weatherAPIKey)
+	// STORAGE_DRIVER: memory（デフォルト）/ sqlite / postgres
+	// DATABASE_URL: 接続先（sqlite の場合はファイルパス、postgres の場合は接続URL）
+	store, err := setupStorage(os.Getenv("STORAGE_DRIVER"), os.Getenv("DATABASE_URL"), database.PoolOptions{
+		MaxOpenConns:    envInt("DB_MAX_OPEN_CONNS", 0),
+		MaxIdleConns:    envInt("DB_MAX_IDLE_CONNS", 0),
+		ConnMaxLifetime: envDuration("DB_CONN_MAX_LIFETIME", 0),
+		ConnMaxIdleTime: envDuration("DB_CONN_MAX_IDLE_TIME", 0),
+	})
+	if err != nil {
+		log.Fatalf("ストレージの初期化に失敗しました: %v", err)
+	}
+	var weatherRepo repositories.WeatherRepository = infrarepo.NewWeatherRepository(weatherProvider)
+
+	// 天気データのキャッシュ（WEATHER_CACHE_TTL=0 で無効化）
+	// 天気サービスの障害時は WEATHER_CACHE_STALE_TTL の間、最後に取得できた天気を Stale を付けて返す
+	// 統計情報は /debug/vars の weather_cache で確認できる
+	if ttl := envDuration("WEATHER_CACHE_TTL", 10*time.Minute); ttl > 0 {
+		cachedWeatherRepo := infrarepo.NewCachedWeatherRepository(weatherRepo, infrarepo.WeatherCacheOptions{
+			TTL:              ttl,
+			StaleTTL:         envDuration("WEATHER_CACHE_STALE_TTL", time.Hour),
+			GeohashPrecision: envInt("WEATHER_CACHE_GEOHASH_PRECISION", 5),
+		})
+		expvar.Publish("weather_cache", expvar.Func(func() interface{} {
+			return cachedWeatherRepo.Stats()
+		}))
+		weatherRepo = cachedWeatherRepo
+	}
 
 	fashionService := services.NewFashionRecommendationService()
 
+	// 洗濯中のアイテムを自動で着用可能に戻すまでの期間（LAUNDRY_CYCLE、未設定・0の場合は手動で戻す）
+	laundryCycle := envDuration("LAUNDRY_CYCLE", 0)
+
 	// Initialize use cases (application layer)
-	userUseCase := usecases.NewUserUseCase(userRepo, jwtSecret)
-	clothingUseCase := usecases.NewClothingUseCase(clothingRepo)
-	fashionUseCase := usecases.NewFashionUseCase(fashionService, weatherRepo, clothingRepo, fashionRepo)
-	outfitUseCase := usecases.NewOutfitUseCase(outfitRepo)
+	userUseCase := usecases.NewUserUseCase(store.users, store.uow, jwtSecret)
+	clothingUseCase := usecases.NewClothingUseCase(store.clothing, store.wearLogs, store.uow, laundryCycle)
+	// 推奨戦略（RECOMMENDATION_STRATEGY: rules / weighted、ユーザー設定で個別に上書き可能）
+	strategyName := os.Getenv("RECOMMENDATION_STRATEGY")
+	if strategyName == "" {
+		strategyName = services.StrategyRules
+	}
+	recommendationStrategy := services.NewRecommendationStrategy(strategyName)
+	if recommendationStrategy == nil {
+		log.Fatalf("Unknown recommendation strategy: %s", strategyName)
+	}
+
+	fashionUseCase := usecases.NewFashionUseCase(fashionService, weatherRepo, store.clothing, store.recommendations, store.users, store.wearLogs, store.uow, recommendationStrategy, laundryCycle)
+	outfitUseCase := usecases.NewOutfitUseCase(store.outfitPosts, store.uow)
+	weatherUseCase := usecases.NewWeatherUseCase(weatherRepo)
+
+	// アップロード画像の保存先（BLOB_STORE: local（デフォルト）/ s3）
+	blobStore, err := setupBlobStore(os.Getenv("BLOB_STORE"))
+	if err != nil {
+		log.Fatalf("画像ストレージの初期化に失敗しました: %v", err)
+	}
+	// 画像URLの署名鍵（IMAGE_URL_SECRET、未設定の場合は JWT_SECRET から HKDF で導出した別の鍵を使用）
+	imageURLKey, err := imageSigningKey(os.Getenv("IMAGE_URL_SECRET"), jwtSecret)
+	if err != nil {
+		log.Fatalf("画像URLの署名鍵の生成に失敗しました: %v", err)
+	}
+	imageService := services.NewImageService(services.ImageOptions{
+		MaxBytes:      int64(envInt("IMAGE_MAX_BYTES", 0)),
+		ThumbnailSize: envInt("IMAGE_THUMBNAIL_SIZE", 0),
+	})
+	imageUseCase := usecases.NewImageUseCase(imageService, blobStore, store.clothing, imageURLKey)
 
 	// Initialize handlers (interface layer)
 	userHandler := handlers.NewUserHandler(userUseCase)
-	clothingHandler := handlers.NewClothingHandler(clothingUseCase)
+	clothingHandler := handlers.NewClothingHandler(clothingUseCase, imageUseCase)
 	fashionHandler := handlers.NewFashionHandler(fashionUseCase)
 	outfitHandler := handlers.NewOutfitHandler(outfitUseCase)
+	weatherHandler := handlers.NewWeatherHandler(weatherUseCase)
+	imageHandler := handlers.NewImageHandler(imageUseCase)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(userUseCase)
 
 	// Setup routes
-	setupRoutes(userHandler, clothingHandler, fashionHandler, outfitHandler, authMiddleware)
+	setupRoutes(userHandler, clothingHandler, fashionHandler, outfitHandler, weatherHandler, imageHandler, authMiddleware)
 
 	log.Printf("Server starting on port %s", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
 
+// 永続化層のリポジトリ一式
+type storage struct {
+	users           repositories.UserRepository
+	clothing        repositories.ClothingRepository
+	recommendations repositories.FashionRecommendationRepository
+	outfitPosts     repositories.OutfitPostRepository
+	wearLogs        repositories.WearLogRepository
+	uow             repositories.UnitOfWork
+}
+
+/*
+	 ストレージドライバーに応じたリポジトリ生成
+*/
+func setupStorage(driver, databaseURL string, pool database.PoolOptions) (*storage, error) {
+	switch driver {
+	case "", "memory":
+		log.Println("Using in-memory storage (data is lost on restart)")
+		users := infrarepo.NewInMemoryUserRepository()
+		wearLogs := infrarepo.NewInMemoryWearLogRepository()
+		clothing := infrarepo.NewInMemoryClothingRepository(wearLogs)
+		recommendations := infrarepo.NewInMemoryFashionRecommendationRepository()
+		outfitPosts := infrarepo.NewInMemoryOutfitPostRepository()
+		return &storage{
+			users:           users,
+			clothing:        clothing,
+			recommendations: recommendations,
+			outfitPosts:     outfitPosts,
+			wearLogs:        wearLogs,
+			uow:             infrarepo.NewInMemoryUnitOfWork(users, clothing, recommendations, outfitPosts, wearLogs),
+		}, nil
+	default:
+		db, err := database.Open(driver, databaseURL, pool)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Using %s storage", db.Dialect)
+		return &storage{
+			users:           infrarepo.NewSQLUserRepository(db),
+			clothing:        infrarepo.NewSQLClothingRepository(db),
+			recommendations: infrarepo.NewSQLFashionRecommendationRepository(db),
+			outfitPosts:     infrarepo.NewSQLOutfitPostRepository(db),
+			wearLogs:        infrarepo.NewSQLWearLogRepository(db),
+			uow:             infrarepo.NewSQLUnitOfWork(db),
+		}, nil
+	}
+}
+
+/*
+	 画像ストレージの生成
+*/
+func setupBlobStore(driver string) (repositories.BlobStore, error) {
+	switch driver {
+	case "", "local":
+		// BLOB_LOCAL_DIR: 保存先ディレクトリ（デフォルトは data/blobs）
+		root := os.Getenv("BLOB_LOCAL_DIR")
+		if root == "" {
+			root = "data/blobs"
+		}
+		log.Printf("Storing uploaded images in %s", root)
+		return blobstore.NewLocalBlobStore(root)
+	case "s3":
+		// S3 互換ストレージ（AWS S3・MinIO など、MinIO では S3_PATH_STYLE=true を指定）
+		pathStyle, _ := strconv.ParseBool(os.Getenv("S3_PATH_STYLE"))
+		store, err := blobstore.NewS3BlobStore(blobstore.S3Options{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       pathStyle,
+			Timeout:         envDuration("S3_TIMEOUT", 0),
+		})
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := store.EnsureBucket(ctx); err != nil {
+			return nil, err
+		}
+		log.Printf("Storing uploaded images in S3 bucket %s", os.Getenv("S3_BUCKET"))
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown blob store: %s", driver)
+	}
+}
+
+// 画像URLの署名に使用する HKDF の info（JWT の署名鍵と用途を分離する）
+const imageSigningKeyInfo = "tenkiru image url signature v1"
+
+// 画像URLの署名鍵
+// secret が未設定の場合は jwtSecret をそのまま使わず、HKDF-SHA256 で画像URL専用の鍵を導出する
+func imageSigningKey(secret, jwtSecret string) ([]byte, error) {
+	if secret != "" {
+		return []byte(secret), nil
+	}
+	log.Println("Warning: IMAGE_URL_SECRET is not set; deriving the image URL signing key from JWT_SECRET")
+	key := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(jwtSecret), nil, []byte(imageSigningKeyInfo)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// 整数の環境変数を取得（未設定・不正な値の場合はデフォルト値）
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// 時間の環境変数を取得（"30m" などの形式、未設定・不正な値の場合はデフォルト値）
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 /*
 	 ルーティング設定
 */ 
@@ -70,6 +271,8 @@ func setupRoutes(
 	clothingHandler *handlers.ClothingHandler,
 	fashionHandler *handlers.FashionHandler,
 	outfitHandler *handlers.OutfitHandler,
+	weatherHandler *handlers.WeatherHandler,
+	imageHandler *handlers.ImageHandler,
 	authMiddleware *middleware.AuthMiddleware,
 ) {
 	// Public routes
@@ -79,14 +282,40 @@ func setupRoutes(
 	// Fashion recommendations (legacy endpoint for compatibility)
 	http.HandleFunc("/api/fashion-recommendations", authMiddleware.CORS(authMiddleware.OptionalAuth(fashionHandler.GetRecommendationsLegacy)))
 	
+	// Weather forecast (public read access)
+	http.HandleFunc("/api/weather/forecast", authMiddleware.CORS(weatherHandler.GetForecast))
+
 	// Outfit posts (public read access)
 	http.HandleFunc("/api/outfit-posts", authMiddleware.CORS(outfitHandler.GetAllOutfitPosts))
 
+	// Uploaded images (access is granted by the signature in the URL)
+	http.HandleFunc(usecases.ImageRoutePrefix, authMiddleware.CORS(imageHandler.ServeImage))
+
 	// Protected routes (require authentication)
-	http.HandleFunc("/api/profile", authMiddleware.CORS(authMiddleware.RequireAuth(userHandler.GetProfile)))
+	http.HandleFunc("/api/profile", authMiddleware.CORS(authMiddleware.RequireAuth(byMethod(map[string]http.HandlerFunc{
+		http.MethodGet: userHandler.GetProfile,
+		http.MethodPut: userHandler.UpdateProfile,
+	}))))
 	http.HandleFunc("/api/clothing", authMiddleware.CORS(authMiddleware.RequireAuth(clothingHandler.CreateClothingItem)))
-	http.HandleFunc("/api/clothing/", authMiddleware.CORS(authMiddleware.RequireAuth(clothingHandler.GetUserClothing)))
+	http.HandleFunc("/api/clothing/availability", authMiddleware.CORS(authMiddleware.RequireAuth(clothingHandler.BulkUpdateAvailability)))
+	http.HandleFunc("/api/clothing/import", authMiddleware.CORS(authMiddleware.RequireAuth(clothingHandler.ImportClothing)))
+	http.HandleFunc("/api/clothing/export", authMiddleware.CORS(authMiddleware.RequireAuth(clothingHandler.ExportClothing)))
+	http.HandleFunc("/api/clothing/", authMiddleware.CORS(authMiddleware.RequireAuth(clothingHandler.ServeClothingPath)))
 	http.HandleFunc("/api/recommendations", authMiddleware.CORS(authMiddleware.RequireAuth(fashionHandler.GetRecommendations)))
+	http.HandleFunc("/api/recommendations/", authMiddleware.CORS(authMiddleware.RequireAuth(fashionHandler.ServeRecommendationPath)))
 	http.HandleFunc("/api/outfit-posts/create", authMiddleware.CORS(authMiddleware.RequireAuth(outfitHandler.CreateOutfitPost)))
+	http.HandleFunc("/api/outfit-posts/", authMiddleware.CORS(authMiddleware.RequireAuth(outfitHandler.ServeOutfitPostPath)))
+	http.HandleFunc("/api/images", authMiddleware.CORS(authMiddleware.RequireAuth(imageHandler.UploadImage)))
 }
 
+// HTTPメソッドごとにハンドラーを振り分け
+func byMethod(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.Method]
+		if !ok {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
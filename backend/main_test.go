@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestImageSigningKey(t *testing.T) {
+	explicit, err := imageSigningKey("image-secret", "jwt-secret")
+	if err != nil {
+		t.Fatalf("imageSigningKey: %v", err)
+	}
+	if string(explicit) != "image-secret" {
+		t.Errorf("key with IMAGE_URL_SECRET = %q, want the configured secret", explicit)
+	}
+
+	derived, err := imageSigningKey("", "jwt-secret")
+	if err != nil {
+		t.Fatalf("imageSigningKey: %v", err)
+	}
+	if len(derived) != 32 {
+		t.Errorf("derived key length = %d, want 32", len(derived))
+	}
+	if bytes.Equal(derived, []byte("jwt-secret")) {
+		t.Error("derived key equals the JWT secret")
+	}
+
+	again, _ := imageSigningKey("", "jwt-secret")
+	if !bytes.Equal(derived, again) {
+		t.Error("derived key is not stable across restarts")
+	}
+	other, _ := imageSigningKey("", "another-jwt-secret")
+	if bytes.Equal(derived, other) {
+		t.Error("different JWT secrets derived the same key")
+	}
+}
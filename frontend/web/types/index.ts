@@ -83,6 +83,7 @@ export interface WeatherForecast {
   daily: WeatherForecastDaily[];
   hourly: WeatherCondition[];
   lastUpdated: string;
+  stale?: boolean;
 }
 
 export interface WeatherForecastDaily extends WeatherCondition {
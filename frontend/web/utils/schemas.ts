@@ -77,6 +77,7 @@ export const WeatherForecastSchema = z.object({
   ),
   hourly: z.array(WeatherConditionSchema),
   lastUpdated: z.string(),
+  stale: z.boolean().optional(),
 });
 
 // Clothing schemas